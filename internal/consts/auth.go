@@ -0,0 +1,11 @@
+package consts
+
+// AuthorizationHeader is the header carrying the bearer access token
+const AuthorizationHeader = "Authorization"
+
+// Context keys set by the auth middleware
+const (
+	UserIDContextKey    = "userId"
+	UserRoleContextKey  = "userRole"
+	SessionIDContextKey = "sessionId"
+)
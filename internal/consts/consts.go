@@ -11,6 +11,220 @@ var (
 		ACTIVE:   1,
 	}
 
+	LoginAttemptScope = struct {
+		ACCOUNT string
+		IP      string
+	}{
+		ACCOUNT: "account",
+		IP:      "ip",
+	}
+
+	UserRole = struct {
+		STUDENT string
+		ADMIN   string
+	}{
+		STUDENT: "student",
+		ADMIN:   "admin",
+	}
+
+	ClassSessionType = struct {
+		LECTURE  int8
+		LAB      int8
+		TUTORIAL int8
+	}{
+		LECTURE:  0,
+		LAB:      1,
+		TUTORIAL: 2,
+	}
+
+	WeekParity = struct {
+		EVERY int8
+		ODD   int8
+		EVEN  int8
+	}{
+		EVERY: 0,
+		ODD:   1, // weeks 1, 3, 5... of the semester
+		EVEN:  2, // weeks 2, 4, 6... of the semester
+	}
+
+	ReminderType = struct {
+		COURSE     int8
+		ASSIGNMENT int8
+		EXAM       int8
+	}{
+		COURSE:     0,
+		ASSIGNMENT: 1,
+		EXAM:       2,
+	}
+
+	ReminderStatus = struct {
+		PENDING   int8
+		COMPLETED int8
+		OVERDUE   int8
+	}{
+		PENDING:   0,
+		COMPLETED: 1,
+		OVERDUE:   2,
+	}
+
+	ReminderEditScope = struct {
+		ALL       string
+		THIS      string
+		FOLLOWING string
+	}{
+		ALL:       "all",       // the whole series
+		THIS:      "this",      // one occurrence, detached from its series
+		FOLLOWING: "following", // an occurrence and every later one, split into a new series
+	}
+
+	ReminderDeliveryStatus = struct {
+		PENDING   int8
+		SENDING   int8
+		SENT      int8
+		FAILED    int8
+		CANCELLED int8
+	}{
+		PENDING:   0,
+		SENDING:   1, // claimed by an instance, never picked up again
+		SENT:      2,
+		FAILED:    3, // out of attempts, or the outcome of a send is unknown
+		CANCELLED: 4, // the reminder was completed or moved before the notification went out
+	}
+
+	ReminderChannel = struct {
+		EMAIL   string
+		WEBHOOK string
+	}{
+		EMAIL:   "email",
+		WEBHOOK: "webhook",
+	}
+
+	CourseworkStatus = struct {
+		PENDING     int8
+		IN_PROGRESS int8
+		SUBMITTED   int8
+		GRADED      int8
+	}{
+		PENDING:     0,
+		IN_PROGRESS: 1,
+		SUBMITTED:   2, // handed in, or sat for an exam
+		GRADED:      3,
+	}
+
+	CourseworkType = struct {
+		ASSIGNMENT string
+		EXAM       string
+	}{
+		ASSIGNMENT: "assignment",
+		EXAM:       "exam",
+	}
+
+	NoteFormat = struct {
+		MARKDOWN string
+		JSON     string
+	}{
+		MARKDOWN: "markdown",
+		JSON:     "json", // rich-text editor document, e.g. ProseMirror
+	}
+
+	GradingScaleType = struct {
+		BANDS  int8
+		LINEAR int8
+	}{
+		BANDS:  0, // letters, numeric grades map to the band they fall into
+		LINEAR: 1, // numeric grades only, points proportional to the grade, e.g. percentages
+	}
+
+	RetakePolicy = struct {
+		LATEST string
+		BEST   string
+	}{
+		LATEST: "latest", // the attempt in the latest semester counts
+		BEST:   "best",   // the attempt with the most points counts
+	}
+
+	CourseGradeStatus = struct {
+		COUNTED    string
+		UNGRADED   string
+		PASS_FAIL  string
+		EXCLUDED   string
+		SUPERSEDED string
+		INVALID    string
+	}{
+		COUNTED:    "counted",
+		UNGRADED:   "ungraded",
+		PASS_FAIL:  "pass_fail",
+		EXCLUDED:   "excluded",
+		SUPERSEDED: "superseded", // another attempt of a retaken course counts instead
+		INVALID:    "invalid",    // the grade is not on the course's grading scale, e.g. after the scale changed
+	}
+
 	REDIS_OTP_EXPIRATION     = 60 * time.Second // 1 minute
 	REDIS_DEFAULT_EXPIRATION = 60 * time.Minute // 1 hour
+
+	REDIS_AUTH_USER_EXPIRATION      = 60 * time.Second // 1 minute
+	REDIS_PASSWORD_RESET_EXPIRATION = 30 * time.Minute // 30 minutes
+	REDIS_2FA_CHALLENGE_EXPIRATION  = 5 * time.Minute  // 5 minutes
+	REDIS_TOTP_USED_CODE_EXPIRATION = 90 * time.Second // covers the accepted clock skew
+	REDIS_SSO_STATE_EXPIRATION      = 10 * time.Minute // 10 minutes
+	REDIS_PHONE_OTP_EXPIRATION      = 5 * time.Minute  // sms can take a while to arrive
+	REDIS_EMAIL_CHANGE_EXPIRATION   = 10 * time.Minute // 10 minutes
+
+	REDIS_OTP_RESEND_COOLDOWN = 60 * time.Second // 1 minute
+	REDIS_OTP_DAILY_WINDOW    = 24 * time.Hour   // 1 day
+
+	DEFAULT_ACCESS_TOKEN_TTL  = 15 * time.Minute    // used when jwt.access_token_ttl is unset
+	DEFAULT_REFRESH_TOKEN_TTL = 30 * 24 * time.Hour // used when jwt.refresh_token_ttl is unset
+
+	LOGIN_ACCOUNT_MAX_FAILED_ATTEMPTS = 5                // failed logins per account before a lockout
+	LOGIN_IP_MAX_FAILED_ATTEMPTS      = 20               // failed logins per client ip before a lockout
+	LOGIN_FAILED_ATTEMPTS_WINDOW      = 15 * time.Minute // failed logins are forgotten after this
+	LOGIN_LOCKOUT_BASE                = 1 * time.Minute  // first lockout, doubled on every further lockout
+	LOGIN_LOCKOUT_MAX                 = 1 * time.Hour    // longest lockout
+	LOGIN_LOCKOUT_LEVEL_WINDOW        = 24 * time.Hour   // backoff level resets after this
+
+	OTP_MAX_ATTEMPTS     = 5 // failed tries before the otp is locked
+	TOTP_ISSUER          = "ScholarAI"
+	TOTP_RECOVERY_CODES  = 10 // recovery codes issued when 2fa is enabled
+	OTP_DAILY_SEND_LIMIT = 5  // verification emails per address per day
+
+	SSO_USERNAME_MAX_LENGTH = 32 // generated usernames are cut to this before the suffix
+
+	ACCOUNT_DELETION_GRACE_PERIOD = 14 * 24 * time.Hour // deletion can be cancelled until then
+	ACCOUNT_PURGE_INTERVAL        = 1 * time.Hour       // how often due accounts are hard deleted
+	ACCOUNT_PURGE_BATCH_SIZE      = 100                 // accounts hard deleted per run
+
+	COURSE_MIN_CREDITS = 0  // e.g. non-credit seminars
+	COURSE_MAX_CREDITS = 30 // upper bound for a single course
+
+	GRADING_MAX_BANDS          = 30   // letters per band scale
+	GRADING_MAX_POINTS float64 = 100  // highest grade points a scale may award, percentage scales
+	GRADING_MAX_SCORE  float64 = 1000 // highest numeric grade a scale may accept
+
+	GPA_TARGET_MAX_SEARCH       = 1_000_000 // letter combinations searched, larger plans come without combinations
+	GPA_TARGET_MAX_COMBINATIONS = 20        // combinations returned per plan
+
+	ASSESSMENT_WEIGHT_TOLERANCE = 0.01 // how far the weights of a course may sum from 100 percent
+
+	TAG_DEFAULT_COLOR = "#808080"
+
+	REMINDER_OVERDUE_SWEEP_INTERVAL = 1 * time.Minute // how often past-due reminders are marked overdue
+	REMINDER_MAX_RANGE_DAYS         = 366             // longest date range recurring reminders are expanded over
+
+	DEFAULT_REMINDER_OFFSETS       = []time.Duration{7 * 24 * time.Hour, 3 * 24 * time.Hour, 24 * time.Hour} // used when reminder.offsets is unset
+	DEFAULT_REMINDER_MAX_ATTEMPTS  = 5                                                                       // used when reminder.max_attempts is unset
+	REMINDER_DELIVERY_INTERVAL     = 1 * time.Minute                                                         // how often notifications are planned and sent
+	REMINDER_DELIVERY_BATCH_SIZE   = 100                                                                     // notifications sent per run
+	REMINDER_DELIVERY_RETRY_BASE   = 1 * time.Minute                                                         // first retry delay, doubled on every further failure
+	REMINDER_DELIVERY_LOCK_TTL     = 2 * time.Minute                                                         // longer than one send may take
+	REMINDER_DELIVERY_SEND_TIMEOUT = 30 * time.Second                                                        // bounds a single channel send
+	REMINDER_DELIVERY_STALE_AFTER  = 10 * time.Minute                                                        // a send still claimed after this is given up
+
+	CALENDAR_IMPORT_MAX_BYTES  int64 = 1 << 20 // largest .ics file accepted for import, 1 MiB
+	CALENDAR_IMPORT_MAX_EVENTS       = 2000    // events and to-dos per imported file
+
+	DEFAULT_COURSEWORK_REMINDER_OFFSETS = []time.Duration{3 * 24 * time.Hour, 24 * time.Hour} // used when reminder.coursework is unset
+	COURSEWORK_UPCOMING_DAYS            = 14                                                  // how far ahead the upcoming feed looks by default
+
+	NOTE_DIFF_CONTEXT = 3 // unchanged lines shown around every change of a revision diff
 )
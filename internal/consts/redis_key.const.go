@@ -3,4 +3,49 @@ package consts
 const (
 	// otp for email verification (%s: user's email)
 	REDIS_KEY_URS_OTP_PREFIX = "urs:%s:otp" //
+	// failed otp attempts for email verification (%s: user's email)
+	REDIS_KEY_URS_OTP_ATTEMPTS_PREFIX = "urs:%s:otp:attempts"
+	// resend cooldown for email verification (%s: user's email)
+	REDIS_KEY_URS_OTP_COOLDOWN_PREFIX = "urs:%s:otp:cooldown"
+	// otp sent today for email verification (%s: user's email)
+	REDIS_KEY_URS_OTP_DAILY_PREFIX = "urs:%s:otp:daily"
+	// otp for phone verification (%s: user id, %s: phone number)
+	REDIS_KEY_URS_PHONE_OTP_PREFIX = "urs:%s:phone:%s:otp"
+	// failed otp attempts for phone verification (%s: user id, %s: phone number)
+	REDIS_KEY_URS_PHONE_OTP_ATTEMPTS_PREFIX = "urs:%s:phone:%s:otp:attempts"
+	// resend cooldown for phone verification (%s: user id)
+	REDIS_KEY_URS_PHONE_OTP_COOLDOWN_PREFIX = "urs:%s:phone:otp:cooldown"
+	// otp sent today for phone verification (%s: user id)
+	REDIS_KEY_URS_PHONE_OTP_DAILY_PREFIX = "urs:%s:phone:otp:daily"
+	// pending email change with the otp sent to the new address (%s: user id)
+	REDIS_KEY_URS_EMAIL_CHANGE_PREFIX = "urs:%s:email:change"
+	// failed otp attempts for an email change (%s: user id)
+	REDIS_KEY_URS_EMAIL_CHANGE_ATTEMPTS_PREFIX = "urs:%s:email:change:attempts"
+
+	// revoked session, checked against access tokens until they expire (%s: session id)
+	REDIS_KEY_AUTH_REVOKED_SESSION_PREFIX = "auth:session:%s:revoked"
+	// user loaded by the auth middleware (%s: user id)
+	REDIS_KEY_AUTH_USER_PREFIX = "auth:user:%s"
+	// password reset token owner (%s: sha256 of the reset token)
+	REDIS_KEY_AUTH_PASSWORD_RESET_PREFIX = "auth:pwreset:%s"
+	// latest password reset token of a user (%s: user id)
+	REDIS_KEY_URS_PASSWORD_RESET_PREFIX = "urs:%s:pwreset"
+	// pending second login step (%s: sha256 of the challenge token)
+	REDIS_KEY_AUTH_2FA_CHALLENGE_PREFIX = "auth:2fa:challenge:%s"
+	// failed codes for a login challenge (%s: sha256 of the challenge token)
+	REDIS_KEY_AUTH_2FA_CHALLENGE_ATTEMPTS_PREFIX = "auth:2fa:challenge:%s:attempts"
+	// totp code already accepted, blocks replays (%s: user id, %s: code)
+	REDIS_KEY_URS_TOTP_USED_PREFIX = "urs:%s:totp:%s"
+	// pending sso authorization request (%s: sha256 of the state)
+	REDIS_KEY_AUTH_SSO_STATE_PREFIX = "auth:sso:state:%s"
+
+	// failed logins in the current window (%s: consts.LoginAttemptScope, %s: user id or client ip)
+	REDIS_KEY_AUTH_LOGIN_FAILS_PREFIX = "auth:login:%s:%s:fails"
+	// active lockout, expires when the lockout ends (%s: consts.LoginAttemptScope, %s: user id or client ip)
+	REDIS_KEY_AUTH_LOGIN_LOCK_PREFIX = "auth:login:%s:%s:lock"
+	// number of lockouts used for exponential backoff (%s: consts.LoginAttemptScope, %s: user id or client ip)
+	REDIS_KEY_AUTH_LOGIN_LEVEL_PREFIX = "auth:login:%s:%s:level"
+
+	// instance currently sending a reminder notification (%d: reminder delivery id)
+	REDIS_KEY_REMINDER_DELIVERY_LOCK_PREFIX = "reminder:delivery:%d:lock"
 )
@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"github.com/nas03/scholar-ai/backend/internal/services"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+)
+
+type AdminController struct {
+	authService    services.IAuthService
+	gradingService services.IGradingService
+}
+
+func NewAdminController(authService services.IAuthService, gradingService services.IGradingService) *AdminController {
+	return &AdminController{
+		authService:    authService,
+		gradingService: gradingService,
+	}
+}
+
+func (c *AdminController) GetLoginAttempts(ctx *gin.Context) {
+	var query models.LoginAttemptQuery
+
+	// Validate query binding
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	statuses, code := c.authService.GetLoginAttempts(ctx, query.Identifier, query.IP)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, statuses)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+// System grading scales belong to no user, the grading service takes an empty user ID for them
+
+func (c *AdminController) CreateGradingScale(ctx *gin.Context) {
+	var payload models.GradingScaleRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	scale, code := c.gradingService.CreateGradingScale(ctx, "", &payload)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, scale)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *AdminController) UpdateGradingScale(ctx *gin.Context) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	var payload models.GradingScaleRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	scale, code := c.gradingService.UpdateGradingScale(ctx, "", id, &payload)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, scale)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *AdminController) DeleteGradingScale(ctx *gin.Context) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	code := c.gradingService.DeleteGradingScale(ctx, "", id)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, nil)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
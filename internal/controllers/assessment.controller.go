@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nas03/scholar-ai/backend/internal/helper"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"github.com/nas03/scholar-ai/backend/internal/services"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+)
+
+type AssessmentController struct {
+	assessmentService services.IAssessmentService
+}
+
+func NewAssessmentController(assessmentService services.IAssessmentService) *AssessmentController {
+	return &AssessmentController{
+		assessmentService: assessmentService,
+	}
+}
+
+func (c *AssessmentController) GetAssessments(ctx *gin.Context) {
+	courseID, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	assessment, code := c.assessmentService.GetAssessments(ctx, helper.GetUserID(ctx), courseID)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, assessment)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *AssessmentController) ReplaceAssessments(ctx *gin.Context) {
+	courseID, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	var payload models.AssessmentsRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	assessment, code := c.assessmentService.ReplaceAssessments(ctx, helper.GetUserID(ctx), courseID, &payload)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, assessment)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *AssessmentController) UpdateAssessmentScore(ctx *gin.Context) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	var payload models.AssessmentScoreRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	assessment, code := c.assessmentService.UpdateAssessmentScore(ctx, helper.GetUserID(ctx), id, &payload)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, assessment)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
@@ -0,0 +1,143 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nas03/scholar-ai/backend/internal/consts"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"github.com/nas03/scholar-ai/backend/internal/services"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+)
+
+type AuthController struct {
+	authService services.IAuthService
+}
+
+func NewAuthController(authService services.IAuthService) *AuthController {
+	return &AuthController{
+		authService: authService,
+	}
+}
+
+func (c *AuthController) Login(ctx *gin.Context) {
+	var payload models.LoginRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	result, code := c.authService.Login(ctx, payload.Identifier, payload.Password, ctx.Request.UserAgent(), ctx.ClientIP())
+
+	switch code {
+	case response.CodeSuccess:
+		response.SuccessResponse(ctx, code, result.Tokens)
+	case response.CodeTwoFactorRequired:
+		data := map[string]interface{}{
+			"challengeToken": result.ChallengeToken,
+			"expiresIn":      int64(consts.REDIS_2FA_CHALLENGE_EXPIRATION.Seconds()),
+		}
+		response.SuccessResponse(ctx, code, data)
+	case response.CodeLoginLocked:
+		ctx.Header("Retry-After", strconv.FormatInt(result.RetryAfter, 10))
+		response.ErrorResponseWithContent(ctx, code, map[string]interface{}{"retryAfter": result.RetryAfter})
+	default:
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *AuthController) VerifyTwoFactorLogin(ctx *gin.Context) {
+	var payload models.TwoFactorLoginRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	result, code := c.authService.VerifyTwoFactorLogin(ctx, payload.ChallengeToken, payload.Code, payload.RecoveryCode, ctx.Request.UserAgent(), ctx.ClientIP())
+
+	switch code {
+	case response.CodeSuccess:
+		response.SuccessResponse(ctx, code, result.Tokens)
+	case response.CodeLoginLocked:
+		ctx.Header("Retry-After", strconv.FormatInt(result.RetryAfter, 10))
+		response.ErrorResponseWithContent(ctx, code, map[string]interface{}{"retryAfter": result.RetryAfter})
+	default:
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *AuthController) RefreshToken(ctx *gin.Context) {
+	var payload models.RefreshTokenRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	tokens, code := c.authService.RefreshToken(ctx, payload.RefreshToken)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, tokens)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *AuthController) Logout(ctx *gin.Context) {
+	var payload models.RefreshTokenRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	code := c.authService.Logout(ctx, payload.RefreshToken)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, nil)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *AuthController) ForgotPassword(ctx *gin.Context) {
+	var payload models.ForgotPasswordRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	code := c.authService.ForgotPassword(ctx, payload.Email)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, nil)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *AuthController) ResetPassword(ctx *gin.Context) {
+	var payload models.ResetPasswordRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	code := c.authService.ResetPassword(ctx, payload.Token, payload.NewPassword)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, nil)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
@@ -0,0 +1,127 @@
+package controllers
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nas03/scholar-ai/backend/internal/consts"
+	"github.com/nas03/scholar-ai/backend/internal/helper"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"github.com/nas03/scholar-ai/backend/internal/services"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+)
+
+const calendarContentType = "text/calendar; charset=utf-8"
+
+type CalendarController struct {
+	calendarService       services.ICalendarService
+	calendarImportService services.ICalendarImportService
+}
+
+func NewCalendarController(calendarService services.ICalendarService, calendarImportService services.ICalendarImportService) *CalendarController {
+	return &CalendarController{
+		calendarService:       calendarService,
+		calendarImportService: calendarImportService,
+	}
+}
+
+func (c *CalendarController) ExportCalendar(ctx *gin.Context) {
+	calendar, code := c.calendarService.ExportCalendar(ctx, helper.GetUserID(ctx))
+
+	if code == response.CodeSuccess {
+		ctx.Header("Content-Disposition", `attachment; filename="scholar-ai.ics"`)
+		ctx.Data(http.StatusOK, calendarContentType, []byte(calendar))
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *CalendarController) GetSubscription(ctx *gin.Context) {
+	subscription, code := c.calendarService.GetSubscription(ctx, helper.GetUserID(ctx))
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, subscription)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *CalendarController) RotateSubscription(ctx *gin.Context) {
+	subscription, code := c.calendarService.RotateSubscription(ctx, helper.GetUserID(ctx))
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, subscription)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *CalendarController) RevokeSubscription(ctx *gin.Context) {
+	code := c.calendarService.RevokeSubscription(ctx, helper.GetUserID(ctx))
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, nil)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+// GetFeed serves the calendar to calendar apps polling the subscription URL. They only understand
+// HTTP statuses, so failures are answered with a status instead of a response code.
+func (c *CalendarController) GetFeed(ctx *gin.Context) {
+	token := strings.TrimSuffix(ctx.Param("token"), ".ics")
+	calendar, code := c.calendarService.GetFeed(ctx, token)
+
+	switch code {
+	case response.CodeSuccess:
+		ctx.Data(http.StatusOK, calendarContentType, []byte(calendar))
+	case response.CodeCalendarFeedNotFound, response.CodeUserNotFound:
+		ctx.AbortWithStatus(http.StatusNotFound)
+	default:
+		ctx.AbortWithStatus(http.StatusInternalServerError)
+	}
+}
+
+// ImportCalendar takes a multipart form with the .ics "file" and the fields of models.CalendarImportRequest
+func (c *CalendarController) ImportCalendar(ctx *gin.Context) {
+	var request models.CalendarImportRequest
+
+	// Validate form binding
+	if err := ctx.ShouldBind(&request); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+	if header.Size > consts.CALENDAR_IMPORT_MAX_BYTES {
+		response.ErrorResponse(ctx, response.CodeInvalidCalendarFile, "")
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidCalendarFile, "")
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, consts.CALENDAR_IMPORT_MAX_BYTES))
+	if err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidCalendarFile, "")
+		return
+	}
+
+	preview, code := c.calendarImportService.ImportCalendar(ctx, helper.GetUserID(ctx), string(data), request)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, preview)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
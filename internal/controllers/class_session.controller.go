@@ -0,0 +1,128 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nas03/scholar-ai/backend/internal/helper"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"github.com/nas03/scholar-ai/backend/internal/services"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+)
+
+type ClassSessionController struct {
+	classSessionService services.IClassSessionService
+}
+
+func NewClassSessionController(classSessionService services.IClassSessionService) *ClassSessionController {
+	return &ClassSessionController{
+		classSessionService: classSessionService,
+	}
+}
+
+func (c *ClassSessionController) CreateClassSession(ctx *gin.Context) {
+	var payload models.ClassSessionRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	result, code := c.classSessionService.CreateClassSession(ctx, helper.GetUserID(ctx), &payload)
+	writeClassSessionResult(ctx, result, code)
+}
+
+func (c *ClassSessionController) GetClassSessions(ctx *gin.Context) {
+	var filter models.ClassSessionFilter
+
+	// Validate query binding
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	sessions, code := c.classSessionService.GetClassSessions(ctx, helper.GetUserID(ctx), filter)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, sessions)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *ClassSessionController) GetClassSession(ctx *gin.Context) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	session, code := c.classSessionService.GetClassSession(ctx, helper.GetUserID(ctx), id)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, session)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *ClassSessionController) UpdateClassSession(ctx *gin.Context) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	var payload models.ClassSessionRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	result, code := c.classSessionService.UpdateClassSession(ctx, helper.GetUserID(ctx), id, &payload)
+	writeClassSessionResult(ctx, result, code)
+}
+
+func (c *ClassSessionController) DeleteClassSession(ctx *gin.Context) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	code := c.classSessionService.DeleteClassSession(ctx, helper.GetUserID(ctx), id)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, nil)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *ClassSessionController) GetTimetable(ctx *gin.Context) {
+	var query models.TimetableQuery
+
+	// Validate query binding
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	timetable, code := c.classSessionService.GetTimetable(ctx, helper.GetUserID(ctx), query)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, timetable)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+// writeClassSessionResult answers a create or update, a conflict carries the overlapped sessions
+func writeClassSessionResult(ctx *gin.Context, result *models.ClassSessionResult, code int) {
+	switch code {
+	case response.CodeSuccess:
+		response.SuccessResponse(ctx, code, result)
+	case response.CodeClassSessionConflict:
+		response.ErrorResponseWithContent(ctx, code, result.Conflicts)
+	default:
+		response.ErrorResponse(ctx, code, "")
+	}
+}
@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nas03/scholar-ai/backend/internal/helper"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"github.com/nas03/scholar-ai/backend/internal/services"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+)
+
+type CourseController struct {
+	courseService services.ICourseService
+}
+
+func NewCourseController(courseService services.ICourseService) *CourseController {
+	return &CourseController{
+		courseService: courseService,
+	}
+}
+
+func (c *CourseController) CreateCourse(ctx *gin.Context) {
+	var payload models.CourseRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	course, code := c.courseService.CreateCourse(ctx, helper.GetUserID(ctx), &payload)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, course)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *CourseController) GetCourses(ctx *gin.Context) {
+	var filter models.CourseFilter
+
+	// Validate query binding
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	courses, code := c.courseService.GetCourses(ctx, helper.GetUserID(ctx), filter)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, courses)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *CourseController) GetCourse(ctx *gin.Context) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	course, code := c.courseService.GetCourse(ctx, helper.GetUserID(ctx), id)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, course)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *CourseController) UpdateCourse(ctx *gin.Context) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	var payload models.CourseRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	course, code := c.courseService.UpdateCourse(ctx, helper.GetUserID(ctx), id, &payload)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, course)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *CourseController) DeleteCourse(ctx *gin.Context) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	code := c.courseService.DeleteCourse(ctx, helper.GetUserID(ctx), id)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, nil)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+// getIDParam parses the ":id" path parameter, responding with CodeInvalidInput when it is not a positive integer
+func getIDParam(ctx *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || id <= 0 {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, "invalid id")
+		return 0, false
+	}
+	return id, true
+}
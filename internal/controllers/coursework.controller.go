@@ -0,0 +1,179 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nas03/scholar-ai/backend/internal/helper"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"github.com/nas03/scholar-ai/backend/internal/services"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+)
+
+type CourseworkController struct {
+	courseworkService services.ICourseworkService
+}
+
+func NewCourseworkController(courseworkService services.ICourseworkService) *CourseworkController {
+	return &CourseworkController{
+		courseworkService: courseworkService,
+	}
+}
+
+func (c *CourseworkController) CreateAssignment(ctx *gin.Context) {
+	createCoursework(ctx, c.courseworkService.CreateAssignment)
+}
+
+func (c *CourseworkController) GetAssignments(ctx *gin.Context) {
+	listCoursework(ctx, c.courseworkService.GetAssignments)
+}
+
+func (c *CourseworkController) GetAssignment(ctx *gin.Context) {
+	getCoursework(ctx, c.courseworkService.GetAssignment)
+}
+
+func (c *CourseworkController) UpdateAssignment(ctx *gin.Context) {
+	updateCoursework(ctx, c.courseworkService.UpdateAssignment)
+}
+
+func (c *CourseworkController) UpdateAssignmentStatus(ctx *gin.Context) {
+	updateCoursework(ctx, c.courseworkService.UpdateAssignmentStatus)
+}
+
+func (c *CourseworkController) DeleteAssignment(ctx *gin.Context) {
+	deleteCoursework(ctx, c.courseworkService.DeleteAssignment)
+}
+
+func (c *CourseworkController) CreateExam(ctx *gin.Context) {
+	createCoursework(ctx, c.courseworkService.CreateExam)
+}
+
+func (c *CourseworkController) GetExams(ctx *gin.Context) {
+	listCoursework(ctx, c.courseworkService.GetExams)
+}
+
+func (c *CourseworkController) GetExam(ctx *gin.Context) {
+	getCoursework(ctx, c.courseworkService.GetExam)
+}
+
+func (c *CourseworkController) UpdateExam(ctx *gin.Context) {
+	updateCoursework(ctx, c.courseworkService.UpdateExam)
+}
+
+func (c *CourseworkController) UpdateExamStatus(ctx *gin.Context) {
+	updateCoursework(ctx, c.courseworkService.UpdateExamStatus)
+}
+
+func (c *CourseworkController) DeleteExam(ctx *gin.Context) {
+	deleteCoursework(ctx, c.courseworkService.DeleteExam)
+}
+
+func (c *CourseworkController) GetUpcoming(ctx *gin.Context) {
+	var query models.UpcomingQuery
+
+	// Validate query binding
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	items, code := c.courseworkService.GetUpcoming(ctx, helper.GetUserID(ctx), query)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, items)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+// The handlers below are shared by assignments and exams, P is the request payload and T what is returned
+
+func createCoursework[P, T any](ctx *gin.Context, create func(context.Context, string, *P) (*T, int)) {
+	var payload P
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	item, code := create(ctx, helper.GetUserID(ctx), &payload)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, item)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func listCoursework[T any](ctx *gin.Context, list func(context.Context, string, models.CourseworkFilter) ([]*T, int)) {
+	var filter models.CourseworkFilter
+
+	// Validate query binding
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	items, code := list(ctx, helper.GetUserID(ctx), filter)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, items)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func getCoursework[T any](ctx *gin.Context, get func(context.Context, string, int) (*T, int)) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	item, code := get(ctx, helper.GetUserID(ctx), id)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, item)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+// updateCoursework serves both the full update and the status change
+func updateCoursework[P, T any](ctx *gin.Context, update func(context.Context, string, int, *P) (*T, int)) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	var payload P
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	item, code := update(ctx, helper.GetUserID(ctx), id, &payload)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, item)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func deleteCoursework(ctx *gin.Context, del func(context.Context, string, int) int) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	code := del(ctx, helper.GetUserID(ctx), id)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, nil)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
@@ -0,0 +1,182 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nas03/scholar-ai/backend/internal/helper"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"github.com/nas03/scholar-ai/backend/internal/services"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+)
+
+type GradingController struct {
+	gradingService services.IGradingService
+}
+
+func NewGradingController(gradingService services.IGradingService) *GradingController {
+	return &GradingController{
+		gradingService: gradingService,
+	}
+}
+
+func (c *GradingController) CreateGradingScale(ctx *gin.Context) {
+	var payload models.GradingScaleRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	scale, code := c.gradingService.CreateGradingScale(ctx, helper.GetUserID(ctx), &payload)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, scale)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *GradingController) GetGradingScales(ctx *gin.Context) {
+	scales, code := c.gradingService.GetGradingScales(ctx, helper.GetUserID(ctx))
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, scales)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *GradingController) GetGradingScale(ctx *gin.Context) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	scale, code := c.gradingService.GetGradingScale(ctx, helper.GetUserID(ctx), id)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, scale)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *GradingController) UpdateGradingScale(ctx *gin.Context) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	var payload models.GradingScaleRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	scale, code := c.gradingService.UpdateGradingScale(ctx, helper.GetUserID(ctx), id, &payload)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, scale)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *GradingController) DeleteGradingScale(ctx *gin.Context) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	code := c.gradingService.DeleteGradingScale(ctx, helper.GetUserID(ctx), id)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, nil)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *GradingController) GetGPA(ctx *gin.Context) {
+	var query models.GpaQuery
+
+	// Validate query binding
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	report, code := c.gradingService.GetGPA(ctx, helper.GetUserID(ctx), query)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, report)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *GradingController) PlanTargetGPA(ctx *gin.Context) {
+	var payload models.GpaTargetRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	plan, code := c.gradingService.PlanTargetGPA(ctx, helper.GetUserID(ctx), &payload)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, plan)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *GradingController) SimulateGPA(ctx *gin.Context) {
+	var payload models.GpaWhatIfRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	whatIf, code := c.gradingService.SimulateGPA(ctx, helper.GetUserID(ctx), &payload)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, whatIf)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *GradingController) GetGpaSetting(ctx *gin.Context) {
+	setting, code := c.gradingService.GetGpaSetting(ctx, helper.GetUserID(ctx))
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, setting)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *GradingController) UpdateGpaSetting(ctx *gin.Context) {
+	var payload models.GpaSettingRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	setting, code := c.gradingService.UpdateGpaSetting(ctx, helper.GetUserID(ctx), &payload)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, setting)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
@@ -0,0 +1,115 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nas03/scholar-ai/backend/internal/helper"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"github.com/nas03/scholar-ai/backend/internal/services"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+)
+
+type LecturerController struct {
+	lecturerService services.ILecturerService
+}
+
+func NewLecturerController(lecturerService services.ILecturerService) *LecturerController {
+	return &LecturerController{
+		lecturerService: lecturerService,
+	}
+}
+
+func (c *LecturerController) CreateLecturer(ctx *gin.Context) {
+	var payload models.LecturerRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	lecturer, code := c.lecturerService.CreateLecturer(ctx, helper.GetUserID(ctx), &payload)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, lecturer)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *LecturerController) GetLecturers(ctx *gin.Context) {
+	lecturers, code := c.lecturerService.GetLecturers(ctx, helper.GetUserID(ctx))
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, lecturers)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *LecturerController) GetLecturer(ctx *gin.Context) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	lecturer, code := c.lecturerService.GetLecturer(ctx, helper.GetUserID(ctx), id)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, lecturer)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *LecturerController) UpdateLecturer(ctx *gin.Context) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	var payload models.LecturerRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	lecturer, code := c.lecturerService.UpdateLecturer(ctx, helper.GetUserID(ctx), id, &payload)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, lecturer)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *LecturerController) DeleteLecturer(ctx *gin.Context) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	code := c.lecturerService.DeleteLecturer(ctx, helper.GetUserID(ctx), id)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, nil)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *LecturerController) GetLecturerCourses(ctx *gin.Context) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	courses, code := c.lecturerService.GetLecturerCourses(ctx, helper.GetUserID(ctx), id)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, courses)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
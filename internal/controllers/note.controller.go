@@ -0,0 +1,195 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nas03/scholar-ai/backend/internal/helper"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"github.com/nas03/scholar-ai/backend/internal/services"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+)
+
+type NoteController struct {
+	noteService services.INoteService
+}
+
+func NewNoteController(noteService services.INoteService) *NoteController {
+	return &NoteController{
+		noteService: noteService,
+	}
+}
+
+func (c *NoteController) CreateNote(ctx *gin.Context) {
+	var payload models.NoteRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	note, code := c.noteService.CreateNote(ctx, helper.GetUserID(ctx), &payload)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, note)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *NoteController) GetNotes(ctx *gin.Context) {
+	var filter models.NoteFilter
+
+	// Validate query binding
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	notes, code := c.noteService.GetNotes(ctx, helper.GetUserID(ctx), filter)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, notes)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *NoteController) GetNote(ctx *gin.Context) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	note, code := c.noteService.GetNote(ctx, helper.GetUserID(ctx), id)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, note)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *NoteController) UpdateNote(ctx *gin.Context) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	var payload models.NoteRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	note, code := c.noteService.UpdateNote(ctx, helper.GetUserID(ctx), id, &payload)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, note)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *NoteController) DeleteNote(ctx *gin.Context) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	code := c.noteService.DeleteNote(ctx, helper.GetUserID(ctx), id)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, nil)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *NoteController) GetNoteRevisions(ctx *gin.Context) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	revisions, code := c.noteService.GetNoteRevisions(ctx, helper.GetUserID(ctx), id)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, revisions)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *NoteController) GetNoteRevision(ctx *gin.Context) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+	version, ok := getVersionParam(ctx)
+	if !ok {
+		return
+	}
+
+	revision, code := c.noteService.GetNoteRevision(ctx, helper.GetUserID(ctx), id, version)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, revision)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *NoteController) DiffNoteRevisions(ctx *gin.Context) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	var query models.NoteDiffQuery
+
+	// Validate query binding
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	diff, code := c.noteService.DiffNoteRevisions(ctx, helper.GetUserID(ctx), id, query)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, diff)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *NoteController) RestoreNoteRevision(ctx *gin.Context) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+	version, ok := getVersionParam(ctx)
+	if !ok {
+		return
+	}
+
+	note, code := c.noteService.RestoreNoteRevision(ctx, helper.GetUserID(ctx), id, version)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, note)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func getVersionParam(ctx *gin.Context) (int, bool) {
+	version, err := strconv.Atoi(ctx.Param("version"))
+	if err != nil || version <= 0 {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, "invalid version")
+		return 0, false
+	}
+	return version, true
+}
@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nas03/scholar-ai/backend/internal/helper"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"github.com/nas03/scholar-ai/backend/internal/services"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+)
+
+type PhoneController struct {
+	phoneService services.IPhoneService
+}
+
+func NewPhoneController(phoneService services.IPhoneService) *PhoneController {
+	return &PhoneController{
+		phoneService: phoneService,
+	}
+}
+
+func (c *PhoneController) AddPhoneNumber(ctx *gin.Context) {
+	var payload models.AddPhoneNumberRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	phoneNumber, code := c.phoneService.AddPhoneNumber(ctx, helper.GetUserID(ctx), payload.PhoneNumber)
+
+	if code == response.CodeSuccess {
+		data := map[string]interface{}{"phoneNumber": phoneNumber, "isPhoneVerified": false}
+		response.SuccessResponse(ctx, code, data)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *PhoneController) SendPhoneOtp(ctx *gin.Context) {
+	cooldown, code := c.phoneService.SendPhoneOtp(ctx, helper.GetUserID(ctx))
+
+	data := map[string]interface{}{"cooldownSeconds": cooldown}
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, data)
+	} else if code == response.CodeOTPResendCooldown {
+		response.ErrorResponseWithContent(ctx, code, data)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *PhoneController) VerifyPhoneNumber(ctx *gin.Context) {
+	var payload models.VerifyPhoneRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	code := c.phoneService.VerifyPhoneNumber(ctx, helper.GetUserID(ctx), payload.Otp)
+
+	if code == response.CodeSuccess {
+		data := map[string]interface{}{"isPhoneVerified": true}
+		response.SuccessResponse(ctx, code, data)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
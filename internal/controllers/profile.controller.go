@@ -0,0 +1,116 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nas03/scholar-ai/backend/internal/helper"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"github.com/nas03/scholar-ai/backend/internal/services"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+)
+
+type ProfileController struct {
+	profileService services.IProfileService
+}
+
+func NewProfileController(profileService services.IProfileService) *ProfileController {
+	return &ProfileController{
+		profileService: profileService,
+	}
+}
+
+func (c *ProfileController) GetProfile(ctx *gin.Context) {
+	profile, code := c.profileService.GetProfile(ctx, helper.GetUserID(ctx))
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, profile)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *ProfileController) UpdateProfile(ctx *gin.Context) {
+	var payload models.UpdateProfileRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	profile, code := c.profileService.UpdateProfile(ctx, helper.GetUserID(ctx), &payload)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, profile)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *ProfileController) RequestEmailChange(ctx *gin.Context) {
+	var payload models.ChangeEmailRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	cooldown, code := c.profileService.RequestEmailChange(ctx, helper.GetUserID(ctx), payload.NewEmail, payload.Password)
+
+	data := map[string]interface{}{"cooldownSeconds": cooldown}
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, data)
+	} else if code == response.CodeOTPResendCooldown {
+		response.ErrorResponseWithContent(ctx, code, data)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *ProfileController) ConfirmEmailChange(ctx *gin.Context) {
+	var payload models.ConfirmEmailChangeRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	email, code := c.profileService.ConfirmEmailChange(ctx, helper.GetUserID(ctx), payload.Otp)
+
+	if code == response.CodeSuccess {
+		data := map[string]interface{}{"email": email}
+		response.SuccessResponse(ctx, code, data)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *ProfileController) DeleteAccount(ctx *gin.Context) {
+	var payload models.DeleteAccountRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	deleteAt, code := c.profileService.RequestDeletion(ctx, helper.GetUserID(ctx), payload.Password)
+
+	if code == response.CodeSuccess {
+		data := map[string]interface{}{"deletionScheduledAt": deleteAt}
+		response.SuccessResponse(ctx, code, data)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *ProfileController) CancelDeletion(ctx *gin.Context) {
+	code := c.profileService.CancelDeletion(ctx, helper.GetUserID(ctx))
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, nil)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
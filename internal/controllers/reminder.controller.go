@@ -0,0 +1,165 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nas03/scholar-ai/backend/internal/helper"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"github.com/nas03/scholar-ai/backend/internal/services"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+)
+
+type ReminderController struct {
+	reminderService services.IReminderService
+}
+
+func NewReminderController(reminderService services.IReminderService) *ReminderController {
+	return &ReminderController{
+		reminderService: reminderService,
+	}
+}
+
+func (c *ReminderController) CreateReminder(ctx *gin.Context) {
+	var payload models.ReminderRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	reminder, code := c.reminderService.CreateReminder(ctx, helper.GetUserID(ctx), &payload)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, reminder)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *ReminderController) GetReminders(ctx *gin.Context) {
+	var filter models.ReminderFilter
+
+	// Validate query binding
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	reminders, code := c.reminderService.GetReminders(ctx, helper.GetUserID(ctx), filter)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, reminders)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *ReminderController) GetOccurrences(ctx *gin.Context) {
+	var query models.ReminderRangeQuery
+
+	// Validate query binding
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	occurrences, code := c.reminderService.GetOccurrences(ctx, helper.GetUserID(ctx), query)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, occurrences)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *ReminderController) GetReminder(ctx *gin.Context) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	reminder, code := c.reminderService.GetReminder(ctx, helper.GetUserID(ctx), id)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, reminder)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *ReminderController) UpdateReminder(ctx *gin.Context) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	var query models.ReminderOccurrenceQuery
+
+	// Validate query binding
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	var payload models.ReminderRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	reminder, code := c.reminderService.UpdateReminder(ctx, helper.GetUserID(ctx), id, query, &payload)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, reminder)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *ReminderController) DeleteReminder(ctx *gin.Context) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	var query models.ReminderOccurrenceQuery
+
+	// Validate query binding
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	code := c.reminderService.DeleteReminder(ctx, helper.GetUserID(ctx), id, query)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, nil)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *ReminderController) CompleteReminder(ctx *gin.Context) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	var query models.ReminderOccurrenceQuery
+
+	// Validate query binding
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	reminder, code := c.reminderService.CompleteReminder(ctx, helper.GetUserID(ctx), id, query.OccurrenceDate)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, reminder)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nas03/scholar-ai/backend/internal/helper"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"github.com/nas03/scholar-ai/backend/internal/services"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+)
+
+type SemesterController struct {
+	semesterService services.ISemesterService
+}
+
+func NewSemesterController(semesterService services.ISemesterService) *SemesterController {
+	return &SemesterController{
+		semesterService: semesterService,
+	}
+}
+
+func (c *SemesterController) CreateSemester(ctx *gin.Context) {
+	var payload models.SemesterRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	semester, code := c.semesterService.CreateSemester(ctx, helper.GetUserID(ctx), &payload)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, semester)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *SemesterController) GetSemesters(ctx *gin.Context) {
+	semesters, code := c.semesterService.GetSemesters(ctx, helper.GetUserID(ctx))
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, semesters)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *SemesterController) GetCurrentSemester(ctx *gin.Context) {
+	var query models.CurrentSemesterQuery
+
+	// Validate query binding
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	semester, code := c.semesterService.GetCurrentSemester(ctx, helper.GetUserID(ctx), query.Date)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, semester)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *SemesterController) GetSemester(ctx *gin.Context) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	semester, code := c.semesterService.GetSemester(ctx, helper.GetUserID(ctx), id)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, semester)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *SemesterController) UpdateSemester(ctx *gin.Context) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	var payload models.SemesterRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	semester, code := c.semesterService.UpdateSemester(ctx, helper.GetUserID(ctx), id, &payload)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, semester)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *SemesterController) DeleteSemester(ctx *gin.Context) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	code := c.semesterService.DeleteSemester(ctx, helper.GetUserID(ctx), id)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, nil)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nas03/scholar-ai/backend/internal/consts"
+	"github.com/nas03/scholar-ai/backend/internal/helper"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"github.com/nas03/scholar-ai/backend/internal/services"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+)
+
+type SsoController struct {
+	ssoService services.ISsoService
+}
+
+func NewSsoController(ssoService services.ISsoService) *SsoController {
+	return &SsoController{
+		ssoService: ssoService,
+	}
+}
+
+func (c *SsoController) GetProviders(ctx *gin.Context) {
+	response.SuccessResponse(ctx, response.CodeSuccess, c.ssoService.GetProviders())
+}
+
+func (c *SsoController) Authorize(ctx *gin.Context) {
+	c.authorize(ctx, "")
+}
+
+func (c *SsoController) AuthorizeLink(ctx *gin.Context) {
+	c.authorize(ctx, helper.GetUserID(ctx))
+}
+
+func (c *SsoController) Callback(ctx *gin.Context) {
+	var payload models.SsoCallbackRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	result, code := c.ssoService.Callback(ctx, ctx.Param("provider"), payload.Code, payload.State, ctx.Request.UserAgent(), ctx.ClientIP())
+
+	switch code {
+	case response.CodeSuccess:
+		response.SuccessResponse(ctx, code, result.Tokens)
+	case response.CodeTwoFactorRequired:
+		data := map[string]interface{}{
+			"challengeToken": result.ChallengeToken,
+			"expiresIn":      int64(consts.REDIS_2FA_CHALLENGE_EXPIRATION.Seconds()),
+		}
+		response.SuccessResponse(ctx, code, data)
+	default:
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *SsoController) LinkCallback(ctx *gin.Context) {
+	var payload models.SsoCallbackRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	identity, code := c.ssoService.Link(ctx, helper.GetUserID(ctx), ctx.Param("provider"), payload.Code, payload.State)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, identity)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *SsoController) GetIdentities(ctx *gin.Context) {
+	identities, code := c.ssoService.GetIdentities(ctx, helper.GetUserID(ctx))
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, identities)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *SsoController) Unlink(ctx *gin.Context) {
+	code := c.ssoService.Unlink(ctx, helper.GetUserID(ctx), ctx.Param("provider"))
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, nil)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *SsoController) authorize(ctx *gin.Context, userID string) {
+	authURL, code := c.ssoService.Authorize(ctx, ctx.Param("provider"), userID)
+
+	if code == response.CodeSuccess {
+		data := map[string]interface{}{"authorizationUrl": authURL}
+		response.SuccessResponse(ctx, code, data)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nas03/scholar-ai/backend/internal/helper"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"github.com/nas03/scholar-ai/backend/internal/services"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+)
+
+type TagController struct {
+	tagService services.ITagService
+}
+
+func NewTagController(tagService services.ITagService) *TagController {
+	return &TagController{
+		tagService: tagService,
+	}
+}
+
+func (c *TagController) CreateTag(ctx *gin.Context) {
+	var payload models.TagRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	tag, code := c.tagService.CreateTag(ctx, helper.GetUserID(ctx), &payload)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, tag)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *TagController) GetTags(ctx *gin.Context) {
+	tags, code := c.tagService.GetTags(ctx, helper.GetUserID(ctx))
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, tags)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *TagController) GetTag(ctx *gin.Context) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	tag, code := c.tagService.GetTag(ctx, helper.GetUserID(ctx), id)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, tag)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *TagController) UpdateTag(ctx *gin.Context) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	var payload models.TagRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	tag, code := c.tagService.UpdateTag(ctx, helper.GetUserID(ctx), id, &payload)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, tag)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *TagController) DeleteTag(ctx *gin.Context) {
+	id, ok := getIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	code := c.tagService.DeleteTag(ctx, helper.GetUserID(ctx), id)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, nil)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *TagController) AttachTags(ctx *gin.Context) {
+	var payload models.TagAssignmentRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	code := c.tagService.AttachTags(ctx, helper.GetUserID(ctx), &payload)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, nil)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *TagController) DetachTags(ctx *gin.Context) {
+	var payload models.TagAssignmentRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	code := c.tagService.DetachTags(ctx, helper.GetUserID(ctx), &payload)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, nil)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
@@ -0,0 +1,66 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nas03/scholar-ai/backend/internal/helper"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"github.com/nas03/scholar-ai/backend/internal/services"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+)
+
+type TwoFactorController struct {
+	twoFactorService services.ITwoFactorService
+}
+
+func NewTwoFactorController(twoFactorService services.ITwoFactorService) *TwoFactorController {
+	return &TwoFactorController{
+		twoFactorService: twoFactorService,
+	}
+}
+
+func (c *TwoFactorController) Enroll(ctx *gin.Context) {
+	enrollment, code := c.twoFactorService.Enroll(ctx, helper.GetUserID(ctx))
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, enrollment)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *TwoFactorController) Confirm(ctx *gin.Context) {
+	var payload models.TwoFactorConfirmRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	recoveryCodes, code := c.twoFactorService.Confirm(ctx, helper.GetUserID(ctx), payload.Code)
+
+	if code == response.CodeSuccess {
+		data := map[string]interface{}{"recoveryCodes": recoveryCodes}
+		response.SuccessResponse(ctx, code, data)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *TwoFactorController) Disable(ctx *gin.Context) {
+	var payload models.TwoFactorDisableRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	code := c.twoFactorService.Disable(ctx, helper.GetUserID(ctx), payload.Password)
+
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, nil)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
@@ -44,3 +44,43 @@ func (c *UserController) CreateUser(ctx *gin.Context) {
 		response.ErrorResponse(ctx, code, "")
 	}
 }
+
+func (c *UserController) VerifyUserEmail(ctx *gin.Context) {
+	var payload models.VerifyEmailRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	code := c.userService.VerifyUserEmail(ctx, payload.Otp, payload.Email)
+
+	if code == response.CodeSuccess {
+		data := map[string]interface{}{"isEmailVerified": true}
+		response.SuccessResponse(ctx, code, data)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
+
+func (c *UserController) ResendVerificationEmail(ctx *gin.Context) {
+	var payload models.ResendVerificationRequest
+
+	// Validate JSON binding
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		response.ErrorResponse(ctx, response.CodeInvalidInput, err.Error())
+		return
+	}
+
+	cooldown, code := c.userService.ResendVerificationEmail(ctx, payload.Email)
+
+	data := map[string]interface{}{"cooldownSeconds": cooldown}
+	if code == response.CodeSuccess {
+		response.SuccessResponse(ctx, code, data)
+	} else if code == response.CodeOTPResendCooldown {
+		response.ErrorResponseWithContent(ctx, code, data)
+	} else {
+		response.ErrorResponse(ctx, code, "")
+	}
+}
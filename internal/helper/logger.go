@@ -3,6 +3,7 @@ package helper
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/consts"
 	"github.com/nas03/scholar-ai/backend/internal/utils/requestid"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -14,6 +15,9 @@ func LogWithRequestID(c *gin.Context, level zapcore.Level, msg string, fields ..
 	if requestID != "" {
 		fields = append(fields, zap.String("requestId", requestID))
 	}
+	if userID := GetUserID(c); userID != "" {
+		fields = append(fields, zap.String("userId", userID))
+	}
 
 	global.Log.Check(level, msg).Write(fields...)
 }
@@ -42,3 +46,18 @@ func LogDebug(c *gin.Context, msg string, fields ...zap.Field) {
 func GetRequestID(c *gin.Context) string {
 	return requestid.GetRequestIDFromContext(c)
 }
+
+// GetUserID returns the authenticated user's ID set by the auth middleware
+func GetUserID(c *gin.Context) string {
+	return c.GetString(consts.UserIDContextKey)
+}
+
+// GetUserRole returns the authenticated user's role set by the auth middleware
+func GetUserRole(c *gin.Context) string {
+	return c.GetString(consts.UserRoleContextKey)
+}
+
+// GetSessionID returns the session of the access token used for the request
+func GetSessionID(c *gin.Context) string {
+	return c.GetString(consts.SessionIDContextKey)
+}
@@ -0,0 +1,158 @@
+package helper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/pkg/setting"
+	"golang.org/x/oauth2"
+)
+
+// oidcHTTPTimeout bounds discovery and key requests to the issuer
+const oidcHTTPTimeout = 10 * time.Second
+
+// OidcClaims are the id token claims used to sign a user in
+type OidcClaims struct {
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+}
+
+// IOidcProvider runs the authorization code flow with PKCE against one OpenID Connect issuer
+type IOidcProvider interface {
+	// AuthCodeURL builds the authorization request for the given state, nonce and PKCE verifier
+	AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error)
+	// Exchange redeems the code and returns the claims of the verified id token
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*OidcClaims, error)
+}
+
+type OidcProvider struct {
+	setting setting.OidcProviderSetting
+	client  *http.Client
+
+	// Discovery runs on first use so an unreachable issuer does not stop the server
+	mu       sync.Mutex
+	config   *oauth2.Config
+	verifier *oidc.IDTokenVerifier
+}
+
+// NewOidcProvider creates a provider for any issuer that serves a discovery document,
+// including a local mock issuer
+func NewOidcProvider(providerSetting setting.OidcProviderSetting) IOidcProvider {
+	return &OidcProvider{
+		setting: providerSetting,
+		client:  &http.Client{Timeout: oidcHTTPTimeout},
+	}
+}
+
+// NewOidcProviders creates the providers configured in global.Config.Oidc keyed by name
+func NewOidcProviders() map[string]IOidcProvider {
+	providers := make(map[string]IOidcProvider, len(global.Config.Oidc.Providers))
+	for name, providerSetting := range global.Config.Oidc.Providers {
+		providers[name] = NewOidcProvider(providerSetting)
+	}
+	return providers
+}
+
+func (p *OidcProvider) AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error) {
+	config, _, err := p.discover()
+	if err != nil {
+		return "", err
+	}
+
+	return config.AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(codeVerifier)), nil
+}
+
+func (p *OidcProvider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*OidcClaims, error) {
+	config, verifier, err := p.discover()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = oidc.ClientContext(ctx, p.client)
+	token, err := config.Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, errors.New("token response has no id_token")
+	}
+	idToken, err := verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify id token: %w", err)
+	}
+	if idToken.Nonce != nonce {
+		return nil, errors.New("id token nonce does not match")
+	}
+
+	var claims struct {
+		Email             string      `json:"email"`
+		EmailVerified     interface{} `json:"email_verified"` // some issuers send "true" as a string
+		Name              string      `json:"name"`
+		PreferredUsername string      `json:"preferred_username"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("failed to decode id token claims: %w", err)
+	}
+
+	emailVerified := false
+	switch v := claims.EmailVerified.(type) {
+	case bool:
+		emailVerified = v
+	case string:
+		emailVerified, _ = strconv.ParseBool(v)
+	case nil:
+		emailVerified = p.setting.TrustEmail
+	}
+
+	return &OidcClaims{
+		Subject:           idToken.Subject,
+		Email:             claims.Email,
+		EmailVerified:     emailVerified && claims.Email != "",
+		Name:              claims.Name,
+		PreferredUsername: claims.PreferredUsername,
+	}, nil
+}
+
+// discover loads the issuer metadata and keys once, retrying on the next call after a failure
+func (p *OidcProvider) discover() (*oauth2.Config, *oidc.IDTokenVerifier, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.config != nil {
+		return p.config, p.verifier, nil
+	}
+
+	// The key set keeps this context for background refreshes, so it must not be the request context
+	discoveryCtx := oidc.ClientContext(context.Background(), p.client)
+	provider, err := oidc.NewProvider(discoveryCtx, p.setting.IssuerURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to discover oidc issuer '%s': %w", p.setting.IssuerURL, err)
+	}
+
+	scopes := p.setting.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{oidc.ScopeOpenID, "email", "profile"}
+	}
+
+	p.config = &oauth2.Config{
+		ClientID:     p.setting.ClientID,
+		ClientSecret: p.setting.ClientSecret,
+		RedirectURL:  p.setting.RedirectURL,
+		Endpoint:     provider.Endpoint(),
+		Scopes:       scopes,
+	}
+	p.verifier = provider.Verifier(&oidc.Config{ClientID: p.setting.ClientID})
+	return p.config, p.verifier, nil
+}
@@ -0,0 +1,270 @@
+package helper
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/nas03/scholar-ai/backend/pkg/setting"
+)
+
+const (
+	mockClientID = "scholar-ai"
+	mockCode     = "auth-code"
+	mockKeyID    = "mock-key"
+)
+
+// mockIssuer is an OpenID Connect issuer serving discovery, keys and a token endpoint
+type mockIssuer struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey // published in the key set
+	signer *rsa.PrivateKey // signs id tokens, key unless a test swaps it
+
+	// claims are signed into the id token, challenge is the PKCE challenge the code was issued for
+	claims    jwt.MapClaims
+	challenge string
+}
+
+func newMockIssuer(t *testing.T) *mockIssuer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	issuer := &mockIssuer{key: key, signer: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{
+			"issuer":                                issuer.server.URL,
+			"authorization_endpoint":                issuer.server.URL + "/authorize",
+			"token_endpoint":                        issuer.server.URL + "/token",
+			"jwks_uri":                              issuer.server.URL + "/keys",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"alg": "RS256",
+				"use": "sig",
+				"kid": mockKeyID,
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("code") != mockCode {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		verifier := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if base64.RawURLEncoding.EncodeToString(verifier[:]) != issuer.challenge {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, issuer.claims)
+		token.Header["kid"] = mockKeyID
+		idToken, err := token.SignedString(issuer.signer)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, map[string]interface{}{
+			"access_token": "access-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     idToken,
+		})
+	})
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+	return issuer
+}
+
+// signIn runs the authorization code flow of provider against the issuer
+func (m *mockIssuer) signIn(t *testing.T, provider IOidcProvider, nonce string) (*OidcClaims, error) {
+	t.Helper()
+
+	const verifier = "a-code-verifier-long-enough-for-pkce-0123456789"
+	authURL, err := provider.AuthCodeURL(context.Background(), "state", nonce, verifier)
+	if err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("parse auth url: %v", err)
+	}
+	query := parsed.Query()
+	if query.Get("code_challenge_method") != "S256" || query.Get("nonce") != nonce || query.Get("state") != "state" {
+		t.Fatalf("auth url misses PKCE, nonce or state: %s", authURL)
+	}
+	m.challenge = query.Get("code_challenge")
+
+	return provider.Exchange(context.Background(), mockCode, verifier, nonce)
+}
+
+func (m *mockIssuer) provider(trustEmail bool) IOidcProvider {
+	return NewOidcProvider(setting.OidcProviderSetting{
+		IssuerURL:   m.server.URL,
+		ClientID:    mockClientID,
+		RedirectURL: "http://localhost/callback",
+		TrustEmail:  trustEmail,
+	})
+}
+
+func (m *mockIssuer) idClaims(nonce string, extra map[string]interface{}) jwt.MapClaims {
+	claims := jwt.MapClaims{
+		"iss":   m.server.URL,
+		"aud":   mockClientID,
+		"sub":   "subject-1",
+		"nonce": nonce,
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}
+	for k, v := range extra {
+		claims[k] = v
+	}
+	return claims
+}
+
+func writeJSON(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func TestOidcProviderExchange(t *testing.T) {
+	issuer := newMockIssuer(t)
+
+	tests := []struct {
+		name       string
+		extra      map[string]interface{}
+		trustEmail bool
+		tokenNonce string // nonce put into the id token, the requested one when empty
+		want       *OidcClaims
+		wantErr    string
+	}{
+		{
+			name:  "verified email",
+			extra: map[string]interface{}{"email": "ada@example.com", "email_verified": true, "name": "Ada", "preferred_username": "ada"},
+			want:  &OidcClaims{Subject: "subject-1", Email: "ada@example.com", EmailVerified: true, Name: "Ada", PreferredUsername: "ada"},
+		},
+		{
+			name:  "email_verified as a string",
+			extra: map[string]interface{}{"email": "ada@example.com", "email_verified": "true"},
+			want:  &OidcClaims{Subject: "subject-1", Email: "ada@example.com", EmailVerified: true},
+		},
+		{
+			name:  "unverified email",
+			extra: map[string]interface{}{"email": "ada@example.com", "email_verified": false},
+			want:  &OidcClaims{Subject: "subject-1", Email: "ada@example.com"},
+		},
+		{
+			name:       "missing email_verified trusted by setting",
+			extra:      map[string]interface{}{"email": "ada@example.com"},
+			trustEmail: true,
+			want:       &OidcClaims{Subject: "subject-1", Email: "ada@example.com", EmailVerified: true},
+		},
+		{
+			name:  "missing email_verified not trusted",
+			extra: map[string]interface{}{"email": "ada@example.com"},
+			want:  &OidcClaims{Subject: "subject-1", Email: "ada@example.com"},
+		},
+		{
+			name:       "nonce mismatch",
+			tokenNonce: "another-nonce",
+			wantErr:    "nonce does not match",
+		},
+		{
+			name:    "wrong audience",
+			extra:   map[string]interface{}{"aud": "another-client"},
+			wantErr: "failed to verify id token",
+		},
+		{
+			name:    "expired token",
+			extra:   map[string]interface{}{"exp": time.Now().Add(-time.Hour).Unix()},
+			wantErr: "failed to verify id token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const nonce = "nonce-1"
+			tokenNonce := tt.tokenNonce
+			if tokenNonce == "" {
+				tokenNonce = nonce
+			}
+			issuer.claims = issuer.idClaims(tokenNonce, tt.extra)
+
+			claims, err := issuer.signIn(t, issuer.provider(tt.trustEmail), nonce)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Exchange() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Exchange() error = %v", err)
+			}
+			if *claims != *tt.want {
+				t.Errorf("Exchange() = %+v, want %+v", *claims, *tt.want)
+			}
+		})
+	}
+}
+
+func TestOidcProviderRejectsForeignSignature(t *testing.T) {
+	issuer := newMockIssuer(t)
+	issuer.claims = issuer.idClaims("nonce-1", nil)
+
+	// Sign with a key the issuer does not publish
+	foreign, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	issuer.signer = foreign
+
+	if _, err := issuer.signIn(t, issuer.provider(false), "nonce-1"); err == nil || !strings.Contains(err.Error(), "failed to verify id token") {
+		t.Fatalf("Exchange() error = %v, want a verification error", err)
+	}
+}
+
+func TestOidcProviderRejectsWrongVerifier(t *testing.T) {
+	issuer := newMockIssuer(t)
+	issuer.claims = issuer.idClaims("nonce-1", nil)
+	provider := issuer.provider(false)
+
+	if _, err := provider.AuthCodeURL(context.Background(), "state", "nonce-1", "the-right-verifier-0123456789-abcdefghijklmnop"); err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+	issuer.challenge = "challenge-of-another-verifier"
+
+	if _, err := provider.Exchange(context.Background(), mockCode, "the-right-verifier-0123456789-abcdefghijklmnop", "nonce-1"); err == nil {
+		t.Fatal("Exchange() succeeded with a verifier that does not match the challenge")
+	}
+}
+
+func TestOidcProviderUnreachableIssuer(t *testing.T) {
+	issuer := newMockIssuer(t)
+	provider := issuer.provider(false)
+	issuer.server.Close()
+
+	if _, err := provider.AuthCodeURL(context.Background(), "state", "nonce", "verifier"); err == nil || !strings.Contains(err.Error(), "failed to discover") {
+		t.Fatalf("AuthCodeURL() error = %v, want a discovery error", err)
+	}
+}
@@ -0,0 +1,147 @@
+package helper
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"time"
+
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/consts"
+	"go.uber.org/zap"
+)
+
+// webhookTimeout bounds a webhook request
+const webhookTimeout = 10 * time.Second
+
+// ReminderNotification is what a channel tells the user about a reminder coming due
+type ReminderNotification struct {
+	ReminderID  int            `json:"reminder_id"`
+	UserID      string         `json:"user_id"`
+	Email       string         `json:"-"`
+	Title       string         `json:"title"`
+	Description string         `json:"description,omitempty"`
+	CourseName  string         `json:"course_name,omitempty"`
+	DueAt       time.Time      `json:"due_at"`
+	Location    *time.Location `json:"-"` // the user's timezone, used to format DueAt
+	Offset      time.Duration  `json:"-"` // how long before DueAt the notification is sent
+}
+
+// IReminderChannel delivers reminder notifications through one medium
+type IReminderChannel interface {
+	Send(ctx context.Context, notification *ReminderNotification) error
+}
+
+// NewReminderChannels returns the channels enabled by reminder.channels keyed by name, email when unset
+func NewReminderChannels() map[string]IReminderChannel {
+	names := global.Config.Reminder.Channels
+	if len(names) == 0 {
+		names = []string{consts.ReminderChannel.EMAIL}
+	}
+
+	channels := make(map[string]IReminderChannel, len(names))
+	for _, name := range names {
+		switch name {
+		case consts.ReminderChannel.EMAIL:
+			channels[name] = NewEmailReminderChannel(NewMailHelper())
+		case consts.ReminderChannel.WEBHOOK:
+			if global.Config.Reminder.WebhookURL == "" {
+				global.Log.Warn("Reminder webhook channel enabled without reminder.webhook_url, skipping it")
+				continue
+			}
+			channels[name] = NewWebhookReminderChannel(global.Config.Reminder.WebhookURL, global.Config.Reminder.WebhookSecret)
+		default:
+			global.Log.Warn("Unknown reminder channel, skipping it", zap.String("channel", name))
+		}
+	}
+	return channels
+}
+
+// EmailReminderChannel mails the notification to the user's address
+type EmailReminderChannel struct {
+	mailHelper IMailHelper
+}
+
+func NewEmailReminderChannel(mailHelper IMailHelper) IReminderChannel {
+	return &EmailReminderChannel{
+		mailHelper: mailHelper,
+	}
+}
+
+func (c *EmailReminderChannel) Send(ctx context.Context, notification *ReminderNotification) error {
+	if notification.Email == "" {
+		return errors.New("user has no email address")
+	}
+
+	dueAt := notification.DueAt.In(notification.Location).Format("Mon, 02 Jan 2006 15:04 MST")
+	body := fmt.Sprintf("<p><strong>%s</strong> is due on %s.</p>", html.EscapeString(notification.Title), dueAt)
+	if notification.CourseName != "" {
+		body += fmt.Sprintf("<p>Course: %s</p>", html.EscapeString(notification.CourseName))
+	}
+	if notification.Description != "" {
+		body += fmt.Sprintf("<p>%s</p>", html.EscapeString(notification.Description))
+	}
+
+	_, err := c.mailHelper.SendMail(ctx, notification.Email, fmt.Sprintf("ScholarAI Reminder: %s", notification.Title), body)
+	return err
+}
+
+// WebhookReminderChannel posts the notification as JSON, signed with
+// an "X-ScholarAI-Signature: sha256=<hex hmac of the body>" header when a secret is set
+type WebhookReminderChannel struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+func NewWebhookReminderChannel(url, secret string) IReminderChannel {
+	return &WebhookReminderChannel{
+		url:    url,
+		secret: secret,
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+func (c *WebhookReminderChannel) Send(ctx context.Context, notification *ReminderNotification) error {
+	body, err := json.Marshal(struct {
+		Event string `json:"event"`
+		*ReminderNotification
+		OffsetSeconds int64 `json:"offset_seconds"`
+	}{
+		Event:                "reminder.due",
+		ReminderNotification: notification,
+		OffsetSeconds:        int64(notification.Offset.Seconds()),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.secret != "" {
+		mac := hmac.New(sha256.New, []byte(c.secret))
+		mac.Write(body)
+		req.Header.Set("X-ScholarAI-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nas03/scholar-ai/backend/global"
+	"go.uber.org/zap"
+)
+
+type ISmsHelper interface {
+	SendSms(ctx context.Context, to, body string) (string, error)
+}
+
+// NewSmsHelper returns the sender selected by sms.provider, printing to the log when unset
+func NewSmsHelper() ISmsHelper {
+	switch global.Config.Sms.Provider {
+	case "file":
+		return NewFileSmsHelper(global.Config.Sms.FilePath)
+	default:
+		return NewConsoleSmsHelper()
+	}
+}
+
+// ConsoleSmsHelper writes messages to the application log instead of sending them. For development only.
+type ConsoleSmsHelper struct{}
+
+func NewConsoleSmsHelper() ISmsHelper {
+	return &ConsoleSmsHelper{}
+}
+
+func (h *ConsoleSmsHelper) SendSms(ctx context.Context, to, body string) (string, error) {
+	id := uuid.NewString()
+	global.Log.Info("SMS (console)", zap.String("id", id), zap.String("to", to), zap.String("body", body))
+	return id, nil
+}
+
+// FileSmsHelper appends messages to a file instead of sending them. For development and tests.
+type FileSmsHelper struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewFileSmsHelper(path string) ISmsHelper {
+	return &FileSmsHelper{
+		path: path,
+	}
+}
+
+func (h *FileSmsHelper) SendSms(ctx context.Context, to, body string) (string, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	file, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return "", fmt.Errorf("failed to open sms file '%s': %w", h.path, err)
+	}
+	defer file.Close()
+
+	id := uuid.NewString()
+	if _, err := fmt.Fprintf(file, "%s\t%s\t%s\t%s\n", time.Now().Format(time.RFC3339), id, to, body); err != nil {
+		return "", fmt.Errorf("failed to send sms to '%s': %w", to, err)
+	}
+	return id, nil
+}
@@ -19,6 +19,16 @@ func GetDSN(config *setting.DatabaseSetting) string {
 		config.Username, config.Password, config.Host, config.Port, config.Name)
 }
 
+// newGormConfig configures GORM. Services tell duplicate keys and missing references apart
+// through gorm.ErrDuplicatedKey and gorm.ErrForeignKeyViolated, which need TranslateError.
+func newGormConfig() *gorm.Config {
+	return &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+		// Map duplicate key and foreign key errors to gorm.ErrDuplicatedKey / gorm.ErrForeignKeyViolated
+		TranslateError: true,
+	}
+}
+
 // InitGorm initializes and returns a GORM database connection
 func InitGorm() {
 	// Use database configuration directly from global config
@@ -50,13 +60,8 @@ func InitGorm() {
 		return
 	}
 
-	// Configure GORM
-	gormConfig := &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
-	}
-
 	// Open database connection
-	db, err := gorm.Open(mysql.Open(GetDSN(dbConfig)), gormConfig)
+	db, err := gorm.Open(mysql.Open(GetDSN(dbConfig)), newGormConfig())
 	if err != nil {
 		if global.Log != nil {
 			global.Log.Error("Failed to open database connection", zap.Error(err))
@@ -0,0 +1,56 @@
+package initialize
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	mysqlDriver "github.com/go-sql-driver/mysql"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// noConn is a connection pool that is never reached, errors are added to the session by hand
+type noConn struct{}
+
+func (noConn) PrepareContext(context.Context, string) (*sql.Stmt, error) {
+	return nil, errors.New("no connection")
+}
+
+func (noConn) ExecContext(context.Context, string, ...interface{}) (sql.Result, error) {
+	return nil, errors.New("no connection")
+}
+
+func (noConn) QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error) {
+	return nil, errors.New("no connection")
+}
+
+func (noConn) QueryRowContext(context.Context, string, ...interface{}) *sql.Row {
+	return nil
+}
+
+func TestGormConfigTranslatesMySQLErrors(t *testing.T) {
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: noConn{}, SkipInitializeWithVersion: true}), newGormConfig())
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		number uint16
+		want   error
+	}{
+		{name: "duplicate entry", number: 1062, want: gorm.ErrDuplicatedKey},
+		{name: "row is referenced", number: 1451, want: gorm.ErrForeignKeyViolated},
+		{name: "missing referenced row", number: 1452, want: gorm.ErrForeignKeyViolated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := db.Session(&gorm.Session{}).AddError(&mysqlDriver.MySQLError{Number: tt.number})
+			if !errors.Is(err, tt.want) {
+				t.Errorf("AddError(%d) = %v, want %v", tt.number, err, tt.want)
+			}
+		})
+	}
+}
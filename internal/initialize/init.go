@@ -14,5 +14,8 @@ func Bootstrap() error {
 	InitMailClient()
 	InitRedis()
 
+	// Start background jobs once the stores they use are ready
+	InitJobs()
+
 	return nil
 }
@@ -0,0 +1,75 @@
+package initialize
+
+import (
+	"context"
+	"time"
+
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/consts"
+	"github.com/nas03/scholar-ai/backend/internal/helper"
+	"github.com/nas03/scholar-ai/backend/internal/repositories"
+	"github.com/nas03/scholar-ai/backend/internal/services"
+	"go.uber.org/zap"
+)
+
+// InitJobs starts the periodic background jobs. Every job must be safe to run
+// on several instances at once.
+func InitJobs() {
+	userRepo := repositories.NewUserRepository(global.Mdb)
+	reminderRepo := repositories.NewReminderRepository(global.Mdb)
+	courseRepo := repositories.NewCourseRepository(global.Mdb)
+	reminderService := services.NewReminderService(reminderRepo, courseRepo, userRepo)
+	profileService := services.NewProfileService(userRepo, helper.NewMailHelper(), reminderService)
+	deliveryRepo := repositories.NewReminderDeliveryRepository(global.Mdb)
+	deliveryService := services.NewReminderDeliveryService(deliveryRepo, userRepo, helper.NewReminderChannels())
+
+	runPeriodically("purge-deleted-accounts", consts.ACCOUNT_PURGE_INTERVAL, func(ctx context.Context) {
+		if deleted := profileService.PurgeDueAccounts(ctx); deleted > 0 {
+			global.Log.Info("Purged deleted accounts", zap.Int("deleted", deleted))
+		}
+	})
+
+	// A single UPDATE, concurrent runs simply find nothing left to move
+	runPeriodically("mark-overdue-reminders", consts.REMINDER_OVERDUE_SWEEP_INTERVAL, func(ctx context.Context) {
+		if moved := reminderService.MarkOverdueReminders(ctx); moved > 0 {
+			global.Log.Info("Marked reminders overdue", zap.Int("moved", moved))
+		}
+	})
+
+	// Planning relies on a unique index and every send on a Redis lock plus a claim in
+	// the database, so concurrent runs neither plan nor send a notification twice
+	runPeriodically("deliver-reminders", consts.REMINDER_DELIVERY_INTERVAL, func(ctx context.Context) {
+		if planned := deliveryService.ScheduleDeliveries(ctx); planned > 0 {
+			global.Log.Info("Planned reminder notifications", zap.Int("planned", planned))
+		}
+		if sent := deliveryService.DeliverDueNotifications(ctx); sent > 0 {
+			global.Log.Info("Sent reminder notifications", zap.Int("sent", sent))
+		}
+	})
+}
+
+// runPeriodically runs job now and then every interval in its own goroutine
+func runPeriodically(name string, interval time.Duration, job func(ctx context.Context)) {
+	global.Log.Info("Background job scheduled", zap.String("job", name), zap.Duration("interval", interval))
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			runJob(name, job)
+			<-ticker.C
+		}
+	}()
+}
+
+// runJob keeps a panicking job from taking the server down
+func runJob(name string, job func(ctx context.Context)) {
+	defer func() {
+		if r := recover(); r != nil {
+			global.Log.Error("Background job panicked", zap.String("job", name), zap.Any("panic", r))
+		}
+	}()
+
+	job(context.Background())
+}
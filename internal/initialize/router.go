@@ -47,6 +47,42 @@ func InitRouter() *gin.Engine {
 		// Register user routes
 		router.SetupUserRoutes(apiV1)
 
+		// Register auth routes
+		router.SetupAuthRoutes(apiV1)
+
+		// Register admin routes
+		router.SetupAdminRoutes(apiV1)
+
+		// Register course routes
+		router.SetupCourseRoutes(apiV1)
+
+		// Register semester routes
+		router.SetupSemesterRoutes(apiV1)
+
+		// Register lecturer routes
+		router.SetupLecturerRoutes(apiV1)
+
+		// Register tag routes
+		router.SetupTagRoutes(apiV1)
+
+		// Register reminder routes
+		router.SetupReminderRoutes(apiV1)
+
+		// Register class session and timetable routes
+		router.SetupClassSessionRoutes(apiV1)
+
+		// Register calendar export, import and feed routes
+		router.SetupCalendarRoutes(apiV1)
+
+		// Register grading scale and GPA routes
+		router.SetupGradingRoutes(apiV1)
+
+		// Register assignment, exam and upcoming deadline routes
+		router.SetupCourseworkRoutes(apiV1)
+
+		// Register note and revision routes
+		router.SetupNoteRoutes(apiV1)
+
 		// Add other route groups here as needed
 		// router.SetupProductRoutes(apiV1)
 		// router.SetupOrderRoutes(apiV1)
@@ -0,0 +1,149 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nas03/scholar-ai/backend/internal/consts"
+	"github.com/nas03/scholar-ai/backend/internal/helper"
+	repo "github.com/nas03/scholar-ai/backend/internal/repositories"
+	"github.com/nas03/scholar-ai/backend/internal/utils"
+	errMessage "github.com/nas03/scholar-ai/backend/pkg/errors"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// authUser is the slice of models.User the middleware needs, cached in Redis
+type authUser struct {
+	UserID        string `json:"user_id"`
+	Role          string `json:"role"`
+	AccountStatus int8   `json:"account_status"`
+}
+
+// AuthMiddleware validates the bearer access token and injects the current user into the context
+func AuthMiddleware(userRepo repo.IUserRepository, sessionRepo repo.ISessionRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := strings.CutPrefix(c.GetHeader(consts.AuthorizationHeader), "Bearer ")
+		if !ok || token == "" {
+			abortUnauthorized(c, response.CodeUnauthorized, "missing_bearer_token")
+			return
+		}
+
+		claims, err := utils.ParseAccessToken(token)
+		if err != nil {
+			abortUnauthorized(c, response.CodeUnauthorized, err.Error())
+			return
+		}
+
+		// Access tokens of logged out or revoked sessions stay signed until they expire
+		cache := utils.NewRedisCache()
+		revoked, err := isSessionRevoked(c, cache, sessionRepo, claims.SessionID)
+		if err != nil {
+			helper.LogError(c, "Failed to check revoked session", zap.Error(err), zap.String("sessionID", claims.SessionID))
+			abortUnauthorized(c, response.CodeUnauthorized, "session_check_failed")
+			return
+		}
+		if revoked {
+			abortUnauthorized(c, response.CodeUnauthorized, errMessage.ErrSessionRevoked.Error())
+			return
+		}
+
+		user, err := loadAuthUser(c, cache, userRepo, claims.Subject)
+		if err != nil {
+			helper.LogError(c, "Failed to load authenticated user", zap.Error(err), zap.String("userID", claims.Subject))
+			abortUnauthorized(c, response.CodeUnauthorized, errMessage.ErrUserNotFound.Error())
+			return
+		}
+		if user.AccountStatus != consts.UserAccountStatus.ACTIVE {
+			abortUnauthorized(c, response.CodeAccountInactive, errMessage.ErrAccountInactive.Error())
+			return
+		}
+
+		c.Set(consts.UserIDContextKey, user.UserID)
+		c.Set(consts.UserRoleContextKey, user.Role)
+		c.Set(consts.SessionIDContextKey, claims.SessionID)
+		c.Next()
+	}
+}
+
+// RequireRole rejects users whose role is not in roles. Must run after AuthMiddleware.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := helper.GetUserRole(c)
+		for _, r := range roles {
+			if role == r {
+				c.Next()
+				return
+			}
+		}
+
+		helper.LogWarn(c, errMessage.ErrForbidden.Error(), zap.String("role", role), zap.Strings("required", roles))
+		response.ErrorResponse(c, response.CodeForbidden, "")
+		c.Abort()
+	}
+}
+
+// isSessionRevoked looks the session up in the Redis revocation list, falling back to
+// the database when Redis cannot answer so revoked tokens are never let through
+func isSessionRevoked(c *gin.Context, cache utils.IRedisCache, sessionRepo repo.ISessionRepository, sessionID string) (bool, error) {
+	_, err := cache.Get(c, fmt.Sprintf(consts.REDIS_KEY_AUTH_REVOKED_SESSION_PREFIX, sessionID))
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	helper.LogWarn(c, "Failed to check revoked session in Redis, checking the database", zap.Error(err))
+
+	session, err := sessionRepo.GetSessionByID(c, sessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return true, nil
+		}
+		return false, err
+	}
+	return session.RevokedAt.Valid, nil
+}
+
+// loadAuthUser reads the user from Redis, falling back to the database
+func loadAuthUser(ctx context.Context, cache utils.IRedisCache, userRepo repo.IUserRepository, userID string) (*authUser, error) {
+	key := fmt.Sprintf(consts.REDIS_KEY_AUTH_USER_PREFIX, userID)
+
+	var user authUser
+	if cached, err := cache.Get(ctx, key); err == nil {
+		if err := json.Unmarshal([]byte(cached), &user); err == nil {
+			return &user, nil
+		}
+	}
+
+	dbUser, err := userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	user = authUser{
+		UserID:        dbUser.UserID,
+		Role:          dbUser.Role,
+		AccountStatus: dbUser.AccountStatus,
+	}
+
+	if data, err := json.Marshal(user); err == nil {
+		_ = cache.SetEx(ctx, key, data, consts.REDIS_AUTH_USER_EXPIRATION)
+	}
+	return &user, nil
+}
+
+func abortUnauthorized(c *gin.Context, code int, reason string) {
+	helper.LogWarn(c, "Request rejected by auth middleware",
+		zap.String("method", c.Request.Method),
+		zap.String("path", c.Request.URL.Path),
+		zap.String("reason", reason),
+	)
+	response.ErrorResponse(c, code, "")
+	c.Abort()
+}
@@ -0,0 +1,30 @@
+package models
+
+// AssessmentsRequest replaces the assessment components of a course, their weights sum to 100
+type AssessmentsRequest struct {
+	Components []AssessmentComponentRequest `json:"components" binding:"omitempty,max=50,dive"` // empty removes them all, the ones left out are removed
+}
+
+type AssessmentComponentRequest struct {
+	ID       *int     `json:"id"` // an existing component of the course to update, a new one when unset
+	Name     string   `json:"name" binding:"required,max=255"`
+	Weight   float64  `json:"weight" binding:"required,gt=0,lte=100"`           // percent of the final grade
+	MaxScore float64  `json:"max_score" binding:"required,gt=0"`                // e.g. 40 for a test marked out of 40
+	Score    *float64 `json:"score" binding:"omitempty,min=0"`                  // obtained score, null until graded
+	DueDate  *string  `json:"due_date" binding:"omitempty,datetime=2006-01-02"` // calendar date in the user's timezone
+}
+
+type AssessmentScoreRequest struct {
+	Score *float64 `json:"score" binding:"omitempty,min=0"` // null clears the score
+}
+
+// CourseAssessment is the running grade of a course from its graded assessment components
+type CourseAssessment struct {
+	CourseID        int                    `json:"course_id"`
+	Components      []*AssessmentComponent `json:"components"`
+	GradedWeight    float64                `json:"graded_weight"`    // percent of the final grade graded so far
+	SecuredWeight   float64                `json:"secured_weight"`   // percent of the final grade already earned
+	RunningGrade    *float64               `json:"running_grade"`    // percent over the graded components, null until one is graded
+	ProjectedGrade  *string                `json:"projected_grade"`  // the running grade on the course's grading scale, a letter or a score
+	ProjectedPoints *float64               `json:"projected_points"` // on the course's grading scale
+}
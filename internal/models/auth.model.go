@@ -0,0 +1,81 @@
+package models
+
+type LoginRequest struct {
+	Identifier string `json:"identifier" binding:"required"` // email or username
+	Password   string `json:"password" binding:"required"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type AuthTokens struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"` // access token lifetime in seconds
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required"`
+}
+
+type LoginAttemptQuery struct {
+	Identifier string `form:"identifier"` // email or username
+	IP         string `form:"ip"`
+}
+
+// LoginAttemptStatus is the brute-force limiter state of an account or client ip
+type LoginAttemptStatus struct {
+	Scope          string `json:"scope"` // consts.LoginAttemptScope
+	Key            string `json:"key"`   // user id or client ip
+	FailedAttempts int64  `json:"failed_attempts"`
+	LockoutLevel   int64  `json:"lockout_level"`
+	Locked         bool   `json:"locked"`
+	RetryAfter     int64  `json:"retry_after"` // seconds until the lockout ends
+}
+
+// LoginResult is the outcome of the password step of a login
+type LoginResult struct {
+	Tokens         *AuthTokens // set when the login is complete
+	ChallengeToken string      // set when a second factor is required
+	RetryAfter     int64       // seconds until the caller may retry when locked out
+}
+
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code"`          // totp code
+	RecoveryCode   string `json:"recovery_code"` // used instead of code when the device is lost
+}
+
+type TwoFactorConfirmRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type TwoFactorDisableRequest struct {
+	Password string `json:"password" binding:"required"`
+}
+
+type TotpEnrollment struct {
+	Secret     string `json:"secret"`
+	OtpauthURL string `json:"otpauth_url"`
+	QRCodePNG  string `json:"qr_code_png"` // base64 encoded PNG
+}
+
+type SsoCallbackRequest struct {
+	Code  string `json:"code" binding:"required"`
+	State string `json:"state" binding:"required"`
+}
+
+// SsoState is kept in redis between the authorization request and the callback
+type SsoState struct {
+	Provider     string `json:"provider"`
+	CodeVerifier string `json:"code_verifier"` // PKCE verifier
+	Nonce        string `json:"nonce"`
+	UserID       string `json:"user_id,omitempty"` // set when a logged-in user links the provider
+}
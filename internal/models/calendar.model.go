@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// CalendarSubscription describes the user's calendar feed. The URL carries the secret token,
+// which is stored hashed, so it is only returned when the token is issued.
+type CalendarSubscription struct {
+	URL       string    `json:"url,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	RotatedAt time.Time `json:"rotated_at"` // when the current token was issued
+}
+
+// CalendarImportRequest goes with the uploaded .ics file
+type CalendarImportRequest struct {
+	SemesterID     int  `form:"semester_id" binding:"required"` // events are matched to the courses of this semester
+	CreateCourses  bool `form:"create_courses"`                 // create a course for events naming none, instead of skipping them
+	AllowConflicts bool `form:"allow_conflicts"`                // import sessions overlapping others instead of skipping them
+	Commit         bool `form:"commit"`                         // save the import, otherwise only the preview is returned
+}
+
+// CalendarImportPreview lists what an import creates. Sessions and reminders of courses to create
+// point at them through their course, ids are only set once committed.
+type CalendarImportPreview struct {
+	Committed     bool                 `json:"committed"`
+	Courses       []*Course            `json:"courses"`
+	ClassSessions []*ClassSession      `json:"class_sessions"`
+	Reminders     []*Reminder          `json:"reminders"`
+	Skipped       []CalendarImportSkip `json:"skipped"`
+	Conflicts     []SessionConflict    `json:"conflicts"` // imported sessions overlapping others, skipped unless conflicts are allowed
+	Warnings      []string             `json:"warnings"`  // e.g. a timezone that is not known, its times are read in the user's timezone
+}
+
+// SessionConflict is an imported class session with the existing or imported sessions it overlaps
+type SessionConflict struct {
+	Session *ClassSession   `json:"session"`
+	With    []*ClassSession `json:"with"`
+}
+
+// CalendarImportSkip is an event of the file that is not imported
+type CalendarImportSkip struct {
+	UID     string `json:"uid,omitempty"`
+	Summary string `json:"summary"`
+	Reason  string `json:"reason"`
+}
@@ -0,0 +1,58 @@
+package models
+
+type CourseRequest struct {
+	CourseID    string  `json:"course_id" binding:"required,max=255"` // e.g. "CS101"
+	CourseName  string  `json:"course_name" binding:"required,max=255"`
+	Description *string `json:"description"`
+	LecturerIDs []int   `json:"lecturer_ids"` // replaces the course's lecturers
+	Credits     int     `json:"credits"`
+	SemesterID  int     `json:"semester_id" binding:"required"`
+
+	Grade          *string `json:"grade" binding:"omitempty,max=16"` // a letter or a numeric grade on the grading scale, "P" or "F" for pass-fail courses
+	IsPassFail     bool    `json:"is_pass_fail"`
+	ExcludeFromGPA bool    `json:"exclude_from_gpa"`
+	GradingScaleID *int    `json:"grading_scale_id"` // the user's scale when unset
+}
+
+// Tag match modes of CourseFilter
+const (
+	TagMatchAny = "any"
+	TagMatchAll = "all"
+)
+
+// CourseFilter narrows the course list, zero values are ignored
+type CourseFilter struct {
+	SemesterID int    `form:"semester_id"`
+	TagID      int    `form:"tag_id"`
+	TagIDs     []int  `form:"tag_ids" collection_format:"csv"`         // e.g. tag_ids=1,2,3
+	Match      string `form:"match" binding:"omitempty,oneof=any all"` // how TagIDs combine, any by default
+	LecturerID int    `form:"lecturer_id"`
+}
+
+type SemesterRequest struct {
+	Name      string `json:"name" binding:"required,max=255"`                   // e.g. "Fall 2026"
+	StartDate string `json:"start_date" binding:"required,datetime=2006-01-02"` // first day
+	EndDate   string `json:"end_date" binding:"required,datetime=2006-01-02"`   // last day, inclusive
+}
+
+type CurrentSemesterQuery struct {
+	Date string `form:"date" binding:"omitempty,datetime=2006-01-02"` // defaults to today in the user's timezone
+}
+
+type LecturerRequest struct {
+	Name        string  `json:"name" binding:"required,max=255"`
+	Email       *string `json:"email" binding:"omitempty,email,max=255"`
+	Office      *string `json:"office" binding:"omitempty,max=255"`
+	OfficeHours *string `json:"office_hours" binding:"omitempty,max=255"` // e.g. "Mon 14:00-16:00"
+}
+
+type TagRequest struct {
+	Name  string `json:"name" binding:"required,max=255"`
+	Color string `json:"color" binding:"omitempty,hexcolor,len=7"` // e.g. "#808080", the default
+}
+
+// TagAssignmentRequest attaches or detaches every tag to every course
+type TagAssignmentRequest struct {
+	CourseIDs []int `json:"course_ids" binding:"required,min=1,max=100,dive,gt=0"`
+	TagIDs    []int `json:"tag_ids" binding:"required,min=1,max=100,dive,gt=0"`
+}
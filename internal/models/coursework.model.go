@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+type AssignmentRequest struct {
+	CourseID        int     `json:"course_id" binding:"required"`
+	Title           string  `json:"title" binding:"required,max=255"`
+	Description     *string `json:"description"`
+	DueDate         string  `json:"due_date" binding:"required,datetime=2006-01-02"`                   // in the user's timezone
+	DueTime         string  `json:"due_time" binding:"required,datetime=15:04"`                        // in the user's timezone
+	ComponentID     *int    `json:"component_id"`                                                      // an assessment component of the course it is graded as
+	ReminderOffsets []int   `json:"reminder_offsets" binding:"omitempty,max=10,dive,min=0,max=525600"` // minutes before the deadline, the configured ones on create and the current ones on update when unset
+}
+
+type ExamRequest struct {
+	CourseID        int     `json:"course_id" binding:"required"`
+	Title           string  `json:"title" binding:"required,max=255"`
+	Description     *string `json:"description"`
+	Date            string  `json:"date" binding:"required,datetime=2006-01-02"` // in the user's timezone
+	StartTime       string  `json:"start_time" binding:"required,datetime=15:04"`
+	EndTime         *string `json:"end_time" binding:"omitempty,datetime=15:04"` // after StartTime on the same day
+	Location        *string `json:"location" binding:"omitempty,max=255"`
+	ComponentID     *int    `json:"component_id"`                                                      // an assessment component of the course it is graded as
+	ReminderOffsets []int   `json:"reminder_offsets" binding:"omitempty,max=10,dive,min=0,max=525600"` // minutes before the start, the configured ones on create and the current ones on update when unset
+}
+
+type CourseworkStatusRequest struct {
+	Status *int8 `json:"status" binding:"required,oneof=0 1 2 3"` // consts.CourseworkStatus
+}
+
+// CourseworkFilter narrows the assignment and exam lists, zero values are ignored
+type CourseworkFilter struct {
+	CourseID int   `form:"course_id"`
+	Status   *int8 `form:"status" binding:"omitempty,oneof=0 1 2 3"` // consts.CourseworkStatus
+}
+
+type UpcomingQuery struct {
+	Days     int `form:"days" binding:"omitempty,min=1,max=365"` // how far ahead to look, 14 by default
+	CourseID int `form:"course_id"`
+}
+
+// CourseworkItem is an open assignment or exam of the upcoming feed
+type CourseworkItem struct {
+	Type       string      `json:"type"` // consts.CourseworkType
+	At         time.Time   `json:"at"`   // the deadline of an assignment, the start of an exam
+	Assignment *Assignment `json:"assignment,omitempty"`
+	Exam       *Exam       `json:"exam,omitempty"`
+}
@@ -13,40 +13,111 @@ type TableCommon struct {
 }
 
 type User struct {
-	UserID          string         `gorm:"primaryKey;type:char(36)" json:"user_id"`
-	Username        string         `gorm:"uniqueIndex;not null;size:255" json:"username"`
-	Email           string         `gorm:"uniqueIndex;not null;size:255" json:"email"`
-	Password        string         `gorm:"type:text;not null" json:"-"` // Never expose password in JSON
-	PhoneNumber     sql.NullString `gorm:"size:10" json:"phone_number,omitempty"`
-	AccountStatus   int8           `gorm:"not null;default:0" json:"account_status"`    // account status (0=inactive, 1=active)
-	IsEmailVerified int8           `gorm:"not null;default:0" json:"is_email_verified"` // email verification (0=unverified, 1=verified)
-	IsPhoneVerified int8           `gorm:"not null;default:0" json:"is_phone_verified"` // phone verification (0=unverified, 1=verified)
+	UserID              string         `gorm:"primaryKey;type:char(36)" json:"user_id"`
+	Username            string         `gorm:"uniqueIndex;not null;size:255" json:"username"`
+	Email               string         `gorm:"uniqueIndex;not null;size:255" json:"email"`
+	Password            string         `gorm:"type:text;not null" json:"-"`                  // Never expose password in JSON
+	PhoneNumber         sql.NullString `gorm:"size:16" json:"phone_number,omitempty"`        // E.164, e.g. +14155552671
+	AccountStatus       int8           `gorm:"not null;default:0" json:"account_status"`     // account status (0=inactive, 1=active)
+	IsEmailVerified     int8           `gorm:"not null;default:0" json:"is_email_verified"`  // email verification (0=unverified, 1=verified)
+	IsPhoneVerified     int8           `gorm:"not null;default:0" json:"is_phone_verified"`  // phone verification (0=unverified, 1=verified)
+	Role                string         `gorm:"not null;default:student;size:32" json:"role"` // consts.UserRole
+	TotpSecret          sql.NullString `gorm:"size:64" json:"-"`
+	IsTotpEnabled       int8           `gorm:"not null;default:0" json:"is_totp_enabled"` // two-factor login (0=disabled, 1=enabled)
+	TotpRecovery        sql.NullString `gorm:"type:text" json:"-"`                        // JSON array of hashed recovery codes
+	DisplayName         sql.NullString `gorm:"size:255" json:"display_name,omitempty"`
+	University          sql.NullString `gorm:"size:255" json:"university,omitempty"`
+	Major               sql.NullString `gorm:"size:255" json:"major,omitempty"`
+	GraduationYear      sql.NullInt16  `json:"graduation_year,omitempty"`
+	Timezone            string         `gorm:"not null;default:UTC;size:64" json:"timezone"` // IANA name, e.g. Asia/Ho_Chi_Minh
+	DeletionScheduledAt sql.NullTime   `gorm:"index" json:"deletion_scheduled_at,omitempty"` // hard delete time, null unless deletion was requested
 	TableCommon
 
 	// Relationships (one-to-many)
-	Courses []Course `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"courses,omitempty"`
+	Courses    []Course       `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"courses,omitempty"`
+	Semesters  []Semester     `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	Lecturers  []Lecturer     `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	Tags       []Tag          `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	Reminders  []Reminder     `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	Sessions   []Session      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	Identities []UserIdentity `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+
+	GradingScales []GradingScale `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	Notes         []Note         `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+
+	// Relationships (one-to-one)
+	CalendarFeed *CalendarFeed `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	GpaSetting   *GpaSetting   `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
 }
 
 func (User) TableName() string {
 	return "users"
 }
 
+// Session is a refresh token family. Every refresh rotates RefreshTokenHash;
+// presenting an older token of the family revokes the whole session.
+type Session struct {
+	SessionID        string       `gorm:"primaryKey;type:char(36)" json:"session_id"`
+	UserID           string       `gorm:"not null;index;type:char(36)" json:"user_id"`
+	RefreshTokenHash string       `gorm:"uniqueIndex;not null;type:char(64)" json:"-"` // sha256 hex of the current refresh token
+	UserAgent        string       `gorm:"size:255" json:"user_agent"`
+	IPAddress        string       `gorm:"size:45" json:"ip_address"`
+	ExpiresAt        time.Time    `gorm:"not null;index" json:"expires_at"`
+	RevokedAt        sql.NullTime `json:"revoked_at,omitempty"`
+	TableCommon
+}
+
+func (Session) TableName() string {
+	return "sessions"
+}
+
+// CalendarFeed is the secret subscription link to a user's calendar, only its token hash is stored
+type CalendarFeed struct {
+	UserID    string `gorm:"primaryKey;type:char(36)" json:"-"`
+	TokenHash string `gorm:"uniqueIndex;not null;type:char(64)" json:"-"` // sha256 hex of the feed token
+	TableCommon
+}
+
+func (CalendarFeed) TableName() string {
+	return "calendar_feeds"
+}
+
+// UserIdentity links a user to an account at an OpenID Connect provider
+type UserIdentity struct {
+	IdentityID string `gorm:"primaryKey;type:char(36)" json:"identity_id"`
+	UserID     string `gorm:"not null;type:char(36);uniqueIndex:idx_user_identities_user_provider" json:"user_id"` // one identity per provider
+	Provider   string `gorm:"not null;size:64;uniqueIndex:idx_user_identities_provider_subject;uniqueIndex:idx_user_identities_user_provider" json:"provider"`
+	Subject    string `gorm:"not null;size:255;uniqueIndex:idx_user_identities_provider_subject" json:"-"` // "sub" claim, stable per provider
+	Email      string `gorm:"size:255" json:"email"`
+	TableCommon
+}
+
+func (UserIdentity) TableName() string {
+	return "user_identities"
+}
+
 type Course struct {
-	ID          int            `gorm:"primaryKey;autoIncrement" json:"id"`
-	CourseID    string         `gorm:"not null;index;size:255" json:"course_id"` // Course identifier (e.g., "CS101")
-	CourseName  string         `gorm:"not null;size:255" json:"course_name"`
-	UserID      string         `gorm:"not null;index;type:char(36)" json:"user_id"`
-	Description sql.NullString `gorm:"type:text" json:"description,omitempty"`
-	Lecturers   string         `gorm:"type:text;not null" json:"lecturers"` // Comma-separated lecturer names
-	Credits     int            `gorm:"not null" json:"credits"`
-	GPA         float32        `gorm:"not null;default:0" json:"gpa"`
-	SemesterID  int            `gorm:"not null;index" json:"semester_id"`
+	ID          int     `gorm:"primaryKey;autoIncrement" json:"id"`
+	CourseID    string  `gorm:"not null;index;size:255;uniqueIndex:idx_courses_user_semester_course,priority:3" json:"course_id"` // Course identifier (e.g., "CS101"), unique per user and semester
+	CourseName  string  `gorm:"not null;size:255" json:"course_name"`
+	UserID      string  `gorm:"not null;index;type:char(36);uniqueIndex:idx_courses_user_semester_course,priority:1" json:"user_id"`
+	Description *string `gorm:"type:text" json:"description,omitempty"`
+	Credits     int     `gorm:"not null" json:"credits"`
+	SemesterID  int     `gorm:"not null;index;uniqueIndex:idx_courses_user_semester_course,priority:2" json:"semester_id"`
+
+	// Grading, points are derived from the raw grade through the grading scale
+	Grade          *string `gorm:"size:16" json:"grade,omitempty"`             // as given, a letter such as "B+" or a score such as "87.5"
+	IsPassFail     int8    `gorm:"not null;default:0" json:"is_pass_fail"`     // graded pass or fail, kept out of the GPA (0=no, 1=yes)
+	ExcludeFromGPA int8    `gorm:"not null;default:0" json:"exclude_from_gpa"` // kept out of the GPA, e.g. an audited course (0=no, 1=yes)
+	GradingScaleID *int    `gorm:"index" json:"grading_scale_id,omitempty"`    // overrides the user's scale, e.g. for a transfer course
 	TableCommon
 
 	// Relationships
 	// Don't include User back-ref to avoid circular JSON; fetch separately if needed
-	Semester Semester `gorm:"foreignKey:SemesterID;constraint:OnDelete:RESTRICT" json:"semester,omitempty"`
-	Tags     []Tag    `gorm:"many2many:course_tags;" json:"tags,omitempty"`
+	Semester     Semester      `gorm:"foreignKey:SemesterID;constraint:OnDelete:RESTRICT" json:"semester,omitempty"`
+	GradingScale *GradingScale `gorm:"foreignKey:GradingScaleID;constraint:OnDelete:SET NULL" json:"-"`
+	Tags         []Tag         `gorm:"many2many:course_tags;constraint:OnDelete:CASCADE" json:"tags,omitempty"`
+	Lecturers    []Lecturer    `gorm:"many2many:course_lecturers;constraint:OnDelete:CASCADE" json:"lecturers,omitempty"`
 }
 
 func (Course) TableName() string {
@@ -58,6 +129,7 @@ type Semester struct {
 	Name      string    `gorm:"not null;size:255" json:"name"`
 	StartDate time.Time `gorm:"not null;index" json:"start_date"` // Index added per SQL schema
 	EndDate   time.Time `gorm:"not null" json:"end_date"`
+	UserID    string    `gorm:"not null;index;type:char(36)" json:"user_id"`
 	TableCommon
 
 	// Relationships (one-to-many)
@@ -69,13 +141,14 @@ func (Semester) TableName() string {
 }
 
 type Tag struct {
-	ID    int    `gorm:"primaryKey;autoIncrement" json:"id"`
-	Name  string `gorm:"not null;index;size:255" json:"name"`          // Index added per SQL schema
-	Color string `gorm:"not null;default:#808080;size:7" json:"color"` // hex color
+	ID     int    `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name   string `gorm:"not null;size:255;uniqueIndex:idx_tags_user_name,priority:2" json:"name"` // unique per user
+	Color  string `gorm:"not null;default:#808080;size:7" json:"color"`                            // hex color
+	UserID string `gorm:"not null;type:char(36);uniqueIndex:idx_tags_user_name,priority:1" json:"user_id"`
 	TableCommon
 
 	// Relationships (many-to-many)
-	Courses []Course `gorm:"many2many:course_tags;" json:"courses,omitempty"`
+	Courses []Course `gorm:"many2many:course_tags;constraint:OnDelete:CASCADE" json:"courses,omitempty"`
 }
 
 func (Tag) TableName() string {
@@ -91,3 +164,311 @@ type CourseTag struct {
 func (CourseTag) TableName() string {
 	return "course_tags"
 }
+
+type Lecturer struct {
+	ID          int     `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID      string  `gorm:"not null;type:char(36);uniqueIndex:idx_lecturers_user_name,priority:1" json:"user_id"`
+	Name        string  `gorm:"not null;size:255;uniqueIndex:idx_lecturers_user_name,priority:2" json:"name"` // unique per user
+	Email       *string `gorm:"size:255" json:"email,omitempty"`
+	Office      *string `gorm:"size:255" json:"office,omitempty"`
+	OfficeHours *string `gorm:"size:255" json:"office_hours,omitempty"` // free text, e.g. "Mon 14:00-16:00"
+	TableCommon
+
+	// Relationships (many-to-many)
+	Courses []Course `gorm:"many2many:course_lecturers;constraint:OnDelete:CASCADE" json:"courses,omitempty"`
+}
+
+func (Lecturer) TableName() string {
+	return "lecturers"
+}
+
+// CourseLecturer is the explicit join table for the many2many relationship.
+type CourseLecturer struct {
+	CourseID   int `gorm:"primaryKey;index"`
+	LecturerID int `gorm:"primaryKey;index"`
+}
+
+func (CourseLecturer) TableName() string {
+	return "course_lecturers"
+}
+
+// GradingScale turns raw grades into grade points. System scales have no user, those with a university
+// are the default of the users of that university and the one marked default is everyone else's.
+type GradingScale struct {
+	ID         int     `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name       string  `gorm:"not null;size:255" json:"name"`                // e.g. "4.0 letter scale"
+	UserID     *string `gorm:"index;type:char(36)" json:"user_id,omitempty"` // null for system scales
+	University *string `gorm:"index;size:255" json:"university,omitempty"`   // system scales only
+	IsDefault  int8    `gorm:"not null;default:0" json:"is_default"`         // system scale used when nothing else applies (0=no, 1=yes)
+	Type       int8    `gorm:"not null;default:0" json:"type"`               // consts.GradingScaleType
+	MaxPoints  float64 `gorm:"not null" json:"max_points"`                   // e.g. 4.0
+	MaxScore   float64 `gorm:"not null" json:"max_score"`                    // highest numeric grade, e.g. 100
+	PassScore  float64 `gorm:"not null;default:0" json:"pass_score"`         // lowest passing numeric grade of linear scales
+	TableCommon
+
+	// Relationships
+	Bands []GradingBand `gorm:"foreignKey:ScaleID;constraint:OnDelete:CASCADE" json:"bands,omitempty"` // by min score, highest first
+}
+
+func (GradingScale) TableName() string {
+	return "grading_scales"
+}
+
+// GradingBand is a letter of a band scale, numeric grades from MinScore up fall into it
+type GradingBand struct {
+	ID        int     `gorm:"primaryKey;autoIncrement" json:"-"`
+	ScaleID   int     `gorm:"not null;uniqueIndex:idx_grading_bands_scale_letter,priority:1" json:"-"`
+	Letter    string  `gorm:"not null;size:8;uniqueIndex:idx_grading_bands_scale_letter,priority:2" json:"letter"` // e.g. "B+"
+	MinScore  float64 `gorm:"not null" json:"min_score"`
+	Points    float64 `gorm:"not null" json:"points"`
+	IsPassing int8    `gorm:"not null;default:1" json:"is_passing"` // (0=failing, 1=passing)
+}
+
+func (GradingBand) TableName() string {
+	return "grading_bands"
+}
+
+// GpaSetting holds how the GPA of a user is computed, a missing row means the defaults
+type GpaSetting struct {
+	UserID         string `gorm:"primaryKey;type:char(36)" json:"-"`
+	GradingScaleID *int   `gorm:"index" json:"grading_scale_id"`                        // null for the university or system default
+	RetakePolicy   string `gorm:"not null;default:latest;size:16" json:"retake_policy"` // consts.RetakePolicy
+	TableCommon
+
+	// Relationships
+	GradingScale *GradingScale `gorm:"foreignKey:GradingScaleID;constraint:OnDelete:SET NULL" json:"-"`
+}
+
+func (GpaSetting) TableName() string {
+	return "gpa_settings"
+}
+
+// ClassSession is a weekly time block of a course on the timetable
+type ClassSession struct {
+	ID         int     `gorm:"primaryKey;autoIncrement" json:"id"`
+	CourseID   int     `gorm:"not null;index" json:"course_id"`
+	DayOfWeek  int8    `gorm:"not null" json:"day_of_week"`           // 1 = Monday ... 7 = Sunday
+	StartTime  string  `gorm:"not null;type:time" json:"start_time"`  // wall clock time, e.g. "09:30:00"
+	EndTime    string  `gorm:"not null;type:time" json:"end_time"`    // after StartTime on the same day
+	Location   *string `gorm:"size:255" json:"location,omitempty"`    // e.g. "Room B1-203"
+	Type       int8    `gorm:"not null;default:0" json:"type"`        // consts.ClassSessionType
+	WeekParity int8    `gorm:"not null;default:0" json:"week_parity"` // consts.WeekParity, weeks counted from the semester start
+	TableCommon
+
+	// Relationships
+	Course *Course `gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE" json:"course,omitempty"`
+}
+
+func (ClassSession) TableName() string {
+	return "class_sessions"
+}
+
+// Assignment is coursework handed in by a deadline
+type Assignment struct {
+	ID              int            `gorm:"primaryKey;autoIncrement" json:"id"`
+	CourseID        int            `gorm:"not null;index" json:"course_id"`
+	Title           string         `gorm:"not null;size:255" json:"title"`
+	Description     *string        `gorm:"type:text" json:"description,omitempty"`
+	DueAt           time.Time      `gorm:"not null;index" json:"due_at"`
+	ComponentID     *int           `gorm:"index" json:"component_id,omitempty"` // the assessment component it is graded as, which carries the weight
+	ReminderOffsets sql.NullString `gorm:"size:255" json:"-"`                   // JSON array of minutes before the deadline, the reminders are rebuilt from them when it moves
+	Status          int8           `gorm:"not null;default:0" json:"status"`    // consts.CourseworkStatus
+	TableCommon
+
+	// Relationships
+	Course    *Course              `gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE" json:"course,omitempty"`
+	Component *AssessmentComponent `gorm:"foreignKey:ComponentID;constraint:OnDelete:SET NULL" json:"component,omitempty"`
+}
+
+func (Assignment) TableName() string {
+	return "assignments"
+}
+
+type Exam struct {
+	ID              int            `gorm:"primaryKey;autoIncrement" json:"id"`
+	CourseID        int            `gorm:"not null;index" json:"course_id"`
+	Title           string         `gorm:"not null;size:255" json:"title"` // e.g. "Final exam"
+	Description     *string        `gorm:"type:text" json:"description,omitempty"`
+	StartAt         time.Time      `gorm:"not null;index" json:"start_at"`
+	EndAt           *time.Time     `json:"end_at,omitempty"`
+	Location        *string        `gorm:"size:255" json:"location,omitempty"`  // e.g. "Hall A"
+	ComponentID     *int           `gorm:"index" json:"component_id,omitempty"` // the assessment component it is graded as, which carries the weight
+	ReminderOffsets sql.NullString `gorm:"size:255" json:"-"`                   // JSON array of minutes before the start, the reminders are rebuilt from them when it moves
+	Status          int8           `gorm:"not null;default:0" json:"status"`    // consts.CourseworkStatus
+	TableCommon
+
+	// Relationships
+	Course    *Course              `gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE" json:"course,omitempty"`
+	Component *AssessmentComponent `gorm:"foreignKey:ComponentID;constraint:OnDelete:SET NULL" json:"component,omitempty"`
+}
+
+func (Exam) TableName() string {
+	return "exams"
+}
+
+// AssessmentComponent is a graded part of a course such as a midterm, the weights of a course sum to 100
+type AssessmentComponent struct {
+	ID       int        `gorm:"primaryKey;autoIncrement" json:"id"`
+	CourseID int        `gorm:"not null;index" json:"course_id"`
+	Name     string     `gorm:"not null;size:255" json:"name"`       // e.g. "Midterm exam"
+	Weight   float64    `gorm:"not null" json:"weight"`              // percent of the final grade
+	MaxScore float64    `gorm:"not null" json:"max_score"`           // e.g. 40 for a test marked out of 40
+	Score    *float64   `json:"score"`                               // obtained score, null until graded
+	DueDate  *time.Time `gorm:"type:date" json:"due_date,omitempty"` // calendar date in the user's timezone
+	TableCommon
+
+	// Relationships
+	Course *Course `gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+func (AssessmentComponent) TableName() string {
+	return "assessment_components"
+}
+
+// Note is a lecture note, every save is kept as a NoteRevision
+type Note struct {
+	ID          int        `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID      string     `gorm:"not null;index;type:char(36)" json:"user_id"`
+	CourseID    *int       `gorm:"index" json:"course_id,omitempty"`
+	Title       string     `gorm:"not null;size:255" json:"title"`
+	Format      string     `gorm:"not null;default:markdown;size:16" json:"format"` // consts.NoteFormat
+	Content     string     `gorm:"type:longtext;not null" json:"content"`           // markdown text or a rich-text JSON document
+	LectureDate *time.Time `gorm:"type:date;index" json:"lecture_date,omitempty"`   // calendar date in the user's timezone
+	Version     int        `gorm:"not null;default:1" json:"version"`               // version of the latest revision
+	TableCommon
+
+	// Relationships
+	Course *Course `gorm:"foreignKey:CourseID;constraint:OnDelete:SET NULL" json:"-"`
+	Tags   []Tag   `gorm:"many2many:note_tags;constraint:OnDelete:CASCADE" json:"tags,omitempty"`
+}
+
+func (Note) TableName() string {
+	return "notes"
+}
+
+// NoteTag is the explicit join table for the many2many relationship.
+type NoteTag struct {
+	NoteID int `gorm:"primaryKey;index"`
+	TagID  int `gorm:"primaryKey;index"`
+}
+
+func (NoteTag) TableName() string {
+	return "note_tags"
+}
+
+// NoteRevision is an immutable copy of a note as saved, versions count up from 1 per note
+type NoteRevision struct {
+	ID           int        `gorm:"primaryKey;autoIncrement" json:"id"`
+	NoteID       int        `gorm:"not null;uniqueIndex:idx_note_revisions_note_version,priority:1" json:"note_id"`
+	Version      int        `gorm:"not null;uniqueIndex:idx_note_revisions_note_version,priority:2" json:"version"`
+	CourseID     *int       `json:"course_id,omitempty"` // as saved, the course may since be gone
+	Title        string     `gorm:"not null;size:255" json:"title"`
+	Format       string     `gorm:"not null;size:16" json:"format"`
+	Content      string     `gorm:"type:longtext;not null" json:"content,omitempty"` // left out of revision lists
+	LectureDate  *time.Time `gorm:"type:date" json:"lecture_date,omitempty"`
+	RestoredFrom *int       `json:"restored_from,omitempty"` // version this revision brought back
+	CreatedAt    time.Time  `json:"created_at"`
+
+	// Relationships
+	Note *Note `gorm:"foreignKey:NoteID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+func (NoteRevision) TableName() string {
+	return "note_revisions"
+}
+
+type Reminder struct {
+	ID           int        `gorm:"primaryKey;autoIncrement" json:"id"`
+	Title        string     `gorm:"not null;size:255" json:"title"`
+	Description  *string    `gorm:"type:text" json:"description,omitempty"`
+	DueDate      time.Time  `gorm:"not null;type:date" json:"due_date"`                                  // calendar date in the user's timezone
+	DueTime      string     `gorm:"not null;type:time" json:"due_time"`                                  // wall clock time in the user's timezone, e.g. "09:30:00"
+	DueAt        time.Time  `gorm:"not null;index:idx_reminders_status_due_at,priority:2" json:"due_at"` // DueDate and DueTime as an instant
+	UserID       string     `gorm:"not null;index;type:char(36)" json:"user_id"`
+	CourseID     *int       `gorm:"index" json:"course_id,omitempty"`
+	AssignmentID *int       `gorm:"index" json:"assignment_id,omitempty"`                                          // created for an assignment deadline, follows it when it moves
+	ExamID       *int       `gorm:"index" json:"exam_id,omitempty"`                                                // created for an exam start, follows it when it moves
+	Type         int8       `gorm:"not null;default:0" json:"type"`                                                // consts.ReminderType
+	Status       int8       `gorm:"not null;default:0;index:idx_reminders_status_due_at,priority:1" json:"status"` // consts.ReminderStatus, a series stays pending
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+
+	// Recurrence, DueDate and DueTime are then the first occurrence
+	RRule          *string    `gorm:"size:255" json:"rrule,omitempty"`            // e.g. "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10"
+	SeriesEndAt    *time.Time `gorm:"index" json:"series_end_at,omitempty"`       // when the last occurrence is due, nil while the series has no end
+	SeriesID       *int       `gorm:"index" json:"series_id,omitempty"`           // the series an edited occurrence was detached from
+	OccurrenceDate *time.Time `gorm:"type:date" json:"occurrence_date,omitempty"` // the date the detached occurrence had in its series
+	TableCommon
+
+	// Relationships
+	Course      *Course              `gorm:"foreignKey:CourseID;constraint:OnDelete:SET NULL" json:"course,omitempty"`
+	Assignment  *Assignment          `gorm:"foreignKey:AssignmentID;constraint:OnDelete:CASCADE" json:"-"`
+	Exam        *Exam                `gorm:"foreignKey:ExamID;constraint:OnDelete:CASCADE" json:"-"`
+	Series      *Reminder            `gorm:"foreignKey:SeriesID;constraint:OnDelete:CASCADE" json:"-"`
+	ExDates     []ReminderExDate     `gorm:"foreignKey:ReminderID;constraint:OnDelete:CASCADE" json:"exdates,omitempty"`
+	Completions []ReminderCompletion `gorm:"foreignKey:ReminderID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+func (Reminder) TableName() string {
+	return "reminders"
+}
+
+// ReminderExDate is a date a recurring reminder skips
+type ReminderExDate struct {
+	ReminderID int       `gorm:"primaryKey" json:"-"`
+	Date       time.Time `gorm:"primaryKey;type:date" json:"date"`
+}
+
+func (ReminderExDate) TableName() string {
+	return "reminder_exdates"
+}
+
+// ReminderCompletion marks one occurrence of a recurring reminder completed
+type ReminderCompletion struct {
+	ReminderID     int       `gorm:"primaryKey" json:"reminder_id"`
+	OccurrenceDate time.Time `gorm:"primaryKey;type:date" json:"occurrence_date"`
+	CompletedAt    time.Time `gorm:"not null" json:"completed_at"`
+}
+
+func (ReminderCompletion) TableName() string {
+	return "reminder_completions"
+}
+
+// ReminderDelivery is one notification of a reminder through one channel,
+// planned once per offset and due time so a moved reminder is notified again
+type ReminderDelivery struct {
+	ID            int        `gorm:"primaryKey;autoIncrement" json:"id"`
+	ReminderID    int        `gorm:"not null;uniqueIndex:idx_reminder_deliveries_unique,priority:1" json:"reminder_id"`
+	DueAt         time.Time  `gorm:"not null;uniqueIndex:idx_reminder_deliveries_unique,priority:2" json:"due_at"`          // due time of the reminder when planned
+	OffsetSeconds int64      `gorm:"not null;uniqueIndex:idx_reminder_deliveries_unique,priority:3" json:"offset_seconds"`  // sent this long before DueAt
+	Channel       string     `gorm:"not null;size:16;uniqueIndex:idx_reminder_deliveries_unique,priority:4" json:"channel"` // consts.ReminderChannel
+	Status        int8       `gorm:"not null;default:0;index:idx_reminder_deliveries_status_next,priority:1" json:"status"` // consts.ReminderDeliveryStatus
+	Attempts      int        `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt time.Time  `gorm:"not null;index:idx_reminder_deliveries_status_next,priority:2" json:"next_attempt_at"`
+	LastError     *string    `gorm:"type:text" json:"last_error,omitempty"`
+	SentAt        *time.Time `json:"sent_at,omitempty"`
+	TableCommon
+
+	// Relationships
+	Reminder *Reminder `gorm:"foreignKey:ReminderID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+func (ReminderDelivery) TableName() string {
+	return "reminder_deliveries"
+}
+
+// ReminderDeliveryAttempt records the outcome of one send of a delivery
+type ReminderDeliveryAttempt struct {
+	ID         int       `gorm:"primaryKey;autoIncrement" json:"id"`
+	DeliveryID int       `gorm:"not null;index" json:"delivery_id"`
+	Attempt    int       `gorm:"not null" json:"attempt"` // 1 for the first try
+	Status     int8      `gorm:"not null" json:"status"`  // consts.ReminderDeliveryStatus SENT or FAILED
+	Error      *string   `gorm:"type:text" json:"error,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+
+	// Relationships
+	Delivery *ReminderDelivery `gorm:"foreignKey:DeliveryID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+func (ReminderDeliveryAttempt) TableName() string {
+	return "reminder_delivery_attempts"
+}
@@ -0,0 +1,123 @@
+package models
+
+type GradingScaleRequest struct {
+	Name       string               `json:"name" binding:"required,max=255"`
+	Type       int8                 `json:"type" binding:"oneof=0 1"` // consts.GradingScaleType
+	MaxPoints  float64              `json:"max_points" binding:"required,gt=0"`
+	MaxScore   float64              `json:"max_score" binding:"required,gt=0"`
+	PassScore  float64              `json:"pass_score" binding:"min=0"`             // linear scales only
+	Bands      []GradingBandRequest `json:"bands" binding:"omitempty,dive"`         // band scales only, replaces the current ones
+	IsDefault  bool                 `json:"is_default"`                             // system scales only
+	University *string              `json:"university" binding:"omitempty,max=255"` // system scales only
+}
+
+// GradingBandRequest is a letter of a band scale, numeric grades from MinScore up to the next band fall into it
+type GradingBandRequest struct {
+	Letter    string  `json:"letter" binding:"required,max=8"`
+	MinScore  float64 `json:"min_score" binding:"min=0"`
+	Points    float64 `json:"points" binding:"min=0"`
+	IsPassing *bool   `json:"is_passing"` // true when unset
+}
+
+type GpaSettingRequest struct {
+	GradingScaleID *int   `json:"grading_scale_id"`                                    // the university or system default when unset
+	RetakePolicy   string `json:"retake_policy" binding:"omitempty,oneof=latest best"` // consts.RetakePolicy, latest by default
+}
+
+type GpaQuery struct {
+	Projected bool `form:"projected"` // grade ungraded courses of the current semester with the projection of their assessment components
+}
+
+// GpaReport is the cumulative and per-semester GPA of a user, grade points are expressed on Scale
+type GpaReport struct {
+	Scale        *GradingScale  `json:"scale"`
+	RetakePolicy string         `json:"retake_policy"`
+	GPA          *float64       `json:"gpa"` // null until a graded course counts
+	Credits      GpaCredits     `json:"credits"`
+	Semesters    []*SemesterGpa `json:"semesters"` // oldest first
+}
+
+// GpaCredits totals the credits of graded courses
+type GpaCredits struct {
+	Attempted int `json:"attempted"` // every graded course
+	Earned    int `json:"earned"`    // passed courses
+	GPA       int `json:"gpa"`       // courses the GPA is computed over
+}
+
+// SemesterGpa is the GPA of the courses of a semester as they were graded, superseded retakes included
+type SemesterGpa struct {
+	Semester *Semester      `json:"semester"`
+	GPA      *float64       `json:"gpa"`
+	Credits  GpaCredits     `json:"credits"`
+	Courses  []*CourseGrade `json:"courses"`
+}
+
+// CourseGrade is what the grade of a course is worth
+type CourseGrade struct {
+	CourseID  int      `json:"course_id"`
+	Code      string   `json:"code"`
+	Name      string   `json:"name"`
+	Credits   int      `json:"credits"`
+	Grade     *string  `json:"grade"`
+	Letter    string   `json:"letter,omitempty"`
+	Points    *float64 `json:"points,omitempty"` // on the report scale
+	Passed    *bool    `json:"passed,omitempty"`
+	Status    string   `json:"status"`              // consts.CourseGradeStatus, whether it counts towards the cumulative GPA
+	Projected bool     `json:"projected,omitempty"` // the grade is projected from the assessment components
+}
+
+// GpaTargetRequest plans the grades the planned courses need for the cumulative GPA to reach TargetGPA.
+// Plans take no projections, other ungraded courses do not count until they are graded.
+type GpaTargetRequest struct {
+	TargetGPA float64 `json:"target_gpa" binding:"required,gt=0"`              // on the user's grading scale
+	CourseIDs []int   `json:"course_ids" binding:"omitempty,max=30,dive,gt=0"` // the ungraded courses of the current semester when empty
+}
+
+// GpaTargetPlan counts a planned course that retakes another under the retake policy, like any other attempt
+type GpaTargetPlan struct {
+	Scale           *GradingScale       `json:"scale"`
+	TargetGPA       float64             `json:"target_gpa"`
+	CurrentGPA      *float64            `json:"current_gpa"`
+	RequiredAverage *float64            `json:"required_average"` // points the planned courses need on average, null without planned courses
+	Feasible        bool                `json:"feasible"`
+	Courses         []*PlannedCourse    `json:"courses"`      // planned courses counting towards the GPA
+	Combinations    []*GradeCombination `json:"combinations"` // minimal letter combinations reaching the target, null when not searched
+}
+
+type PlannedCourse struct {
+	CourseID     int     `json:"course_id"`
+	Code         string  `json:"code"`
+	Name         string  `json:"name"`
+	Credits      int     `json:"credits"`
+	MinimumGrade *string `json:"minimum_grade"` // the lowest grade worth the required average, null when out of reach
+}
+
+// GradeCombination is a set of grades of the planned courses, none of which can be lower
+type GradeCombination struct {
+	Grades []PlannedGrade `json:"grades"`
+	GPA    float64        `json:"gpa"`
+}
+
+type PlannedGrade struct {
+	CourseID int    `json:"course_id"`
+	Grade    string `json:"grade"`
+}
+
+// GpaWhatIfRequest overrides grades of courses for a GPA simulation, nothing is saved
+type GpaWhatIfRequest struct {
+	Grades    []WhatIfGrade `json:"grades" binding:"required,min=1,max=100,dive"`
+	Projected bool          `json:"projected"` // simulate on top of the projections of the current semester, like GpaQuery
+}
+
+type WhatIfGrade struct {
+	CourseID       int     `json:"course_id" binding:"required"`
+	Grade          *string `json:"grade" binding:"omitempty,max=16"` // null simulates an ungraded course
+	IsPassFail     *bool   `json:"is_pass_fail"`                     // the course's own when unset
+	ExcludeFromGPA *bool   `json:"exclude_from_gpa"`                 // the course's own when unset
+}
+
+// GpaWhatIf is the GPA report computed with the simulated grades
+type GpaWhatIf struct {
+	CurrentGPA *float64   `json:"current_gpa"`
+	Report     *GpaReport `json:"report"`
+}
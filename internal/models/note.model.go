@@ -0,0 +1,34 @@
+package models
+
+type NoteRequest struct {
+	Title       string  `json:"title" binding:"required,max=255"`
+	Format      string  `json:"format" binding:"omitempty,oneof=markdown json"` // consts.NoteFormat, markdown by default
+	Content     string  `json:"content" binding:"max=1000000"`                  // a JSON document when Format is json
+	CourseID    *int    `json:"course_id"`
+	LectureDate *string `json:"lecture_date" binding:"omitempty,datetime=2006-01-02"`
+	TagIDs      []int   `json:"tag_ids"` // replaces the note's tags
+	Version     *int    `json:"version"` // on update, the version the edit started from, rejected once a newer one is saved
+}
+
+// NoteFilter narrows the note list, zero values are ignored
+type NoteFilter struct {
+	CourseID int    `form:"course_id"`
+	TagID    int    `form:"tag_id"`
+	TagIDs   []int  `form:"tag_ids" collection_format:"csv"`              // e.g. tag_ids=1,2,3
+	Match    string `form:"match" binding:"omitempty,oneof=any all"`      // how TagIDs combine, any by default
+	From     string `form:"from" binding:"omitempty,datetime=2006-01-02"` // earliest lecture date
+	To       string `form:"to" binding:"omitempty,datetime=2006-01-02"`   // latest lecture date, inclusive
+}
+
+type NoteDiffQuery struct {
+	From int `form:"from" binding:"omitempty,min=1"` // the version before To by default
+	To   int `form:"to" binding:"omitempty,min=1"`   // the latest version by default
+}
+
+// NoteDiff is the line-based unified diff of the content of two revisions of a note
+type NoteDiff struct {
+	NoteID int    `json:"note_id"`
+	From   int    `json:"from"`
+	To     int    `json:"to"`
+	Diff   string `json:"diff"` // empty when the content is the same
+}
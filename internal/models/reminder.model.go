@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+type ReminderRequest struct {
+	Title       string   `json:"title" binding:"required,max=255"`
+	Description *string  `json:"description"`
+	DueDate     string   `json:"due_date" binding:"required,datetime=2006-01-02"` // in the user's timezone, the first occurrence of a series
+	DueTime     string   `json:"due_time" binding:"required,datetime=15:04"`      // in the user's timezone
+	CourseID    *int     `json:"course_id"`
+	Type        int8     `json:"type" binding:"oneof=0 1 2"`                                   // consts.ReminderType
+	RRule       *string  `json:"rrule" binding:"omitempty,max=255"`                            // e.g. "FREQ=WEEKLY;BYDAY=MO;COUNT=12"
+	ExDates     []string `json:"exdates" binding:"omitempty,max=366,dive,datetime=2006-01-02"` // skipped dates of a series, replaces the current ones
+}
+
+// ReminderFilter narrows the reminder list, zero values are ignored
+type ReminderFilter struct {
+	UpcomingDays int   `form:"upcoming_days" binding:"omitempty,min=1,max=365"` // due from now until this many days ahead
+	CourseID     int   `form:"course_id"`
+	Status       *int8 `form:"status" binding:"omitempty,oneof=0 1 2"` // consts.ReminderStatus
+}
+
+// ReminderOccurrenceQuery picks the occurrences of a recurring reminder an update, delete or completion applies to
+type ReminderOccurrenceQuery struct {
+	Scope          string `form:"scope" binding:"omitempty,oneof=all this following"` // consts.ReminderEditScope, all by default
+	OccurrenceDate string `form:"occurrence_date" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// ReminderRangeQuery lists reminder occurrences due in a date range, recurring reminders expanded
+type ReminderRangeQuery struct {
+	From     string `form:"from" binding:"required,datetime=2006-01-02"`
+	To       string `form:"to" binding:"required,datetime=2006-01-02"` // inclusive
+	CourseID int    `form:"course_id"`
+	Status   *int8  `form:"status" binding:"omitempty,oneof=0 1 2"` // consts.ReminderStatus
+}
+
+// ReminderOccurrence is one occurrence of a reminder, a one-off reminder has a single one
+type ReminderOccurrence struct {
+	ReminderID     int        `json:"reminder_id"`
+	OccurrenceDate time.Time  `json:"occurrence_date"` // calendar date in the user's timezone
+	DueAt          time.Time  `json:"due_at"`
+	Status         int8       `json:"status"` // consts.ReminderStatus
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+	Recurring      bool       `json:"recurring"`
+	Reminder       *Reminder  `json:"reminder"`
+}
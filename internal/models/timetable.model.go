@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+type ClassSessionRequest struct {
+	CourseID       int     `json:"course_id" binding:"required"`
+	DayOfWeek      int8    `json:"day_of_week" binding:"required,min=1,max=7"` // 1 = Monday ... 7 = Sunday
+	StartTime      string  `json:"start_time" binding:"required,datetime=15:04"`
+	EndTime        string  `json:"end_time" binding:"required,datetime=15:04"`
+	Location       *string `json:"location" binding:"omitempty,max=255"`
+	Type           int8    `json:"type" binding:"oneof=0 1 2"`        // consts.ClassSessionType
+	WeekParity     int8    `json:"week_parity" binding:"oneof=0 1 2"` // consts.WeekParity
+	AllowConflicts bool    `json:"allow_conflicts"`                   // save despite overlapping sessions instead of rejecting
+}
+
+// ClassSessionFilter narrows the class session list, zero values are ignored
+type ClassSessionFilter struct {
+	CourseID   int `form:"course_id"`
+	SemesterID int `form:"semester_id"`
+}
+
+// ClassSessionResult is a saved session with the sessions it overlaps, if it was allowed to
+type ClassSessionResult struct {
+	Session   *ClassSession   `json:"session"`
+	Conflicts []*ClassSession `json:"conflicts,omitempty"`
+}
+
+type TimetableQuery struct {
+	SemesterID int `form:"semester"`                       // the current semester when unset
+	Week       int `form:"week" binding:"omitempty,min=1"` // only the sessions held in this week of the semester
+}
+
+// Timetable is the week grid of a semester, Monday first
+type Timetable struct {
+	Semester *Semester      `json:"semester"`
+	Week     int            `json:"week,omitempty"`
+	Days     []TimetableDay `json:"days"`
+}
+
+type TimetableDay struct {
+	DayOfWeek int8              `json:"day_of_week"`
+	Name      string            `json:"name"`           // e.g. "Monday"
+	Date      *time.Time        `json:"date,omitempty"` // set when a week is picked
+	Sessions  []*TimetableEntry `json:"sessions"`       // by start time
+}
+
+// TimetableEntry is a session on the grid with the sessions of the day it overlaps
+type TimetableEntry struct {
+	*ClassSession
+	ConflictsWith []int `json:"conflicts_with,omitempty"`
+}
@@ -1,7 +1,74 @@
 package models
 
+import "time"
+
 type CreateUserRequest struct {
 	Username string `json:"username" binding:"required"`
 	Email    string `json:"email" binding:"required"`
 	Password string `json:"password" binding:"required"`
 }
+
+type VerifyEmailRequest struct {
+	Email string `json:"email" binding:"required"`
+	Otp   string `json:"otp" binding:"required"`
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" binding:"required"`
+}
+
+type AddPhoneNumberRequest struct {
+	PhoneNumber string `json:"phone_number" binding:"required"` // E.164, e.g. +14155552671
+}
+
+type VerifyPhoneRequest struct {
+	Otp string `json:"otp" binding:"required"`
+}
+
+// UserProfile is the current user as returned by GET /users/me
+type UserProfile struct {
+	UserID              string     `json:"user_id"`
+	Username            string     `json:"username"`
+	Email               string     `json:"email"`
+	PhoneNumber         string     `json:"phone_number,omitempty"`
+	IsEmailVerified     bool       `json:"is_email_verified"`
+	IsPhoneVerified     bool       `json:"is_phone_verified"`
+	IsTotpEnabled       bool       `json:"is_totp_enabled"`
+	Role                string     `json:"role"`
+	DisplayName         string     `json:"display_name"`
+	University          string     `json:"university"`
+	Major               string     `json:"major"`
+	GraduationYear      *int16     `json:"graduation_year"`
+	Timezone            string     `json:"timezone"`
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at"`
+	CreatedAt           time.Time  `json:"created_at"`
+}
+
+// UpdateProfileRequest lists every field a user may edit. Omitted fields are left unchanged,
+// empty strings and a zero graduation year clear the value.
+type UpdateProfileRequest struct {
+	DisplayName    *string `json:"display_name" binding:"omitempty,max=255"`
+	University     *string `json:"university" binding:"omitempty,max=255"`
+	Major          *string `json:"major" binding:"omitempty,max=255"`
+	GraduationYear *int16  `json:"graduation_year" binding:"omitempty,min=1900,max=2200"`
+	Timezone       *string `json:"timezone" binding:"omitempty,max=64"`
+}
+
+type ChangeEmailRequest struct {
+	NewEmail string `json:"new_email" binding:"required,email,max=255"`
+	Password string `json:"password" binding:"required"`
+}
+
+type ConfirmEmailChangeRequest struct {
+	Otp string `json:"otp" binding:"required"`
+}
+
+type DeleteAccountRequest struct {
+	Password string `json:"password" binding:"required"`
+}
+
+// PendingEmailChange is kept in redis until the new address is confirmed
+type PendingEmailChange struct {
+	Email string `json:"email"`
+	Otp   string `json:"otp"`
+}
@@ -0,0 +1,118 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type IAssessmentRepository interface {
+	GetAssessmentByID(ctx context.Context, userID string, id int) (*models.AssessmentComponent, error)
+
+	// GetAssessments lists the components of the given courses of the user, by due date
+	GetAssessments(ctx context.Context, userID string, courseIDs []int) ([]*models.AssessmentComponent, error)
+
+	// ReplaceAssessments swaps the components of a course for the given ones, keeping the ids of
+	// the ones that have one. Callers must make sure the course and those components belong to the user first.
+	ReplaceAssessments(ctx context.Context, courseID int, components []*models.AssessmentComponent) error
+
+	// UpdateAssessment returns the number of affected rows
+	// so callers can tell a component of another user's course apart.
+	UpdateAssessment(ctx context.Context, userID string, id int, updates map[string]interface{}) (int64, error)
+}
+
+type AssessmentRepository struct {
+	db *gorm.DB
+}
+
+// NewAssessmentRepository creates a new assessment repository with the given database connection.
+func NewAssessmentRepository(db *gorm.DB) IAssessmentRepository {
+	return &AssessmentRepository{db: db}
+}
+
+// ownedCourseIDs selects the ids of the user's courses, components are owned through their course
+func (r *AssessmentRepository) ownedCourseIDs(userID string) *gorm.DB {
+	return r.db.Model(&models.Course{}).Select("id").Where("user_id = ?", userID)
+}
+
+// GetAssessmentByID retrieves a component of one of the user's courses.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *AssessmentRepository) GetAssessmentByID(ctx context.Context, userID string, id int) (*models.AssessmentComponent, error) {
+	var component models.AssessmentComponent
+	err := r.db.WithContext(ctx).
+		Where("id = ? AND course_id IN (?)", id, r.ownedCourseIDs(userID)).
+		First(&component).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &component, nil
+}
+
+// GetAssessments lists the components of the user's courses among courseIDs, undated ones last.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *AssessmentRepository) GetAssessments(ctx context.Context, userID string, courseIDs []int) ([]*models.AssessmentComponent, error) {
+	var components []*models.AssessmentComponent
+	err := r.db.WithContext(ctx).
+		Where("course_id IN ? AND course_id IN (?)", courseIDs, r.ownedCourseIDs(userID)).
+		Order("course_id, due_date IS NULL, due_date, id").
+		Find(&components).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return components, nil
+}
+
+// ReplaceAssessments updates the given components that have an id, inserts the others and deletes
+// the components of the course left out, in one transaction.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *AssessmentRepository) ReplaceAssessments(ctx context.Context, courseID int, components []*models.AssessmentComponent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		keptIDs := make([]int, 0, len(components))
+		for _, component := range components {
+			if component.ID != 0 {
+				keptIDs = append(keptIDs, component.ID)
+			}
+		}
+
+		removed := tx.Where("course_id = ?", courseID)
+		if len(keptIDs) > 0 {
+			removed = removed.Where("id NOT IN ?", keptIDs)
+		}
+		if err := removed.Delete(&models.AssessmentComponent{}).Error; err != nil {
+			return err
+		}
+
+		for _, component := range components {
+			if component.ID == 0 {
+				if err := tx.Omit("Course").Create(component).Error; err != nil {
+					return err
+				}
+				continue
+			}
+
+			// Select writes the cleared score and due date too
+			err := tx.Model(&models.AssessmentComponent{}).
+				Where("id = ? AND course_id = ?", component.ID, courseID).
+				Select("name", "weight", "max_score", "score", "due_date").
+				Updates(component).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// UpdateAssessment updates the given columns of a component of one of the user's courses.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *AssessmentRepository) UpdateAssessment(ctx context.Context, userID string, id int, updates map[string]interface{}) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.AssessmentComponent{}).
+		Where("id = ? AND course_id IN (?)", id, r.ownedCourseIDs(userID)).
+		Updates(updates)
+
+	return result.RowsAffected, result.Error
+}
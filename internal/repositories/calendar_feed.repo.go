@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ICalendarFeedRepository interface {
+	GetCalendarFeedByUserID(ctx context.Context, userID string) (*models.CalendarFeed, error)
+
+	// GetCalendarFeedByTokenHash finds the feed a subscription URL points to
+	GetCalendarFeedByTokenHash(ctx context.Context, tokenHash string) (*models.CalendarFeed, error)
+
+	// UpsertCalendarFeed creates the user's feed or replaces its token, invalidating the previous URL
+	UpsertCalendarFeed(ctx context.Context, feed *models.CalendarFeed) error
+
+	// DeleteCalendarFeed returns the number of affected rows so callers can tell a missing feed apart
+	DeleteCalendarFeed(ctx context.Context, userID string) (int64, error)
+}
+
+type CalendarFeedRepository struct {
+	db *gorm.DB
+}
+
+// NewCalendarFeedRepository creates a new calendar feed repository with the given database connection.
+func NewCalendarFeedRepository(db *gorm.DB) ICalendarFeedRepository {
+	return &CalendarFeedRepository{db: db}
+}
+
+// GetCalendarFeedByUserID retrieves the calendar feed of the user.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *CalendarFeedRepository) GetCalendarFeedByUserID(ctx context.Context, userID string) (*models.CalendarFeed, error) {
+	var feed models.CalendarFeed
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		First(&feed).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &feed, nil
+}
+
+// GetCalendarFeedByTokenHash retrieves the calendar feed with the given token hash.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *CalendarFeedRepository) GetCalendarFeedByTokenHash(ctx context.Context, tokenHash string) (*models.CalendarFeed, error) {
+	var feed models.CalendarFeed
+	err := r.db.WithContext(ctx).
+		Where("token_hash = ?", tokenHash).
+		First(&feed).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &feed, nil
+}
+
+// UpsertCalendarFeed inserts the feed, or updates the token hash of the user's existing one.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *CalendarFeedRepository) UpsertCalendarFeed(ctx context.Context, feed *models.CalendarFeed) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"token_hash", "updated_at"}),
+		}).
+		Create(feed).Error
+}
+
+// DeleteCalendarFeed removes the calendar feed of the user.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *CalendarFeedRepository) DeleteCalendarFeed(ctx context.Context, userID string) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Delete(&models.CalendarFeed{})
+
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type ICalendarImportRepository interface {
+	// ImportCalendar creates the courses, then the class sessions and reminders of the import in one transaction.
+	// Sessions and reminders take the id of the course they point at, so they can refer to courses created here.
+	ImportCalendar(ctx context.Context, courses []*models.Course, sessions []*models.ClassSession, reminders []*models.Reminder) error
+}
+
+type CalendarImportRepository struct {
+	db *gorm.DB
+}
+
+// NewCalendarImportRepository creates a new calendar import repository with the given database connection.
+func NewCalendarImportRepository(db *gorm.DB) ICalendarImportRepository {
+	return &CalendarImportRepository{db: db}
+}
+
+// ImportCalendar inserts the imported rows, nothing is kept if one of them fails.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *CalendarImportRepository) ImportCalendar(ctx context.Context, courses []*models.Course, sessions []*models.ClassSession, reminders []*models.Reminder) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(courses) > 0 {
+			if err := tx.Omit("Semester", "Tags", "Lecturers").Create(&courses).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(sessions) > 0 {
+			for _, session := range sessions {
+				session.CourseID = session.Course.ID
+			}
+			if err := tx.Omit("Course").Create(&sessions).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(reminders) > 0 {
+			for _, reminder := range reminders {
+				if reminder.Course != nil {
+					courseID := reminder.Course.ID
+					reminder.CourseID = &courseID
+				}
+			}
+			if err := tx.Omit("Course", "Series").Create(&reminders).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type IClassSessionRepository interface {
+	CreateClassSession(ctx context.Context, session *models.ClassSession) error
+	GetClassSessionByID(ctx context.Context, userID string, id int) (*models.ClassSession, error)
+
+	// GetClassSessions lists the user's sessions by weekday and start time, with their course
+	GetClassSessions(ctx context.Context, userID string, filter models.ClassSessionFilter) ([]*models.ClassSession, error)
+
+	// UpdateClassSession and DeleteClassSession return the number of affected rows
+	// so callers can tell a session of another user's course apart.
+	UpdateClassSession(ctx context.Context, userID string, id int, updates map[string]interface{}) (int64, error)
+	DeleteClassSession(ctx context.Context, userID string, id int) (int64, error)
+}
+
+type ClassSessionRepository struct {
+	db *gorm.DB
+}
+
+// NewClassSessionRepository creates a new class session repository with the given database connection.
+func NewClassSessionRepository(db *gorm.DB) IClassSessionRepository {
+	return &ClassSessionRepository{db: db}
+}
+
+// ownedCourseIDs selects the ids of the user's courses, sessions are owned through their course
+func (r *ClassSessionRepository) ownedCourseIDs(userID string) *gorm.DB {
+	return r.db.Model(&models.Course{}).Select("id").Where("user_id = ?", userID)
+}
+
+// CreateClassSession inserts a new class session.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *ClassSessionRepository) CreateClassSession(ctx context.Context, session *models.ClassSession) error {
+	return r.db.WithContext(ctx).Omit("Course").Create(session).Error
+}
+
+// GetClassSessionByID retrieves a session of one of the user's courses.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *ClassSessionRepository) GetClassSessionByID(ctx context.Context, userID string, id int) (*models.ClassSession, error) {
+	var session models.ClassSession
+	err := r.db.WithContext(ctx).
+		Preload("Course").
+		Where("id = ? AND course_id IN (?)", id, r.ownedCourseIDs(userID)).
+		First(&session).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// GetClassSessions lists the sessions of the user's courses matching the filter.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *ClassSessionRepository) GetClassSessions(ctx context.Context, userID string, filter models.ClassSessionFilter) ([]*models.ClassSession, error) {
+	var sessions []*models.ClassSession
+	courseIDs := r.ownedCourseIDs(userID)
+
+	if filter.CourseID != 0 {
+		courseIDs = courseIDs.Where("id = ?", filter.CourseID)
+	}
+	if filter.SemesterID != 0 {
+		courseIDs = courseIDs.Where("semester_id = ?", filter.SemesterID)
+	}
+
+	err := r.db.WithContext(ctx).
+		Preload("Course").
+		Where("course_id IN (?)", courseIDs).
+		Order("day_of_week, start_time, id").
+		Find(&sessions).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// UpdateClassSession updates the given columns of a session of one of the user's courses.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *ClassSessionRepository) UpdateClassSession(ctx context.Context, userID string, id int, updates map[string]interface{}) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.ClassSession{}).
+		Where("id = ? AND course_id IN (?)", id, r.ownedCourseIDs(userID)).
+		Updates(updates)
+
+	return result.RowsAffected, result.Error
+}
+
+// DeleteClassSession removes a session of one of the user's courses.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *ClassSessionRepository) DeleteClassSession(ctx context.Context, userID string, id int) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND course_id IN (?)", id, r.ownedCourseIDs(userID)).
+		Delete(&models.ClassSession{})
+
+	return result.RowsAffected, result.Error
+}
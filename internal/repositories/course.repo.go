@@ -0,0 +1,139 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type ICourseRepository interface {
+	CreateCourse(ctx context.Context, course *models.Course) error
+	GetCourseByID(ctx context.Context, userID string, id int) (*models.Course, error)
+	GetCourses(ctx context.Context, userID string, filter models.CourseFilter) ([]*models.Course, error)
+
+	// CountCourses counts how many of the given courses belong to the user
+	CountCourses(ctx context.Context, userID string, ids []int) (int64, error)
+
+	// UpdateCourse writes the columns and replaces the lecturers of a course,
+	// callers must make sure the course belongs to the user first
+	UpdateCourse(ctx context.Context, userID string, id int, updates map[string]interface{}, lecturerIDs []int) error
+
+	// DeleteCourse returns the number of deleted rows
+	// so callers can tell a course owned by another user apart.
+	DeleteCourse(ctx context.Context, userID string, id int) (int64, error)
+}
+
+type CourseRepository struct {
+	db *gorm.DB
+}
+
+// NewCourseRepository creates a new course repository with the given database connection.
+func NewCourseRepository(db *gorm.DB) ICourseRepository {
+	return &CourseRepository{db: db}
+}
+
+// CreateCourse inserts a new course and links it to its lecturers, which must already exist.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *CourseRepository) CreateCourse(ctx context.Context, course *models.Course) error {
+	return r.db.WithContext(ctx).Omit("Semester", "Tags", "Lecturers.*").Create(course).Error
+}
+
+// GetCourseByID retrieves a course of the user with its semester and tags.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *CourseRepository) GetCourseByID(ctx context.Context, userID string, id int) (*models.Course, error) {
+	var course models.Course
+	err := r.db.WithContext(ctx).
+		Preload("Semester").
+		Preload("Tags").
+		Preload("Lecturers").
+		Where("id = ? AND user_id = ?", id, userID).
+		First(&course).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &course, nil
+}
+
+// GetCourses lists the user's courses, optionally filtered by semester, tags and lecturer.
+// filter.TagIDs must not contain duplicates.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *CourseRepository) GetCourses(ctx context.Context, userID string, filter models.CourseFilter) ([]*models.Course, error) {
+	var courses []*models.Course
+	query := r.db.WithContext(ctx).
+		Preload("Semester").
+		Preload("Tags").
+		Preload("Lecturers").
+		Where("courses.user_id = ?", userID)
+
+	if filter.SemesterID != 0 {
+		query = query.Where("courses.semester_id = ?", filter.SemesterID)
+	}
+	if len(filter.TagIDs) > 0 {
+		taggedCourses := r.db.Model(&models.CourseTag{}).Select("course_id").Where("tag_id IN ?", filter.TagIDs)
+		if filter.Match == models.TagMatchAll {
+			// TagIDs are distinct, so a full match links the course to each of them once
+			taggedCourses = taggedCourses.Group("course_id").Having("COUNT(*) = ?", len(filter.TagIDs))
+		}
+		query = query.Where("courses.id IN (?)", taggedCourses)
+	}
+	if filter.LecturerID != 0 {
+		query = query.Joins("JOIN course_lecturers ON course_lecturers.course_id = courses.id").
+			Where("course_lecturers.lecturer_id = ?", filter.LecturerID)
+	}
+
+	err := query.Order("courses.semester_id, courses.course_id").Find(&courses).Error
+	if err != nil {
+		return nil, err
+	}
+	return courses, nil
+}
+
+// CountCourses counts the given courses owned by the user.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *CourseRepository) CountCourses(ctx context.Context, userID string, ids []int) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.Course{}).
+		Where("user_id = ? AND id IN ?", userID, ids).
+		Count(&count).Error
+
+	return count, err
+}
+
+// UpdateCourse updates the given columns of a course owned by the user and replaces its lecturer links.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *CourseRepository) UpdateCourse(ctx context.Context, userID string, id int, updates map[string]interface{}, lecturerIDs []int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.Course{}).
+			Where("id = ? AND user_id = ?", id, userID).
+			Updates(updates).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("course_id = ?", id).Delete(&models.CourseLecturer{}).Error; err != nil {
+			return err
+		}
+		if len(lecturerIDs) == 0 {
+			return nil
+		}
+
+		links := make([]models.CourseLecturer, 0, len(lecturerIDs))
+		for _, lecturerID := range lecturerIDs {
+			links = append(links, models.CourseLecturer{CourseID: id, LecturerID: lecturerID})
+		}
+		return tx.Create(&links).Error
+	})
+}
+
+// DeleteCourse removes a course owned by the user, tag and lecturer links go through ON DELETE CASCADE.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *CourseRepository) DeleteCourse(ctx context.Context, userID string, id int) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", id, userID).
+		Delete(&models.Course{})
+
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,213 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/nas03/scholar-ai/backend/internal/consts"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// ICourseworkRepository stores assignments or exams, T is models.Assignment or models.Exam
+type ICourseworkRepository[T any] interface {
+	// CreateCoursework inserts an assignment or exam with its reminders in one transaction
+	CreateCoursework(ctx context.Context, item *T, reminders []*models.Reminder) error
+	GetCourseworkByID(ctx context.Context, userID string, id int) (*T, error)
+	GetCoursework(ctx context.Context, userID string, filter models.CourseworkFilter) ([]*T, error)
+
+	// GetUpcomingCoursework lists the open assignments or exams of the user between from and to
+	GetUpcomingCoursework(ctx context.Context, userID string, courseID int, from, to time.Time) ([]*T, error)
+
+	// GetCourseworkReminders lists the reminders created for an assignment or exam
+	GetCourseworkReminders(ctx context.Context, id int) ([]*models.Reminder, error)
+
+	// UpdateCoursework and DeleteCoursework return the number of affected rows
+	// so callers can tell an assignment or exam of another user's course apart.
+	// UpdateCoursework applies reminderUpdates by reminder id, then swaps every reminder for reminders unless it is nil.
+	UpdateCoursework(ctx context.Context, userID string, id int, updates map[string]interface{}, reminderUpdates map[int]map[string]interface{}, reminders []*models.Reminder) (int64, error)
+	DeleteCoursework(ctx context.Context, userID string, id int) (int64, error)
+}
+
+type CourseworkRepository[T any] struct {
+	db *gorm.DB
+
+	timeColumn     string                                  // due_at of an assignment, start_at of an exam
+	reminderColumn string                                  // the reminders column linking them to it
+	idOf           func(item *T) int                       // reads the id of a created one
+	link           func(reminder *models.Reminder, id int) // links a reminder to it
+}
+
+// NewAssignmentRepository creates a new assignment repository with the given database connection.
+func NewAssignmentRepository(db *gorm.DB) ICourseworkRepository[models.Assignment] {
+	return &CourseworkRepository[models.Assignment]{
+		db:             db,
+		timeColumn:     "due_at",
+		reminderColumn: "assignment_id",
+		idOf:           func(assignment *models.Assignment) int { return assignment.ID },
+		link:           func(reminder *models.Reminder, id int) { reminder.AssignmentID = &id },
+	}
+}
+
+// NewExamRepository creates a new exam repository with the given database connection.
+func NewExamRepository(db *gorm.DB) ICourseworkRepository[models.Exam] {
+	return &CourseworkRepository[models.Exam]{
+		db:             db,
+		timeColumn:     "start_at",
+		reminderColumn: "exam_id",
+		idOf:           func(exam *models.Exam) int { return exam.ID },
+		link:           func(reminder *models.Reminder, id int) { reminder.ExamID = &id },
+	}
+}
+
+// ownedCourseIDs selects the ids of the user's courses, assignments and exams are owned through their course
+func (r *CourseworkRepository[T]) ownedCourseIDs(userID string) *gorm.DB {
+	return r.db.Model(&models.Course{}).Select("id").Where("user_id = ?", userID)
+}
+
+// CreateCoursework inserts an assignment or exam, then its reminders linked to it.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *CourseworkRepository[T]) CreateCoursework(ctx context.Context, item *T, reminders []*models.Reminder) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Course", "Component").Create(item).Error; err != nil {
+			return err
+		}
+		return r.createReminders(tx, r.idOf(item), reminders)
+	})
+}
+
+// GetCourseworkByID retrieves an assignment or exam of one of the user's courses.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *CourseworkRepository[T]) GetCourseworkByID(ctx context.Context, userID string, id int) (*T, error) {
+	var item T
+	err := r.db.WithContext(ctx).
+		Preload("Course").
+		Preload("Component").
+		Where("id = ? AND course_id IN (?)", id, r.ownedCourseIDs(userID)).
+		First(&item).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// GetCoursework lists the assignments or exams of the user's courses matching the filter, soonest first.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *CourseworkRepository[T]) GetCoursework(ctx context.Context, userID string, filter models.CourseworkFilter) ([]*T, error) {
+	var items []*T
+	query := r.db.WithContext(ctx).
+		Preload("Course").
+		Preload("Component").
+		Where("course_id IN (?)", r.ownedCourseIDs(userID))
+
+	if filter.CourseID != 0 {
+		query = query.Where("course_id = ?", filter.CourseID)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+
+	err := query.Order(r.timeColumn + ", id").Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetUpcomingCoursework lists the pending and in progress assignments or exams from from up to to, soonest first.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *CourseworkRepository[T]) GetUpcomingCoursework(ctx context.Context, userID string, courseID int, from, to time.Time) ([]*T, error) {
+	var items []*T
+	query := r.db.WithContext(ctx).
+		Preload("Course").
+		Preload("Component").
+		Where("course_id IN (?)", r.ownedCourseIDs(userID)).
+		Where("status IN ?", []int8{consts.CourseworkStatus.PENDING, consts.CourseworkStatus.IN_PROGRESS}).
+		Where(r.timeColumn+" >= ? AND "+r.timeColumn+" < ?", from, to)
+
+	if courseID != 0 {
+		query = query.Where("course_id = ?", courseID)
+	}
+
+	err := query.Order(r.timeColumn + ", id").Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetCourseworkReminders lists the reminders linked to an assignment or exam.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *CourseworkRepository[T]) GetCourseworkReminders(ctx context.Context, id int) ([]*models.Reminder, error) {
+	var reminders []*models.Reminder
+	err := r.db.WithContext(ctx).
+		Where(r.reminderColumn+" = ?", id).
+		Order("due_at, id").
+		Find(&reminders).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return reminders, nil
+}
+
+// UpdateCoursework updates the given columns of an assignment or exam of one of the user's courses
+// and of its reminders, by reminder id, then replaces its reminders when asked, in one transaction.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *CourseworkRepository[T]) UpdateCoursework(ctx context.Context, userID string, id int, updates map[string]interface{}, reminderUpdates map[int]map[string]interface{}, reminders []*models.Reminder) (int64, error) {
+	var rowsAffected int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(new(T)).
+			Where("id = ? AND course_id IN (?)", id, r.ownedCourseIDs(userID)).
+			Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		rowsAffected = result.RowsAffected
+		if rowsAffected == 0 {
+			return nil
+		}
+
+		for reminderID, reminderUpdate := range reminderUpdates {
+			err := tx.Model(&models.Reminder{}).
+				Where("id = ? AND "+r.reminderColumn+" = ?", reminderID, id).
+				Updates(reminderUpdate).Error
+			if err != nil {
+				return err
+			}
+		}
+		if reminders == nil {
+			return nil
+		}
+
+		if err := tx.Where(r.reminderColumn+" = ?", id).Delete(&models.Reminder{}).Error; err != nil {
+			return err
+		}
+		return r.createReminders(tx, id, reminders)
+	})
+
+	return rowsAffected, err
+}
+
+// DeleteCoursework removes an assignment or exam of one of the user's courses, its reminders go through ON DELETE CASCADE.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *CourseworkRepository[T]) DeleteCoursework(ctx context.Context, userID string, id int) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND course_id IN (?)", id, r.ownedCourseIDs(userID)).
+		Delete(new(T))
+
+	return result.RowsAffected, result.Error
+}
+
+// createReminders inserts reminders linked to the assignment or exam with the given id
+func (r *CourseworkRepository[T]) createReminders(tx *gorm.DB, id int, reminders []*models.Reminder) error {
+	if len(reminders) == 0 {
+		return nil
+	}
+
+	for _, reminder := range reminders {
+		r.link(reminder, id)
+	}
+	return tx.Omit("Course", "ExDates").Create(&reminders).Error
+}
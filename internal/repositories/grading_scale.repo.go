@@ -0,0 +1,217 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Grading scales are owned by a user, or are system scales when userID is empty.
+// Users read their own scales and the system ones.
+type IGradingScaleRepository interface {
+	CreateGradingScale(ctx context.Context, scale *models.GradingScale) error
+	GetGradingScaleByID(ctx context.Context, userID string, id int) (*models.GradingScale, error)
+
+	// GetGradingScales lists the scales the user can pick, system scales first, with their bands
+	GetGradingScales(ctx context.Context, userID string) ([]*models.GradingScale, error)
+
+	// GetGradingScalesByIDs lists the given scales the user can pick, with their bands
+	GetGradingScalesByIDs(ctx context.Context, userID string, ids []int) ([]*models.GradingScale, error)
+
+	// GetDefaultGradingScale returns the system scale of the university, or the system default
+	GetDefaultGradingScale(ctx context.Context, university string) (*models.GradingScale, error)
+
+	// UpdateGradingScale and DeleteGradingScale return the number of affected rows
+	// so callers can tell a scale owned by someone else apart. Updates replace the bands.
+	UpdateGradingScale(ctx context.Context, userID string, id int, updates map[string]interface{}, bands []models.GradingBand) (int64, error)
+	DeleteGradingScale(ctx context.Context, userID string, id int) (int64, error)
+
+	// ClearDefaultGradingScale unmarks the system default, except the scale keepID
+	ClearDefaultGradingScale(ctx context.Context, keepID int) error
+
+	GetGpaSetting(ctx context.Context, userID string) (*models.GpaSetting, error)
+
+	// UpsertGpaSetting creates the user's settings or overwrites them
+	UpsertGpaSetting(ctx context.Context, setting *models.GpaSetting) error
+}
+
+type GradingScaleRepository struct {
+	db *gorm.DB
+}
+
+// NewGradingScaleRepository creates a new grading scale repository with the given database connection.
+func NewGradingScaleRepository(db *gorm.DB) IGradingScaleRepository {
+	return &GradingScaleRepository{db: db}
+}
+
+// ownedScales limits a query to the scales of the user, or to the system scales when userID is empty
+func ownedScales(userID string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if userID == "" {
+			return db.Where("user_id IS NULL")
+		}
+		return db.Where("user_id = ?", userID)
+	}
+}
+
+// visibleScales limits a query to the scales of the user and the system scales
+func visibleScales(userID string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("(user_id = ? OR user_id IS NULL)", userID)
+	}
+}
+
+// preloadBands loads the bands of scales highest first
+func preloadBands(db *gorm.DB) *gorm.DB {
+	return db.Order("min_score DESC")
+}
+
+// CreateGradingScale inserts a new grading scale with its bands.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *GradingScaleRepository) CreateGradingScale(ctx context.Context, scale *models.GradingScale) error {
+	return r.db.WithContext(ctx).Create(scale).Error
+}
+
+// GetGradingScaleByID retrieves a scale the user can pick, with its bands.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *GradingScaleRepository) GetGradingScaleByID(ctx context.Context, userID string, id int) (*models.GradingScale, error) {
+	var scale models.GradingScale
+	err := r.db.WithContext(ctx).
+		Preload("Bands", preloadBands).
+		Scopes(visibleScales(userID)).
+		Where("id = ?", id).
+		First(&scale).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &scale, nil
+}
+
+// GetGradingScales lists the system scales and the user's own ones.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *GradingScaleRepository) GetGradingScales(ctx context.Context, userID string) ([]*models.GradingScale, error) {
+	var scales []*models.GradingScale
+	err := r.db.WithContext(ctx).
+		Preload("Bands", preloadBands).
+		Scopes(visibleScales(userID)).
+		Order("user_id IS NOT NULL, name, id").
+		Find(&scales).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return scales, nil
+}
+
+// GetGradingScalesByIDs retrieves the given scales the user can pick.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *GradingScaleRepository) GetGradingScalesByIDs(ctx context.Context, userID string, ids []int) ([]*models.GradingScale, error) {
+	var scales []*models.GradingScale
+	err := r.db.WithContext(ctx).
+		Preload("Bands", preloadBands).
+		Scopes(visibleScales(userID)).
+		Where("id IN ?", ids).
+		Find(&scales).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return scales, nil
+}
+
+// GetDefaultGradingScale retrieves the system scale of the university, falling back to the system default.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *GradingScaleRepository) GetDefaultGradingScale(ctx context.Context, university string) (*models.GradingScale, error) {
+	var scale models.GradingScale
+	err := r.db.WithContext(ctx).
+		Preload("Bands", preloadBands).
+		Where("user_id IS NULL AND (university = ? OR is_default = 1)", university).
+		Order(clause.Expr{SQL: "university = ? DESC, id", Vars: []interface{}{university}}).
+		First(&scale).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &scale, nil
+}
+
+// UpdateGradingScale updates the given columns of a scale and replaces its bands.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *GradingScaleRepository) UpdateGradingScale(ctx context.Context, userID string, id int, updates map[string]interface{}, bands []models.GradingBand) (int64, error) {
+	var rowsAffected int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.GradingScale{}).
+			Scopes(ownedScales(userID)).
+			Where("id = ?", id).
+			Updates(updates)
+		if result.Error != nil || result.RowsAffected == 0 {
+			rowsAffected = result.RowsAffected
+			return result.Error
+		}
+		rowsAffected = result.RowsAffected
+
+		if err := tx.Where("scale_id = ?", id).Delete(&models.GradingBand{}).Error; err != nil {
+			return err
+		}
+		if len(bands) == 0 {
+			return nil
+		}
+
+		for i := range bands {
+			bands[i].ScaleID = id
+		}
+		return tx.Create(&bands).Error
+	})
+
+	return rowsAffected, err
+}
+
+// DeleteGradingScale removes a scale, its bands go through ON DELETE CASCADE and courses and
+// settings using it fall back to the default through ON DELETE SET NULL.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *GradingScaleRepository) DeleteGradingScale(ctx context.Context, userID string, id int) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Scopes(ownedScales(userID)).
+		Where("id = ?", id).
+		Delete(&models.GradingScale{})
+
+	return result.RowsAffected, result.Error
+}
+
+// ClearDefaultGradingScale unmarks the system default scales other than keepID.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *GradingScaleRepository) ClearDefaultGradingScale(ctx context.Context, keepID int) error {
+	return r.db.WithContext(ctx).
+		Model(&models.GradingScale{}).
+		Where("user_id IS NULL AND is_default = 1 AND id <> ?", keepID).
+		Update("is_default", 0).Error
+}
+
+// GetGpaSetting retrieves the GPA settings of the user.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *GradingScaleRepository) GetGpaSetting(ctx context.Context, userID string) (*models.GpaSetting, error) {
+	var setting models.GpaSetting
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		First(&setting).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &setting, nil
+}
+
+// UpsertGpaSetting inserts the settings, or updates the user's existing ones.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *GradingScaleRepository) UpsertGpaSetting(ctx context.Context, setting *models.GpaSetting) error {
+	return r.db.WithContext(ctx).
+		Omit("GradingScale").
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"grading_scale_id", "retake_policy", "updated_at"}),
+		}).
+		Create(setting).Error
+}
@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type IIdentityRepository interface {
+	CreateIdentity(ctx context.Context, identity *models.UserIdentity) error
+	GetIdentityBySubject(ctx context.Context, provider, subject string) (*models.UserIdentity, error)
+	GetUserIdentities(ctx context.Context, userID string) ([]*models.UserIdentity, error)
+
+	// DeleteIdentity removes the user's identity at a provider.
+	// Returns the number of deleted rows so callers can tell a missing link apart.
+	DeleteIdentity(ctx context.Context, userID, provider string) (int64, error)
+}
+
+type IdentityRepository struct {
+	db *gorm.DB
+}
+
+// NewIdentityRepository creates a new identity repository with the given database connection.
+func NewIdentityRepository(db *gorm.DB) IIdentityRepository {
+	return &IdentityRepository{db: db}
+}
+
+// CreateIdentity links a provider account to a user.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *IdentityRepository) CreateIdentity(ctx context.Context, identity *models.UserIdentity) error {
+	return r.db.WithContext(ctx).Create(identity).Error
+}
+
+// GetIdentityBySubject retrieves the identity of a provider account.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *IdentityRepository) GetIdentityBySubject(ctx context.Context, provider, subject string) (*models.UserIdentity, error) {
+	var identity models.UserIdentity
+	err := r.db.WithContext(ctx).
+		Where("provider = ? AND subject = ?", provider, subject).
+		First(&identity).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+// GetUserIdentities lists the providers linked to a user.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *IdentityRepository) GetUserIdentities(ctx context.Context, userID string) ([]*models.UserIdentity, error) {
+	var identities []*models.UserIdentity
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at").
+		Find(&identities).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return identities, nil
+}
+
+// DeleteIdentity unlinks a provider from a user.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *IdentityRepository) DeleteIdentity(ctx context.Context, userID, provider string) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("user_id = ? AND provider = ?", userID, provider).
+		Delete(&models.UserIdentity{})
+
+	return result.RowsAffected, result.Error
+}
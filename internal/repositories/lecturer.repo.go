@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type ILecturerRepository interface {
+	CreateLecturer(ctx context.Context, lecturer *models.Lecturer) error
+	GetLecturerByID(ctx context.Context, userID string, id int) (*models.Lecturer, error)
+	GetLecturers(ctx context.Context, userID string) ([]*models.Lecturer, error)
+
+	// CountLecturers counts how many of the given lecturers belong to the user
+	CountLecturers(ctx context.Context, userID string, ids []int) (int64, error)
+
+	// UpdateLecturer and DeleteLecturer return the number of affected rows
+	// so callers can tell a lecturer owned by another user apart.
+	UpdateLecturer(ctx context.Context, userID string, id int, updates map[string]interface{}) (int64, error)
+	DeleteLecturer(ctx context.Context, userID string, id int) (int64, error)
+}
+
+type LecturerRepository struct {
+	db *gorm.DB
+}
+
+// NewLecturerRepository creates a new lecturer repository with the given database connection.
+func NewLecturerRepository(db *gorm.DB) ILecturerRepository {
+	return &LecturerRepository{db: db}
+}
+
+// CreateLecturer inserts a new lecturer.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *LecturerRepository) CreateLecturer(ctx context.Context, lecturer *models.Lecturer) error {
+	return r.db.WithContext(ctx).Omit("Courses").Create(lecturer).Error
+}
+
+// GetLecturerByID retrieves a lecturer of the user.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *LecturerRepository) GetLecturerByID(ctx context.Context, userID string, id int) (*models.Lecturer, error) {
+	var lecturer models.Lecturer
+	err := r.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", id, userID).
+		First(&lecturer).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &lecturer, nil
+}
+
+// GetLecturers lists the user's lecturers by name.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *LecturerRepository) GetLecturers(ctx context.Context, userID string) ([]*models.Lecturer, error) {
+	var lecturers []*models.Lecturer
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("name").
+		Find(&lecturers).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return lecturers, nil
+}
+
+// CountLecturers counts the given lecturers owned by the user.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *LecturerRepository) CountLecturers(ctx context.Context, userID string, ids []int) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.Lecturer{}).
+		Where("user_id = ? AND id IN ?", userID, ids).
+		Count(&count).Error
+
+	return count, err
+}
+
+// UpdateLecturer updates the given columns of a lecturer owned by the user.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *LecturerRepository) UpdateLecturer(ctx context.Context, userID string, id int, updates map[string]interface{}) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.Lecturer{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Updates(updates)
+
+	return result.RowsAffected, result.Error
+}
+
+// DeleteLecturer removes a lecturer owned by the user, course links go through ON DELETE CASCADE.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *LecturerRepository) DeleteLecturer(ctx context.Context, userID string, id int) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", id, userID).
+		Delete(&models.Lecturer{})
+
+	return result.RowsAffected, result.Error
+}
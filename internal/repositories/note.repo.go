@@ -0,0 +1,184 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type INoteRepository interface {
+	// CreateNote inserts a note with its first revision and tags in one transaction
+	CreateNote(ctx context.Context, note *models.Note, revision *models.NoteRevision, tagIDs []int) error
+	GetNoteByID(ctx context.Context, userID string, id int) (*models.Note, error)
+	GetNotes(ctx context.Context, userID string, filter models.NoteFilter) ([]*models.Note, error)
+
+	// GetNoteRevisions lists the revisions of a note without their content,
+	// GetNoteRevision retrieves one in full. Callers must make sure the note belongs to the user.
+	GetNoteRevisions(ctx context.Context, noteID int) ([]*models.NoteRevision, error)
+	GetNoteRevision(ctx context.Context, noteID, version int) (*models.NoteRevision, error)
+
+	// UpdateNote and DeleteNote return the number of affected rows
+	// so callers can tell a note of another user, or a note saved since version, apart.
+	UpdateNote(ctx context.Context, userID string, id, version int, updates map[string]interface{}, revision *models.NoteRevision, tagIDs []int) (int64, error)
+	DeleteNote(ctx context.Context, userID string, id int) (int64, error)
+}
+
+type NoteRepository struct {
+	db *gorm.DB
+}
+
+// NewNoteRepository creates a new note repository with the given database connection.
+func NewNoteRepository(db *gorm.DB) INoteRepository {
+	return &NoteRepository{db: db}
+}
+
+// CreateNote inserts a note, then its first revision and its tag links.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *NoteRepository) CreateNote(ctx context.Context, note *models.Note, revision *models.NoteRevision, tagIDs []int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Course", "Tags").Create(note).Error; err != nil {
+			return err
+		}
+
+		revision.NoteID = note.ID
+		if err := tx.Omit("Note").Create(revision).Error; err != nil {
+			return err
+		}
+		return replaceNoteTags(tx, note.ID, tagIDs)
+	})
+}
+
+// GetNoteByID retrieves a note of the user with its tags.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *NoteRepository) GetNoteByID(ctx context.Context, userID string, id int) (*models.Note, error) {
+	var note models.Note
+	err := r.db.WithContext(ctx).
+		Preload("Tags").
+		Where("id = ? AND user_id = ?", id, userID).
+		First(&note).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+// GetNotes lists the user's notes matching the filter, latest lecture first.
+// filter.TagIDs must not contain duplicates.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *NoteRepository) GetNotes(ctx context.Context, userID string, filter models.NoteFilter) ([]*models.Note, error) {
+	var notes []*models.Note
+	query := r.db.WithContext(ctx).
+		Preload("Tags").
+		Where("user_id = ?", userID)
+
+	if filter.CourseID != 0 {
+		query = query.Where("course_id = ?", filter.CourseID)
+	}
+	if len(filter.TagIDs) > 0 {
+		taggedNotes := r.db.Model(&models.NoteTag{}).Select("note_id").Where("tag_id IN ?", filter.TagIDs)
+		if filter.Match == models.TagMatchAll {
+			// TagIDs are distinct, so a full match links the note to each of them once
+			taggedNotes = taggedNotes.Group("note_id").Having("COUNT(*) = ?", len(filter.TagIDs))
+		}
+		query = query.Where("id IN (?)", taggedNotes)
+	}
+	if filter.From != "" {
+		query = query.Where("lecture_date >= ?", filter.From)
+	}
+	if filter.To != "" {
+		query = query.Where("lecture_date <= ?", filter.To)
+	}
+
+	err := query.Order("lecture_date DESC, updated_at DESC, id DESC").Find(&notes).Error
+	if err != nil {
+		return nil, err
+	}
+	return notes, nil
+}
+
+// GetNoteRevisions lists the revisions of a note, latest first, leaving out their content.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *NoteRepository) GetNoteRevisions(ctx context.Context, noteID int) ([]*models.NoteRevision, error) {
+	var revisions []*models.NoteRevision
+	err := r.db.WithContext(ctx).
+		Omit("content").
+		Where("note_id = ?", noteID).
+		Order("version DESC").
+		Find(&revisions).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// GetNoteRevision retrieves a revision of a note by version.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *NoteRepository) GetNoteRevision(ctx context.Context, noteID, version int) (*models.NoteRevision, error) {
+	var revision models.NoteRevision
+	err := r.db.WithContext(ctx).
+		Where("note_id = ? AND version = ?", noteID, version).
+		First(&revision).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// UpdateNote updates the given columns of a note of the user still at version, adds the revision
+// when there is one and replaces the tag links, in one transaction.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *NoteRepository) UpdateNote(ctx context.Context, userID string, id, version int, updates map[string]interface{}, revision *models.NoteRevision, tagIDs []int) (int64, error) {
+	var rowsAffected int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Note{}).
+			Where("id = ? AND user_id = ? AND version = ?", id, userID, version).
+			Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		rowsAffected = result.RowsAffected
+		if rowsAffected == 0 {
+			return nil
+		}
+
+		if revision != nil {
+			revision.NoteID = id
+			if err := tx.Omit("Note").Create(revision).Error; err != nil {
+				return err
+			}
+		}
+		return replaceNoteTags(tx, id, tagIDs)
+	})
+
+	return rowsAffected, err
+}
+
+// DeleteNote removes a note of the user, its revisions and tag links go through ON DELETE CASCADE.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *NoteRepository) DeleteNote(ctx context.Context, userID string, id int) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", id, userID).
+		Delete(&models.Note{})
+
+	return result.RowsAffected, result.Error
+}
+
+// replaceNoteTags swaps the tag links of a note for the given tags
+func replaceNoteTags(tx *gorm.DB, noteID int, tagIDs []int) error {
+	if err := tx.Where("note_id = ?", noteID).Delete(&models.NoteTag{}).Error; err != nil {
+		return err
+	}
+	if len(tagIDs) == 0 {
+		return nil
+	}
+
+	links := make([]models.NoteTag, 0, len(tagIDs))
+	for _, tagID := range tagIDs {
+		links = append(links, models.NoteTag{NoteID: noteID, TagID: tagID})
+	}
+	return tx.Create(&links).Error
+}
@@ -0,0 +1,314 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/nas03/scholar-ai/backend/internal/consts"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IReminderRepository interface {
+	CreateReminder(ctx context.Context, reminder *models.Reminder) error
+	GetReminderByID(ctx context.Context, userID string, id int) (*models.Reminder, error)
+
+	// GetReminders lists the user's reminders by due time with their exception dates, now anchors filter.UpcomingDays
+	GetReminders(ctx context.Context, userID string, filter models.ReminderFilter, now time.Time) ([]*models.Reminder, error)
+
+	// GetOpenReminders lists the user's reminders that are not completed yet
+	GetOpenReminders(ctx context.Context, userID string) ([]*models.Reminder, error)
+
+	// GetRemindersInRange lists the user's one-off reminders due in [from, to) and the recurring
+	// reminders starting before to and not over before from, with their exception dates
+	GetRemindersInRange(ctx context.Context, userID string, courseID int, from, to time.Time) ([]*models.Reminder, error)
+
+	// GetCompletions lists the completed occurrences of the reminders dated in [from, last]
+	GetCompletions(ctx context.Context, reminderIDs []int, from, last time.Time) ([]*models.ReminderCompletion, error)
+
+	// UpdateReminder and DeleteReminder return the number of affected rows
+	// so callers can tell a reminder owned by another user apart.
+	UpdateReminder(ctx context.Context, userID string, id int, updates map[string]interface{}) (int64, error)
+	DeleteReminder(ctx context.Context, userID string, id int) (int64, error)
+
+	// UpdateReminderWithExDates also replaces the exception dates of the reminder
+	UpdateReminderWithExDates(ctx context.Context, userID string, id int, updates map[string]interface{}, exDates []models.ReminderExDate) (int64, error)
+
+	// CreateCompletion marks an occurrence completed, completing it again keeps the first completion
+	CreateCompletion(ctx context.Context, completion *models.ReminderCompletion) error
+
+	// SkipOccurrence adds an exception date to a series and forgets its completion
+	SkipOccurrence(ctx context.Context, seriesID int, date time.Time) error
+
+	// DetachOccurrence skips occurrence.OccurrenceDate in the series and creates the occurrence as a reminder of its own
+	DetachOccurrence(ctx context.Context, seriesID int, occurrence *models.Reminder) error
+
+	// SplitSeries ends a series before date with rrule, its last occurrence due at endAt, and creates next
+	// for date onwards, moving later completions and detached occurrences over to it
+	SplitSeries(ctx context.Context, seriesID int, date time.Time, rrule string, endAt *time.Time, next *models.Reminder) error
+
+	// TruncateSeries ends a series before date with rrule, its last occurrence due at endAt,
+	// and drops everything it had from date onwards
+	TruncateSeries(ctx context.Context, seriesID int, date time.Time, rrule string, endAt *time.Time) error
+
+	// MarkOverdueReminders moves pending reminders due before now to overdue
+	// and returns how many were moved
+	MarkOverdueReminders(ctx context.Context, now time.Time) (int64, error)
+}
+
+type ReminderRepository struct {
+	db *gorm.DB
+}
+
+// NewReminderRepository creates a new reminder repository with the given database connection.
+func NewReminderRepository(db *gorm.DB) IReminderRepository {
+	return &ReminderRepository{db: db}
+}
+
+// CreateReminder inserts a new reminder.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *ReminderRepository) CreateReminder(ctx context.Context, reminder *models.Reminder) error {
+	return r.db.WithContext(ctx).Omit("Course", "Series").Create(reminder).Error
+}
+
+// GetReminderByID retrieves a reminder of the user.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *ReminderRepository) GetReminderByID(ctx context.Context, userID string, id int) (*models.Reminder, error) {
+	var reminder models.Reminder
+	err := r.db.WithContext(ctx).
+		Preload("ExDates").
+		Where("id = ? AND user_id = ?", id, userID).
+		First(&reminder).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &reminder, nil
+}
+
+// GetReminders lists the user's reminders matching the filter.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *ReminderRepository) GetReminders(ctx context.Context, userID string, filter models.ReminderFilter, now time.Time) ([]*models.Reminder, error) {
+	var reminders []*models.Reminder
+	query := r.db.WithContext(ctx).Preload("ExDates").Where("user_id = ?", userID)
+
+	if filter.UpcomingDays != 0 {
+		query = query.Where("due_at BETWEEN ? AND ?", now, now.AddDate(0, 0, filter.UpcomingDays))
+	}
+	if filter.CourseID != 0 {
+		query = query.Where("course_id = ?", filter.CourseID)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+
+	err := query.Order("due_at, id").Find(&reminders).Error
+	if err != nil {
+		return nil, err
+	}
+	return reminders, nil
+}
+
+// GetOpenReminders lists the user's pending and overdue reminders.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *ReminderRepository) GetOpenReminders(ctx context.Context, userID string) ([]*models.Reminder, error) {
+	var reminders []*models.Reminder
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND status <> ?", userID, consts.ReminderStatus.COMPLETED).
+		Find(&reminders).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return reminders, nil
+}
+
+// GetRemindersInRange lists the reminders that may have an occurrence in the range.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *ReminderRepository) GetRemindersInRange(ctx context.Context, userID string, courseID int, from, to time.Time) ([]*models.Reminder, error) {
+	var reminders []*models.Reminder
+	query := r.db.WithContext(ctx).
+		Preload("ExDates").
+		Where("user_id = ?", userID).
+		Where("(rrule IS NULL AND due_at >= ? AND due_at < ?) OR (rrule IS NOT NULL AND due_at < ? AND (series_end_at IS NULL OR series_end_at >= ?))", from, to, to, from)
+
+	if courseID != 0 {
+		query = query.Where("course_id = ?", courseID)
+	}
+
+	err := query.Order("due_at, id").Find(&reminders).Error
+	if err != nil {
+		return nil, err
+	}
+	return reminders, nil
+}
+
+// GetCompletions lists completed occurrences of the reminders in a date range.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *ReminderRepository) GetCompletions(ctx context.Context, reminderIDs []int, from, last time.Time) ([]*models.ReminderCompletion, error) {
+	var completions []*models.ReminderCompletion
+	if len(reminderIDs) == 0 {
+		return completions, nil
+	}
+
+	err := r.db.WithContext(ctx).
+		Where("reminder_id IN ? AND occurrence_date BETWEEN ? AND ?", reminderIDs, from, last).
+		Find(&completions).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return completions, nil
+}
+
+// UpdateReminder updates the given columns of a reminder owned by the user.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *ReminderRepository) UpdateReminder(ctx context.Context, userID string, id int, updates map[string]interface{}) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.Reminder{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Updates(updates)
+
+	return result.RowsAffected, result.Error
+}
+
+// DeleteReminder removes a reminder owned by the user.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *ReminderRepository) DeleteReminder(ctx context.Context, userID string, id int) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", id, userID).
+		Delete(&models.Reminder{})
+
+	return result.RowsAffected, result.Error
+}
+
+// UpdateReminderWithExDates updates a reminder and replaces its exception dates in one transaction.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *ReminderRepository) UpdateReminderWithExDates(ctx context.Context, userID string, id int, updates map[string]interface{}, exDates []models.ReminderExDate) (int64, error) {
+	var rowsAffected int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Reminder{}).
+			Where("id = ? AND user_id = ?", id, userID).
+			Updates(updates)
+		if result.Error != nil || result.RowsAffected == 0 {
+			rowsAffected = result.RowsAffected
+			return result.Error
+		}
+		rowsAffected = result.RowsAffected
+
+		if err := tx.Where("reminder_id = ?", id).Delete(&models.ReminderExDate{}).Error; err != nil {
+			return err
+		}
+		if len(exDates) == 0 {
+			return nil
+		}
+		for i := range exDates {
+			exDates[i].ReminderID = id
+		}
+		return tx.Create(&exDates).Error
+	})
+
+	return rowsAffected, err
+}
+
+// CreateCompletion records a completed occurrence, ignoring one already recorded.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *ReminderRepository) CreateCompletion(ctx context.Context, completion *models.ReminderCompletion) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(completion).Error
+}
+
+// SkipOccurrence removes one occurrence from a series.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *ReminderRepository) SkipOccurrence(ctx context.Context, seriesID int, date time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return skipOccurrence(tx, seriesID, date)
+	})
+}
+
+// DetachOccurrence replaces one occurrence of a series by a reminder of its own.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *ReminderRepository) DetachOccurrence(ctx context.Context, seriesID int, occurrence *models.Reminder) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := skipOccurrence(tx, seriesID, *occurrence.OccurrenceDate); err != nil {
+			return err
+		}
+		return tx.Omit("Course", "Series").Create(occurrence).Error
+	})
+}
+
+// SplitSeries hands the occurrences of a series from date onwards to a new series.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *ReminderRepository) SplitSeries(ctx context.Context, seriesID int, date time.Time, rrule string, endAt *time.Time, next *models.Reminder) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := endSeries(tx, seriesID, date, rrule, endAt); err != nil {
+			return err
+		}
+		if err := tx.Omit("Course", "Series").Create(next).Error; err != nil {
+			return err
+		}
+
+		completions := tx.Model(&models.ReminderCompletion{}).
+			Where("reminder_id = ? AND occurrence_date >= ?", seriesID, date)
+		if next.RRule != nil {
+			if err := completions.Update("reminder_id", next.ID).Error; err != nil {
+				return err
+			}
+		} else if err := completions.Delete(&models.ReminderCompletion{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.Reminder{}).
+			Where("series_id = ? AND occurrence_date >= ?", seriesID, date).
+			Update("series_id", next.ID).Error
+	})
+}
+
+// TruncateSeries removes the occurrences of a series from date onwards, detached ones included.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *ReminderRepository) TruncateSeries(ctx context.Context, seriesID int, date time.Time, rrule string, endAt *time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := endSeries(tx, seriesID, date, rrule, endAt); err != nil {
+			return err
+		}
+		if err := tx.Where("reminder_id = ? AND occurrence_date >= ?", seriesID, date).Delete(&models.ReminderCompletion{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("series_id = ? AND occurrence_date >= ?", seriesID, date).Delete(&models.Reminder{}).Error
+	})
+}
+
+// MarkOverdueReminders flags past-due pending reminders in one statement, so concurrent runs are harmless.
+// A series stays pending, its occurrences are overdue on their own.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *ReminderRepository) MarkOverdueReminders(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.Reminder{}).
+		Where("status = ? AND due_at < ? AND rrule IS NULL", consts.ReminderStatus.PENDING, now).
+		Update("status", consts.ReminderStatus.OVERDUE)
+
+	return result.RowsAffected, result.Error
+}
+
+// skipOccurrence adds an exception date to a series within tx
+func skipOccurrence(tx *gorm.DB, seriesID int, date time.Time) error {
+	exDate := &models.ReminderExDate{ReminderID: seriesID, Date: date}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(exDate).Error; err != nil {
+		return err
+	}
+	return tx.Where("reminder_id = ? AND occurrence_date = ?", seriesID, date).Delete(&models.ReminderCompletion{}).Error
+}
+
+// endSeries sets the truncated rule and end of a series and drops its exception dates past the end within tx
+func endSeries(tx *gorm.DB, seriesID int, date time.Time, rrule string, endAt *time.Time) error {
+	updates := map[string]interface{}{
+		"rrule":         rrule,
+		"series_end_at": endAt,
+	}
+	if err := tx.Model(&models.Reminder{}).Where("id = ?", seriesID).Updates(updates).Error; err != nil {
+		return err
+	}
+	return tx.Where("reminder_id = ? AND date >= ?", seriesID, date).Delete(&models.ReminderExDate{}).Error
+}
@@ -0,0 +1,170 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/nas03/scholar-ai/backend/internal/consts"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IReminderDeliveryRepository interface {
+	// GetRemindersToNotify lists pending reminders due in (from, to] that have no delivery
+	// for this offset and due time yet
+	GetRemindersToNotify(ctx context.Context, from, to time.Time, offsetSeconds int64) ([]*models.Reminder, error)
+
+	// GetRecurringReminders lists the recurring reminders starting before the given time and not over
+	// after, with their exception dates and the completions of occurrences since completedSince
+	GetRecurringReminders(ctx context.Context, after, before time.Time, completedSince time.Time) ([]*models.Reminder, error)
+
+	// CreateDeliveries inserts the deliveries, skipping those already planned,
+	// and returns how many were inserted
+	CreateDeliveries(ctx context.Context, deliveries []*models.ReminderDelivery) (int64, error)
+
+	// GetDueDeliveries lists pending deliveries whose next attempt is due, with their reminder, its course,
+	// its exception dates and the completions of occurrences since completedSince
+	GetDueDeliveries(ctx context.Context, now time.Time, completedSince time.Time, limit int) ([]*models.ReminderDelivery, error)
+
+	// ClaimDelivery moves a pending delivery to sending and reports whether this caller won it
+	ClaimDelivery(ctx context.Context, id int) (bool, error)
+
+	// UpdateDelivery updates the given columns of a delivery
+	UpdateDelivery(ctx context.Context, id int, updates map[string]interface{}) error
+
+	// FinishDeliveryAttempt records the attempt and updates the delivery in one transaction
+	FinishDeliveryAttempt(ctx context.Context, attempt *models.ReminderDeliveryAttempt, updates map[string]interface{}) error
+
+	// FailStaleDeliveries gives up deliveries left sending since before the given time
+	// and returns how many were given up
+	FailStaleDeliveries(ctx context.Context, before time.Time) (int64, error)
+}
+
+type ReminderDeliveryRepository struct {
+	db *gorm.DB
+}
+
+// NewReminderDeliveryRepository creates a new reminder delivery repository with the given database connection.
+func NewReminderDeliveryRepository(db *gorm.DB) IReminderDeliveryRepository {
+	return &ReminderDeliveryRepository{db: db}
+}
+
+// GetRemindersToNotify lists reminders that entered the notification window of an offset.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *ReminderDeliveryRepository) GetRemindersToNotify(ctx context.Context, from, to time.Time, offsetSeconds int64) ([]*models.Reminder, error) {
+	var reminders []*models.Reminder
+	planned := r.db.Model(&models.ReminderDelivery{}).
+		Select("1").
+		Where("reminder_deliveries.reminder_id = reminders.id AND reminder_deliveries.due_at = reminders.due_at AND reminder_deliveries.offset_seconds = ?", offsetSeconds)
+
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND due_at > ? AND due_at <= ? AND rrule IS NULL", consts.ReminderStatus.PENDING, from, to).
+		Where("NOT EXISTS (?)", planned).
+		Find(&reminders).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return reminders, nil
+}
+
+// GetRecurringReminders lists the active series that may have occurrences to notify.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *ReminderDeliveryRepository) GetRecurringReminders(ctx context.Context, after, before time.Time, completedSince time.Time) ([]*models.Reminder, error) {
+	var reminders []*models.Reminder
+	err := r.db.WithContext(ctx).
+		Preload("ExDates").
+		Preload("Completions", "occurrence_date >= ?", completedSince).
+		Where("status = ? AND due_at <= ? AND rrule IS NOT NULL", consts.ReminderStatus.PENDING, before).
+		Where("series_end_at IS NULL OR series_end_at > ?", after).
+		Find(&reminders).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return reminders, nil
+}
+
+// CreateDeliveries inserts deliveries, the unique index makes concurrent planners harmless.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *ReminderDeliveryRepository) CreateDeliveries(ctx context.Context, deliveries []*models.ReminderDelivery) (int64, error) {
+	if len(deliveries) == 0 {
+		return 0, nil
+	}
+
+	result := r.db.WithContext(ctx).
+		Omit("Reminder").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&deliveries)
+
+	return result.RowsAffected, result.Error
+}
+
+// GetDueDeliveries lists deliveries ready to be sent, oldest first.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *ReminderDeliveryRepository) GetDueDeliveries(ctx context.Context, now time.Time, completedSince time.Time, limit int) ([]*models.ReminderDelivery, error) {
+	var deliveries []*models.ReminderDelivery
+	err := r.db.WithContext(ctx).
+		Preload("Reminder.Course").
+		Preload("Reminder.ExDates").
+		Preload("Reminder.Completions", "occurrence_date >= ?", completedSince).
+		Where("status = ? AND next_attempt_at <= ?", consts.ReminderDeliveryStatus.PENDING, now).
+		Order("next_attempt_at, id").
+		Limit(limit).
+		Find(&deliveries).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// ClaimDelivery is a conditional update, only one of several instances sees a row affected.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *ReminderDeliveryRepository) ClaimDelivery(ctx context.Context, id int) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.ReminderDelivery{}).
+		Where("id = ? AND status = ?", id, consts.ReminderDeliveryStatus.PENDING).
+		Update("status", consts.ReminderDeliveryStatus.SENDING)
+
+	return result.RowsAffected == 1, result.Error
+}
+
+// UpdateDelivery updates the given columns of a delivery.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *ReminderDeliveryRepository) UpdateDelivery(ctx context.Context, id int, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).
+		Model(&models.ReminderDelivery{}).
+		Where("id = ?", id).
+		Updates(updates).Error
+}
+
+// FinishDeliveryAttempt logs the attempt and applies its outcome to the delivery.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *ReminderDeliveryRepository) FinishDeliveryAttempt(ctx context.Context, attempt *models.ReminderDeliveryAttempt, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Delivery").Create(attempt).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.ReminderDelivery{}).
+			Where("id = ?", attempt.DeliveryID).
+			Updates(updates).Error
+	})
+}
+
+// FailStaleDeliveries fails deliveries whose sender died mid-send. They are not retried
+// since the notification may already have gone out.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *ReminderDeliveryRepository) FailStaleDeliveries(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.ReminderDelivery{}).
+		Where("status = ? AND updated_at < ?", consts.ReminderDeliveryStatus.SENDING, before).
+		Updates(map[string]interface{}{
+			"status":     consts.ReminderDeliveryStatus.FAILED,
+			"last_error": "sender stopped before reporting the outcome",
+		})
+
+	return result.RowsAffected, result.Error
+}
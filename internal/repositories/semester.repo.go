@@ -0,0 +1,120 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type ISemesterRepository interface {
+	CreateSemester(ctx context.Context, semester *models.Semester) error
+	GetSemesterByID(ctx context.Context, userID string, id int) (*models.Semester, error)
+	GetSemesters(ctx context.Context, userID string) ([]*models.Semester, error)
+
+	// GetSemesterByDate returns the user's semester whose date range contains the given time
+	GetSemesterByDate(ctx context.Context, userID string, date time.Time) (*models.Semester, error)
+
+	// CountOverlappingSemesters counts the user's semesters intersecting [startDate, endDate],
+	// excludeID leaves out the semester being updated
+	CountOverlappingSemesters(ctx context.Context, userID string, startDate, endDate time.Time, excludeID int) (int64, error)
+
+	// UpdateSemester and DeleteSemester return the number of affected rows
+	// so callers can tell a semester owned by another user apart.
+	UpdateSemester(ctx context.Context, userID string, id int, updates map[string]interface{}) (int64, error)
+	DeleteSemester(ctx context.Context, userID string, id int) (int64, error)
+}
+
+type SemesterRepository struct {
+	db *gorm.DB
+}
+
+// NewSemesterRepository creates a new semester repository with the given database connection.
+func NewSemesterRepository(db *gorm.DB) ISemesterRepository {
+	return &SemesterRepository{db: db}
+}
+
+// CreateSemester inserts a new semester.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *SemesterRepository) CreateSemester(ctx context.Context, semester *models.Semester) error {
+	return r.db.WithContext(ctx).Omit("Courses").Create(semester).Error
+}
+
+// GetSemesterByID retrieves a semester of the user.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *SemesterRepository) GetSemesterByID(ctx context.Context, userID string, id int) (*models.Semester, error) {
+	var semester models.Semester
+	err := r.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", id, userID).
+		First(&semester).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &semester, nil
+}
+
+// GetSemesters lists the user's semesters, most recent first.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *SemesterRepository) GetSemesters(ctx context.Context, userID string) ([]*models.Semester, error) {
+	var semesters []*models.Semester
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("start_date DESC").
+		Find(&semesters).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return semesters, nil
+}
+
+// GetSemesterByDate retrieves the user's semester running on the given date.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *SemesterRepository) GetSemesterByDate(ctx context.Context, userID string, date time.Time) (*models.Semester, error) {
+	var semester models.Semester
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND start_date <= ? AND end_date >= ?", userID, date, date).
+		Order("start_date DESC").
+		First(&semester).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &semester, nil
+}
+
+// CountOverlappingSemesters counts the user's other semesters sharing at least one day with the range.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *SemesterRepository) CountOverlappingSemesters(ctx context.Context, userID string, startDate, endDate time.Time, excludeID int) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.Semester{}).
+		Where("user_id = ? AND id <> ?", userID, excludeID).
+		Where("start_date <= ? AND end_date >= ?", endDate, startDate).
+		Count(&count).Error
+
+	return count, err
+}
+
+// UpdateSemester updates the given columns of a semester owned by the user.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *SemesterRepository) UpdateSemester(ctx context.Context, userID string, id int, updates map[string]interface{}) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.Semester{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Updates(updates)
+
+	return result.RowsAffected, result.Error
+}
+
+// DeleteSemester removes a semester owned by the user, courses still in it block the delete.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *SemesterRepository) DeleteSemester(ctx context.Context, userID string, id int) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", id, userID).
+		Delete(&models.Semester{})
+
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type ISessionRepository interface {
+	CreateSession(ctx context.Context, session *models.Session) error
+	GetSessionByID(ctx context.Context, sessionID string) (*models.Session, error)
+
+	// RotateRefreshToken swaps the refresh token hash only if oldHash is still current.
+	// Returns false when another request rotated the token first.
+	RotateRefreshToken(ctx context.Context, sessionID, oldHash, newHash string, expiresAt time.Time) (bool, error)
+	RevokeSession(ctx context.Context, sessionID string) error
+	RevokeUserSessions(ctx context.Context, userID string) ([]string, error)
+}
+
+type SessionRepository struct {
+	db *gorm.DB
+}
+
+// NewSessionRepository creates a new session repository with the given database connection.
+func NewSessionRepository(db *gorm.DB) ISessionRepository {
+	return &SessionRepository{db: db}
+}
+
+// CreateSession inserts a new refresh token family.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *SessionRepository) CreateSession(ctx context.Context, session *models.Session) error {
+	return r.db.WithContext(ctx).Create(session).Error
+}
+
+// GetSessionByID retrieves a session by ID, including revoked ones.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *SessionRepository) GetSessionByID(ctx context.Context, sessionID string) (*models.Session, error) {
+	var session models.Session
+	err := r.db.WithContext(ctx).
+		Where("session_id = ?", sessionID).
+		First(&session).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// RotateRefreshToken replaces the current refresh token hash and extends the session.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *SessionRepository) RotateRefreshToken(ctx context.Context, sessionID, oldHash, newHash string, expiresAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.Session{}).
+		Where("session_id = ? AND refresh_token_hash = ? AND revoked_at IS NULL", sessionID, oldHash).
+		Updates(map[string]interface{}{
+			"refresh_token_hash": newHash,
+			"expires_at":         expiresAt,
+		})
+
+	return result.RowsAffected == 1, result.Error
+}
+
+// RevokeSession marks a session as revoked.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *SessionRepository) RevokeSession(ctx context.Context, sessionID string) error {
+	result := r.db.WithContext(ctx).Model(&models.Session{}).
+		Where("session_id = ? AND revoked_at IS NULL", sessionID).
+		Update("revoked_at", time.Now())
+
+	return result.Error
+}
+
+// RevokeUserSessions revokes every active session of a user and returns their IDs.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *SessionRepository) RevokeUserSessions(ctx context.Context, userID string) ([]string, error) {
+	var sessionIDs []string
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Session{}).
+			Where("user_id = ? AND revoked_at IS NULL", userID).
+			Pluck("session_id", &sessionIDs).Error; err != nil {
+			return err
+		}
+		if len(sessionIDs) == 0 {
+			return nil
+		}
+
+		return tx.Model(&models.Session{}).
+			Where("session_id IN ?", sessionIDs).
+			Update("revoked_at", time.Now()).Error
+	})
+
+	return sessionIDs, err
+}
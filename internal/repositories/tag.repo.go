@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ITagRepository interface {
+	CreateTag(ctx context.Context, tag *models.Tag) error
+	GetTagByID(ctx context.Context, userID string, id int) (*models.Tag, error)
+	GetTags(ctx context.Context, userID string) ([]*models.Tag, error)
+
+	// CountTags counts how many of the given tags belong to the user
+	CountTags(ctx context.Context, userID string, ids []int) (int64, error)
+
+	// UpdateTag and DeleteTag return the number of affected rows
+	// so callers can tell a tag owned by another user apart.
+	UpdateTag(ctx context.Context, userID string, id int, updates map[string]interface{}) (int64, error)
+	DeleteTag(ctx context.Context, userID string, id int) (int64, error)
+
+	// AttachTags links every tag to every course, existing links are kept.
+	// DetachTags removes those links and returns how many existed.
+	// Callers must make sure courses and tags belong to the same user.
+	AttachTags(ctx context.Context, courseIDs, tagIDs []int) error
+	DetachTags(ctx context.Context, courseIDs, tagIDs []int) (int64, error)
+}
+
+type TagRepository struct {
+	db *gorm.DB
+}
+
+// NewTagRepository creates a new tag repository with the given database connection.
+func NewTagRepository(db *gorm.DB) ITagRepository {
+	return &TagRepository{db: db}
+}
+
+// CreateTag inserts a new tag.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *TagRepository) CreateTag(ctx context.Context, tag *models.Tag) error {
+	return r.db.WithContext(ctx).Omit("Courses").Create(tag).Error
+}
+
+// GetTagByID retrieves a tag of the user.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *TagRepository) GetTagByID(ctx context.Context, userID string, id int) (*models.Tag, error) {
+	var tag models.Tag
+	err := r.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", id, userID).
+		First(&tag).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// GetTags lists the user's tags by name.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *TagRepository) GetTags(ctx context.Context, userID string) ([]*models.Tag, error) {
+	var tags []*models.Tag
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("name").
+		Find(&tags).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// CountTags counts the given tags owned by the user.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *TagRepository) CountTags(ctx context.Context, userID string, ids []int) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.Tag{}).
+		Where("user_id = ? AND id IN ?", userID, ids).
+		Count(&count).Error
+
+	return count, err
+}
+
+// UpdateTag updates the given columns of a tag owned by the user.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *TagRepository) UpdateTag(ctx context.Context, userID string, id int, updates map[string]interface{}) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.Tag{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Updates(updates)
+
+	return result.RowsAffected, result.Error
+}
+
+// DeleteTag removes a tag owned by the user, course links go through ON DELETE CASCADE.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *TagRepository) DeleteTag(ctx context.Context, userID string, id int) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", id, userID).
+		Delete(&models.Tag{})
+
+	return result.RowsAffected, result.Error
+}
+
+// AttachTags inserts the missing course-tag links.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *TagRepository) AttachTags(ctx context.Context, courseIDs, tagIDs []int) error {
+	links := make([]models.CourseTag, 0, len(courseIDs)*len(tagIDs))
+	for _, courseID := range courseIDs {
+		for _, tagID := range tagIDs {
+			links = append(links, models.CourseTag{CourseID: courseID, TagID: tagID})
+		}
+	}
+
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&links).Error
+}
+
+// DetachTags deletes the course-tag links.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *TagRepository) DetachTags(ctx context.Context, courseIDs, tagIDs []int) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("course_id IN ? AND tag_id IN ?", courseIDs, tagIDs).
+		Delete(&models.CourseTag{})
+
+	return result.RowsAffected, result.Error
+}
@@ -2,7 +2,9 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/nas03/scholar-ai/backend/internal/consts"
 	"github.com/nas03/scholar-ai/backend/internal/models"
@@ -14,17 +16,33 @@ type IUserRepository interface {
 	CreateUser(ctx context.Context, user *models.User) error
 	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
 	GetUserByID(ctx context.Context, userID string) (*models.User, error)
+	GetUserByUsername(ctx context.Context, username string) (*models.User, error)
 
 	// Update operations
 	UpdateUserAccountStatus(ctx context.Context, userID string, status int8) error
 	UpdateUserPassword(ctx context.Context, userID, password string) error
 	UpdateUserVerification(ctx context.Context, userID string, isEmailVerified, isPhoneVerified bool) error
+	UpdateUserTotp(ctx context.Context, userID string, secret sql.NullString, isEnabled int8, recovery sql.NullString) error
+
+	// SwapTotpRecovery replaces the recovery codes only while they still equal current,
+	// it returns 0 rows when a concurrent request changed them first
+	SwapTotpRecovery(ctx context.Context, userID, current, recovery string) (int64, error)
+	UpdateUserPhone(ctx context.Context, userID, phoneNumber string, isPhoneVerified int8) error
 	UpdateUser(ctx context.Context, userID string, updates map[string]interface{}) error
 
+	// Deletion operations
+	GetUsersDueForDeletion(ctx context.Context, before time.Time, limit int) ([]string, error)
+	DeleteUser(ctx context.Context, userID string) error
+
 	// Transaction operations (like knex.js db.transaction)
 	WithTransaction(ctx context.Context, fn func(tx *gorm.DB) error) error
 }
 
+// userColumns are the columns loaded by the single-user getters
+const userColumns = "user_id, username, email, password, phone_number, account_status, is_email_verified, is_phone_verified, role, " +
+	"totp_secret, is_totp_enabled, totp_recovery, display_name, university, major, graduation_year, timezone, " +
+	"deletion_scheduled_at, created_at, updated_at"
+
 type UserRepository struct {
 	db *gorm.DB
 }
@@ -45,7 +63,7 @@ func (r *UserRepository) CreateUser(ctx context.Context, user *models.User) erro
 func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
 	var user models.User
 	err := r.db.WithContext(ctx).
-		Select("user_id, username, email, password, phone_number, account_status, is_email_verified, is_phone_verified, created_at, updated_at").
+		Select(userColumns).
 		Where("email = ?", email).
 		First(&user).Error
 
@@ -60,7 +78,7 @@ func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (*mod
 func (r *UserRepository) GetUserByID(ctx context.Context, userID string) (*models.User, error) {
 	var user models.User
 	err := r.db.WithContext(ctx).
-		Select("user_id, username, email, password, phone_number, account_status, is_email_verified, is_phone_verified, created_at, updated_at").
+		Select(userColumns).
 		Where("user_id = ?", userID).
 		First(&user).Error
 
@@ -70,6 +88,21 @@ func (r *UserRepository) GetUserByID(ctx context.Context, userID string) (*model
 	return &user, nil
 }
 
+// GetUserByUsername retrieves a user by username with optimized query.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *UserRepository) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	var user models.User
+	err := r.db.WithContext(ctx).
+		Select(userColumns).
+		Where("username = ?", username).
+		First(&user).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
 // UpdateUser updates user fields.
 // Returns raw GORM error - service layer should handle error interpretation
 func (r *UserRepository) UpdateUser(ctx context.Context, userID string, updates map[string]interface{}) error {
@@ -101,7 +134,7 @@ func (r *UserRepository) UpdateUserAccountStatus(ctx context.Context, userID str
 	return result.Error
 }
 
-// UpdateUserPassword updates a user's password.
+// UpdateUserPassword updates a user's password. The password must already be hashed.
 // Returns raw GORM error - service layer should handle error interpretation
 func (r *UserRepository) UpdateUserPassword(ctx context.Context, userID, password string) error {
 	result := r.db.WithContext(ctx).Model(&models.User{}).
@@ -126,6 +159,76 @@ func (r *UserRepository) UpdateUserVerification(ctx context.Context, userID stri
 	return result.Error
 }
 
+// UpdateUserTotp updates the two-factor secret, state and hashed recovery codes.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *UserRepository) UpdateUserTotp(ctx context.Context, userID string, secret sql.NullString, isEnabled int8, recovery sql.NullString) error {
+	updates := map[string]interface{}{
+		"totp_secret":     secret,
+		"is_totp_enabled": isEnabled,
+		"totp_recovery":   recovery,
+	}
+
+	result := r.db.WithContext(ctx).Model(&models.User{}).
+		Where("user_id = ?", userID).
+		Updates(updates)
+
+	return result.Error
+}
+
+// SwapTotpRecovery writes the hashed recovery codes of a user whose codes are still current.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *UserRepository) SwapTotpRecovery(ctx context.Context, userID, current, recovery string) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&models.User{}).
+		Where("user_id = ? AND is_totp_enabled = 1 AND totp_recovery = ?", userID, current).
+		Update("totp_recovery", recovery)
+
+	return result.RowsAffected, result.Error
+}
+
+// UpdateUserPhone sets the phone number and its verification state.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *UserRepository) UpdateUserPhone(ctx context.Context, userID, phoneNumber string, isPhoneVerified int8) error {
+	updates := map[string]interface{}{
+		"phone_number":      phoneNumber,
+		"is_phone_verified": isPhoneVerified,
+	}
+
+	result := r.db.WithContext(ctx).Model(&models.User{}).
+		Where("user_id = ?", userID).
+		Updates(updates)
+
+	return result.Error
+}
+
+// GetUsersDueForDeletion returns the ids of users whose deletion grace period ended before the given time.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *UserRepository) GetUsersDueForDeletion(ctx context.Context, before time.Time, limit int) ([]string, error) {
+	var userIDs []string
+	err := r.db.WithContext(ctx).Model(&models.User{}).
+		Where("deletion_scheduled_at IS NOT NULL AND deletion_scheduled_at <= ?", before).
+		Order("deletion_scheduled_at").
+		Limit(limit).
+		Pluck("user_id", &userIDs).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return userIDs, nil
+}
+
+// DeleteUser hard deletes a user. Owned rows go through the ON DELETE CASCADE foreign keys,
+// courses go first so the semester RESTRICT does not depend on the cascade order.
+// Returns raw GORM error - service layer should handle error interpretation
+func (r *UserRepository) DeleteUser(ctx context.Context, userID string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.Course{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("user_id = ?", userID).Delete(&models.User{}).Error
+	})
+}
+
 // WithTransaction executes a function within a database transaction (like knex.js db.transaction)
 func (r *UserRepository) WithTransaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
@@ -0,0 +1,40 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/consts"
+	"github.com/nas03/scholar-ai/backend/internal/controllers"
+	"github.com/nas03/scholar-ai/backend/internal/helper"
+	"github.com/nas03/scholar-ai/backend/internal/middleware"
+	"github.com/nas03/scholar-ai/backend/internal/repositories"
+	"github.com/nas03/scholar-ai/backend/internal/services"
+)
+
+// SetupAdminRoutes configures all admin-only routes
+func SetupAdminRoutes(apiV1 *gin.RouterGroup) {
+
+	// Initialize dependencies
+	userRepo := repositories.NewUserRepository(global.Mdb)
+	sessionRepo := repositories.NewSessionRepository(global.Mdb)
+	loginAttemptService := services.NewLoginAttemptService(helper.NewMailHelper())
+	twoFactorService := services.NewTwoFactorService(userRepo)
+	authService := services.NewAuthService(userRepo, sessionRepo, loginAttemptService, twoFactorService)
+	courseRepo := repositories.NewCourseRepository(global.Mdb)
+	semesterRepo := repositories.NewSemesterRepository(global.Mdb)
+	assessmentRepo := repositories.NewAssessmentRepository(global.Mdb)
+	gradingScaleRepo := repositories.NewGradingScaleRepository(global.Mdb)
+	semesterService := services.NewSemesterService(semesterRepo, userRepo)
+	gradingService := services.NewGradingService(gradingScaleRepo, courseRepo, assessmentRepo, userRepo, semesterService)
+	adminController := controllers.NewAdminController(authService, gradingService)
+
+	// Admin routes
+	admin := apiV1.Group("/admin")
+	admin.Use(middleware.AuthMiddleware(userRepo, sessionRepo), middleware.RequireRole(consts.UserRole.ADMIN))
+	{
+		admin.GET("/login-attempts", adminController.GetLoginAttempts)
+		admin.POST("/grading-scales", adminController.CreateGradingScale)
+		admin.PUT("/grading-scales/:id", adminController.UpdateGradingScale)
+		admin.DELETE("/grading-scales/:id", adminController.DeleteGradingScale)
+	}
+}
@@ -0,0 +1,64 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/controllers"
+	"github.com/nas03/scholar-ai/backend/internal/helper"
+	"github.com/nas03/scholar-ai/backend/internal/middleware"
+	"github.com/nas03/scholar-ai/backend/internal/repositories"
+	"github.com/nas03/scholar-ai/backend/internal/services"
+)
+
+// SetupAuthRoutes configures all authentication routes
+func SetupAuthRoutes(apiV1 *gin.RouterGroup) {
+
+	// Initialize dependencies
+	userRepo := repositories.NewUserRepository(global.Mdb)
+	sessionRepo := repositories.NewSessionRepository(global.Mdb)
+	loginAttemptService := services.NewLoginAttemptService(helper.NewMailHelper())
+	twoFactorService := services.NewTwoFactorService(userRepo)
+	authService := services.NewAuthService(userRepo, sessionRepo, loginAttemptService, twoFactorService)
+	ssoService := services.NewSsoService(userRepo, repositories.NewIdentityRepository(global.Mdb), authService, helper.NewOidcProviders())
+	authController := controllers.NewAuthController(authService)
+	twoFactorController := controllers.NewTwoFactorController(twoFactorService)
+	ssoController := controllers.NewSsoController(ssoService)
+
+	// Auth routes
+	auth := apiV1.Group("/auth")
+	{
+		auth.POST("/login", authController.Login)
+		auth.POST("/refresh", authController.RefreshToken)
+		auth.POST("/logout", authController.Logout)
+		auth.POST("/forgot-password", authController.ForgotPassword)
+		auth.POST("/reset-password", authController.ResetPassword)
+		auth.POST("/2fa/verify", authController.VerifyTwoFactorLogin)
+	}
+
+	// Two-factor management routes (authenticated)
+	twoFactor := auth.Group("/2fa")
+	twoFactor.Use(middleware.AuthMiddleware(userRepo, sessionRepo))
+	{
+		twoFactor.POST("/enroll", twoFactorController.Enroll)
+		twoFactor.POST("/confirm", twoFactorController.Confirm)
+		twoFactor.POST("/disable", twoFactorController.Disable)
+	}
+
+	// Single sign-on routes
+	sso := auth.Group("/sso")
+	{
+		sso.GET("/providers", ssoController.GetProviders)
+		sso.GET("/:provider/authorize", ssoController.Authorize)
+		sso.POST("/:provider/callback", ssoController.Callback)
+	}
+
+	// Provider linking routes (authenticated)
+	ssoLink := sso.Group("")
+	ssoLink.Use(middleware.AuthMiddleware(userRepo, sessionRepo))
+	{
+		ssoLink.GET("/identities", ssoController.GetIdentities)
+		ssoLink.GET("/:provider/link", ssoController.AuthorizeLink)
+		ssoLink.POST("/:provider/link", ssoController.LinkCallback)
+		ssoLink.DELETE("/:provider", ssoController.Unlink)
+	}
+}
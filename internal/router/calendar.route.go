@@ -0,0 +1,43 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/controllers"
+	"github.com/nas03/scholar-ai/backend/internal/middleware"
+	"github.com/nas03/scholar-ai/backend/internal/repositories"
+	"github.com/nas03/scholar-ai/backend/internal/services"
+)
+
+// SetupCalendarRoutes configures the iCalendar export, import and subscription feed routes
+func SetupCalendarRoutes(apiV1 *gin.RouterGroup) {
+
+	// Initialize dependencies
+	userRepo := repositories.NewUserRepository(global.Mdb)
+	sessionRepo := repositories.NewSessionRepository(global.Mdb)
+	semesterRepo := repositories.NewSemesterRepository(global.Mdb)
+	classSessionRepo := repositories.NewClassSessionRepository(global.Mdb)
+	reminderRepo := repositories.NewReminderRepository(global.Mdb)
+	calendarFeedRepo := repositories.NewCalendarFeedRepository(global.Mdb)
+	courseRepo := repositories.NewCourseRepository(global.Mdb)
+	calendarImportRepo := repositories.NewCalendarImportRepository(global.Mdb)
+	calendarService := services.NewCalendarService(userRepo, semesterRepo, classSessionRepo, reminderRepo, calendarFeedRepo)
+	calendarImportService := services.NewCalendarImportService(userRepo, semesterRepo, courseRepo, classSessionRepo, reminderRepo, calendarImportRepo)
+	calendarController := controllers.NewCalendarController(calendarService, calendarImportService)
+
+	calendar := apiV1.Group("/calendar")
+
+	// Feed route (public), the secret token in the URL stands in for the access token
+	calendar.GET("/feed/:token", calendarController.GetFeed)
+
+	// Calendar routes (authenticated)
+	authenticated := calendar.Group("")
+	authenticated.Use(middleware.AuthMiddleware(userRepo, sessionRepo))
+	{
+		authenticated.GET("/export", calendarController.ExportCalendar)
+		authenticated.GET("/subscription", calendarController.GetSubscription)
+		authenticated.POST("/subscription", calendarController.RotateSubscription)
+		authenticated.DELETE("/subscription", calendarController.RevokeSubscription)
+		authenticated.POST("/import", calendarController.ImportCalendar)
+	}
+}
@@ -0,0 +1,42 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/controllers"
+	"github.com/nas03/scholar-ai/backend/internal/middleware"
+	"github.com/nas03/scholar-ai/backend/internal/repositories"
+	"github.com/nas03/scholar-ai/backend/internal/services"
+)
+
+// SetupClassSessionRoutes configures the class session and timetable routes of the authenticated user
+func SetupClassSessionRoutes(apiV1 *gin.RouterGroup) {
+
+	// Initialize dependencies
+	userRepo := repositories.NewUserRepository(global.Mdb)
+	sessionRepo := repositories.NewSessionRepository(global.Mdb)
+	classSessionRepo := repositories.NewClassSessionRepository(global.Mdb)
+	courseRepo := repositories.NewCourseRepository(global.Mdb)
+	semesterRepo := repositories.NewSemesterRepository(global.Mdb)
+	semesterService := services.NewSemesterService(semesterRepo, userRepo)
+	classSessionService := services.NewClassSessionService(classSessionRepo, courseRepo, semesterService)
+	classSessionController := controllers.NewClassSessionController(classSessionService)
+
+	// Class session routes (authenticated)
+	sessions := apiV1.Group("/class-sessions")
+	sessions.Use(middleware.AuthMiddleware(userRepo, sessionRepo))
+	{
+		sessions.POST("", classSessionController.CreateClassSession)
+		sessions.GET("", classSessionController.GetClassSessions)
+		sessions.GET("/:id", classSessionController.GetClassSession)
+		sessions.PUT("/:id", classSessionController.UpdateClassSession)
+		sessions.DELETE("/:id", classSessionController.DeleteClassSession)
+	}
+
+	// Timetable routes (authenticated)
+	timetable := apiV1.Group("/timetable")
+	timetable.Use(middleware.AuthMiddleware(userRepo, sessionRepo))
+	{
+		timetable.GET("", classSessionController.GetTimetable)
+	}
+}
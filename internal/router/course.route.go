@@ -0,0 +1,49 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/controllers"
+	"github.com/nas03/scholar-ai/backend/internal/middleware"
+	"github.com/nas03/scholar-ai/backend/internal/repositories"
+	"github.com/nas03/scholar-ai/backend/internal/services"
+)
+
+// SetupCourseRoutes configures the course and assessment component routes of the authenticated user
+func SetupCourseRoutes(apiV1 *gin.RouterGroup) {
+
+	// Initialize dependencies
+	userRepo := repositories.NewUserRepository(global.Mdb)
+	sessionRepo := repositories.NewSessionRepository(global.Mdb)
+	courseRepo := repositories.NewCourseRepository(global.Mdb)
+	semesterRepo := repositories.NewSemesterRepository(global.Mdb)
+	lecturerRepo := repositories.NewLecturerRepository(global.Mdb)
+	assessmentRepo := repositories.NewAssessmentRepository(global.Mdb)
+	gradingScaleRepo := repositories.NewGradingScaleRepository(global.Mdb)
+	semesterService := services.NewSemesterService(semesterRepo, userRepo)
+	gradingService := services.NewGradingService(gradingScaleRepo, courseRepo, assessmentRepo, userRepo, semesterService)
+	courseService := services.NewCourseService(courseRepo, semesterRepo, lecturerRepo, gradingService)
+	assessmentService := services.NewAssessmentService(assessmentRepo, courseRepo, gradingService)
+	courseController := controllers.NewCourseController(courseService)
+	assessmentController := controllers.NewAssessmentController(assessmentService)
+
+	// Course routes (authenticated)
+	courses := apiV1.Group("/courses")
+	courses.Use(middleware.AuthMiddleware(userRepo, sessionRepo))
+	{
+		courses.POST("", courseController.CreateCourse)
+		courses.GET("", courseController.GetCourses)
+		courses.GET("/:id", courseController.GetCourse)
+		courses.PUT("/:id", courseController.UpdateCourse)
+		courses.DELETE("/:id", courseController.DeleteCourse)
+		courses.GET("/:id/assessments", assessmentController.GetAssessments)
+		courses.PUT("/:id/assessments", assessmentController.ReplaceAssessments)
+	}
+
+	// Assessment component routes (authenticated)
+	assessments := apiV1.Group("/assessments")
+	assessments.Use(middleware.AuthMiddleware(userRepo, sessionRepo))
+	{
+		assessments.PUT("/:id/score", assessmentController.UpdateAssessmentScore)
+	}
+}
@@ -0,0 +1,55 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/controllers"
+	"github.com/nas03/scholar-ai/backend/internal/middleware"
+	"github.com/nas03/scholar-ai/backend/internal/repositories"
+	"github.com/nas03/scholar-ai/backend/internal/services"
+)
+
+// SetupCourseworkRoutes configures the assignment, exam and upcoming deadline routes of the authenticated user
+func SetupCourseworkRoutes(apiV1 *gin.RouterGroup) {
+
+	// Initialize dependencies
+	userRepo := repositories.NewUserRepository(global.Mdb)
+	sessionRepo := repositories.NewSessionRepository(global.Mdb)
+	courseRepo := repositories.NewCourseRepository(global.Mdb)
+	assignmentRepo := repositories.NewAssignmentRepository(global.Mdb)
+	examRepo := repositories.NewExamRepository(global.Mdb)
+	assessmentRepo := repositories.NewAssessmentRepository(global.Mdb)
+	courseworkService := services.NewCourseworkService(assignmentRepo, examRepo, courseRepo, assessmentRepo, userRepo)
+	courseworkController := controllers.NewCourseworkController(courseworkService)
+
+	// Assignment routes (authenticated)
+	assignments := apiV1.Group("/assignments")
+	assignments.Use(middleware.AuthMiddleware(userRepo, sessionRepo))
+	{
+		assignments.POST("", courseworkController.CreateAssignment)
+		assignments.GET("", courseworkController.GetAssignments)
+		assignments.GET("/:id", courseworkController.GetAssignment)
+		assignments.PUT("/:id", courseworkController.UpdateAssignment)
+		assignments.PUT("/:id/status", courseworkController.UpdateAssignmentStatus)
+		assignments.DELETE("/:id", courseworkController.DeleteAssignment)
+	}
+
+	// Exam routes (authenticated)
+	exams := apiV1.Group("/exams")
+	exams.Use(middleware.AuthMiddleware(userRepo, sessionRepo))
+	{
+		exams.POST("", courseworkController.CreateExam)
+		exams.GET("", courseworkController.GetExams)
+		exams.GET("/:id", courseworkController.GetExam)
+		exams.PUT("/:id", courseworkController.UpdateExam)
+		exams.PUT("/:id/status", courseworkController.UpdateExamStatus)
+		exams.DELETE("/:id", courseworkController.DeleteExam)
+	}
+
+	// Upcoming deadline routes (authenticated)
+	upcoming := apiV1.Group("/upcoming")
+	upcoming.Use(middleware.AuthMiddleware(userRepo, sessionRepo))
+	{
+		upcoming.GET("", courseworkController.GetUpcoming)
+	}
+}
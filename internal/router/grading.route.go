@@ -0,0 +1,47 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/controllers"
+	"github.com/nas03/scholar-ai/backend/internal/middleware"
+	"github.com/nas03/scholar-ai/backend/internal/repositories"
+	"github.com/nas03/scholar-ai/backend/internal/services"
+)
+
+// SetupGradingRoutes configures the grading scale and GPA routes of the authenticated user
+func SetupGradingRoutes(apiV1 *gin.RouterGroup) {
+
+	// Initialize dependencies
+	userRepo := repositories.NewUserRepository(global.Mdb)
+	sessionRepo := repositories.NewSessionRepository(global.Mdb)
+	courseRepo := repositories.NewCourseRepository(global.Mdb)
+	semesterRepo := repositories.NewSemesterRepository(global.Mdb)
+	assessmentRepo := repositories.NewAssessmentRepository(global.Mdb)
+	gradingScaleRepo := repositories.NewGradingScaleRepository(global.Mdb)
+	semesterService := services.NewSemesterService(semesterRepo, userRepo)
+	gradingService := services.NewGradingService(gradingScaleRepo, courseRepo, assessmentRepo, userRepo, semesterService)
+	gradingController := controllers.NewGradingController(gradingService)
+
+	// Grading scale routes (authenticated)
+	scales := apiV1.Group("/grading-scales")
+	scales.Use(middleware.AuthMiddleware(userRepo, sessionRepo))
+	{
+		scales.POST("", gradingController.CreateGradingScale)
+		scales.GET("", gradingController.GetGradingScales)
+		scales.GET("/:id", gradingController.GetGradingScale)
+		scales.PUT("/:id", gradingController.UpdateGradingScale)
+		scales.DELETE("/:id", gradingController.DeleteGradingScale)
+	}
+
+	// GPA routes (authenticated)
+	gpa := apiV1.Group("/gpa")
+	gpa.Use(middleware.AuthMiddleware(userRepo, sessionRepo))
+	{
+		gpa.GET("", gradingController.GetGPA)
+		gpa.POST("/target", gradingController.PlanTargetGPA)
+		gpa.POST("/what-if", gradingController.SimulateGPA)
+		gpa.GET("/settings", gradingController.GetGpaSetting)
+		gpa.PUT("/settings", gradingController.UpdateGpaSetting)
+	}
+}
@@ -0,0 +1,34 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/controllers"
+	"github.com/nas03/scholar-ai/backend/internal/middleware"
+	"github.com/nas03/scholar-ai/backend/internal/repositories"
+	"github.com/nas03/scholar-ai/backend/internal/services"
+)
+
+// SetupLecturerRoutes configures the lecturer routes of the authenticated user
+func SetupLecturerRoutes(apiV1 *gin.RouterGroup) {
+
+	// Initialize dependencies
+	userRepo := repositories.NewUserRepository(global.Mdb)
+	sessionRepo := repositories.NewSessionRepository(global.Mdb)
+	lecturerRepo := repositories.NewLecturerRepository(global.Mdb)
+	courseRepo := repositories.NewCourseRepository(global.Mdb)
+	lecturerService := services.NewLecturerService(lecturerRepo, courseRepo)
+	lecturerController := controllers.NewLecturerController(lecturerService)
+
+	// Lecturer routes (authenticated)
+	lecturers := apiV1.Group("/lecturers")
+	lecturers.Use(middleware.AuthMiddleware(userRepo, sessionRepo))
+	{
+		lecturers.POST("", lecturerController.CreateLecturer)
+		lecturers.GET("", lecturerController.GetLecturers)
+		lecturers.GET("/:id", lecturerController.GetLecturer)
+		lecturers.PUT("/:id", lecturerController.UpdateLecturer)
+		lecturers.DELETE("/:id", lecturerController.DeleteLecturer)
+		lecturers.GET("/:id/courses", lecturerController.GetLecturerCourses)
+	}
+}
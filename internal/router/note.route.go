@@ -0,0 +1,40 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/controllers"
+	"github.com/nas03/scholar-ai/backend/internal/middleware"
+	"github.com/nas03/scholar-ai/backend/internal/repositories"
+	"github.com/nas03/scholar-ai/backend/internal/services"
+)
+
+// SetupNoteRoutes configures the note and note revision routes of the authenticated user
+func SetupNoteRoutes(apiV1 *gin.RouterGroup) {
+
+	// Initialize dependencies
+	userRepo := repositories.NewUserRepository(global.Mdb)
+	sessionRepo := repositories.NewSessionRepository(global.Mdb)
+	courseRepo := repositories.NewCourseRepository(global.Mdb)
+	tagRepo := repositories.NewTagRepository(global.Mdb)
+	noteRepo := repositories.NewNoteRepository(global.Mdb)
+	noteService := services.NewNoteService(noteRepo, courseRepo, tagRepo)
+	noteController := controllers.NewNoteController(noteService)
+
+	// Note routes (authenticated)
+	notes := apiV1.Group("/notes")
+	notes.Use(middleware.AuthMiddleware(userRepo, sessionRepo))
+	{
+		notes.POST("", noteController.CreateNote)
+		notes.GET("", noteController.GetNotes)
+		notes.GET("/:id", noteController.GetNote)
+		notes.PUT("/:id", noteController.UpdateNote)
+		notes.DELETE("/:id", noteController.DeleteNote)
+
+		// Revision history
+		notes.GET("/:id/revisions", noteController.GetNoteRevisions)
+		notes.GET("/:id/revisions/:version", noteController.GetNoteRevision)
+		notes.POST("/:id/revisions/:version/restore", noteController.RestoreNoteRevision)
+		notes.GET("/:id/diff", noteController.DiffNoteRevisions)
+	}
+}
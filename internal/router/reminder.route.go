@@ -0,0 +1,35 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/controllers"
+	"github.com/nas03/scholar-ai/backend/internal/middleware"
+	"github.com/nas03/scholar-ai/backend/internal/repositories"
+	"github.com/nas03/scholar-ai/backend/internal/services"
+)
+
+// SetupReminderRoutes configures the reminder routes of the authenticated user
+func SetupReminderRoutes(apiV1 *gin.RouterGroup) {
+
+	// Initialize dependencies
+	userRepo := repositories.NewUserRepository(global.Mdb)
+	sessionRepo := repositories.NewSessionRepository(global.Mdb)
+	reminderRepo := repositories.NewReminderRepository(global.Mdb)
+	courseRepo := repositories.NewCourseRepository(global.Mdb)
+	reminderService := services.NewReminderService(reminderRepo, courseRepo, userRepo)
+	reminderController := controllers.NewReminderController(reminderService)
+
+	// Reminder routes (authenticated)
+	reminders := apiV1.Group("/reminders")
+	reminders.Use(middleware.AuthMiddleware(userRepo, sessionRepo))
+	{
+		reminders.POST("", reminderController.CreateReminder)
+		reminders.GET("", reminderController.GetReminders)
+		reminders.GET("/occurrences", reminderController.GetOccurrences)
+		reminders.GET("/:id", reminderController.GetReminder)
+		reminders.PUT("/:id", reminderController.UpdateReminder)
+		reminders.DELETE("/:id", reminderController.DeleteReminder)
+		reminders.POST("/:id/complete", reminderController.CompleteReminder)
+	}
+}
@@ -0,0 +1,33 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/controllers"
+	"github.com/nas03/scholar-ai/backend/internal/middleware"
+	"github.com/nas03/scholar-ai/backend/internal/repositories"
+	"github.com/nas03/scholar-ai/backend/internal/services"
+)
+
+// SetupSemesterRoutes configures the semester routes of the authenticated user
+func SetupSemesterRoutes(apiV1 *gin.RouterGroup) {
+
+	// Initialize dependencies
+	userRepo := repositories.NewUserRepository(global.Mdb)
+	sessionRepo := repositories.NewSessionRepository(global.Mdb)
+	semesterRepo := repositories.NewSemesterRepository(global.Mdb)
+	semesterService := services.NewSemesterService(semesterRepo, userRepo)
+	semesterController := controllers.NewSemesterController(semesterService)
+
+	// Semester routes (authenticated)
+	semesters := apiV1.Group("/semesters")
+	semesters.Use(middleware.AuthMiddleware(userRepo, sessionRepo))
+	{
+		semesters.POST("", semesterController.CreateSemester)
+		semesters.GET("", semesterController.GetSemesters)
+		semesters.GET("/current", semesterController.GetCurrentSemester)
+		semesters.GET("/:id", semesterController.GetSemester)
+		semesters.PUT("/:id", semesterController.UpdateSemester)
+		semesters.DELETE("/:id", semesterController.DeleteSemester)
+	}
+}
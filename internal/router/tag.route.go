@@ -0,0 +1,35 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/controllers"
+	"github.com/nas03/scholar-ai/backend/internal/middleware"
+	"github.com/nas03/scholar-ai/backend/internal/repositories"
+	"github.com/nas03/scholar-ai/backend/internal/services"
+)
+
+// SetupTagRoutes configures the tag routes of the authenticated user
+func SetupTagRoutes(apiV1 *gin.RouterGroup) {
+
+	// Initialize dependencies
+	userRepo := repositories.NewUserRepository(global.Mdb)
+	sessionRepo := repositories.NewSessionRepository(global.Mdb)
+	tagRepo := repositories.NewTagRepository(global.Mdb)
+	courseRepo := repositories.NewCourseRepository(global.Mdb)
+	tagService := services.NewTagService(tagRepo, courseRepo)
+	tagController := controllers.NewTagController(tagService)
+
+	// Tag routes (authenticated)
+	tags := apiV1.Group("/tags")
+	tags.Use(middleware.AuthMiddleware(userRepo, sessionRepo))
+	{
+		tags.POST("", tagController.CreateTag)
+		tags.GET("", tagController.GetTags)
+		tags.POST("/attach", tagController.AttachTags)
+		tags.POST("/detach", tagController.DetachTags)
+		tags.GET("/:id", tagController.GetTag)
+		tags.PUT("/:id", tagController.UpdateTag)
+		tags.DELETE("/:id", tagController.DeleteTag)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/nas03/scholar-ai/backend/global"
 	"github.com/nas03/scholar-ai/backend/internal/controllers"
+	"github.com/nas03/scholar-ai/backend/internal/helper"
+	"github.com/nas03/scholar-ai/backend/internal/middleware"
 	"github.com/nas03/scholar-ai/backend/internal/repositories"
 	"github.com/nas03/scholar-ai/backend/internal/services"
 )
@@ -13,13 +15,38 @@ func SetupUserRoutes(apiV1 *gin.RouterGroup) {
 
 	// Initialize dependencies
 	userRepo := repositories.NewUserRepository(global.Mdb)
+	sessionRepo := repositories.NewSessionRepository(global.Mdb)
 	userService := services.NewUserService(userRepo)
+	phoneService := services.NewPhoneService(userRepo, helper.NewSmsHelper())
+	reminderRepo := repositories.NewReminderRepository(global.Mdb)
+	courseRepo := repositories.NewCourseRepository(global.Mdb)
+	reminderService := services.NewReminderService(reminderRepo, courseRepo, userRepo)
+	profileService := services.NewProfileService(userRepo, helper.NewMailHelper(), reminderService)
 	userController := controllers.NewUserController(userService)
+	phoneController := controllers.NewPhoneController(phoneService)
+	profileController := controllers.NewProfileController(profileService)
 
 	// User routes
 	users := apiV1.Group("/users")
 	{
 		users.POST("/create", userController.CreateUser)
+		users.POST("/verify-email", userController.VerifyUserEmail)
+		users.POST("/resend-verification", userController.ResendVerificationEmail)
 		users.GET("/ping", controllers.Ping) // Keep ping for testing
 	}
+
+	// Current user routes (authenticated)
+	me := users.Group("/me")
+	me.Use(middleware.AuthMiddleware(userRepo, sessionRepo))
+	{
+		me.GET("", profileController.GetProfile)
+		me.PATCH("", profileController.UpdateProfile)
+		me.DELETE("", profileController.DeleteAccount)
+		me.POST("/deletion/cancel", profileController.CancelDeletion)
+		me.POST("/email", profileController.RequestEmailChange)
+		me.POST("/email/verify", profileController.ConfirmEmailChange)
+		me.PUT("/phone", phoneController.AddPhoneNumber)
+		me.POST("/phone/send-otp", phoneController.SendPhoneOtp)
+		me.POST("/phone/verify", phoneController.VerifyPhoneNumber)
+	}
 }
@@ -0,0 +1,231 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/consts"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	repo "github.com/nas03/scholar-ai/backend/internal/repositories"
+	"github.com/nas03/scholar-ai/backend/internal/utils"
+	errMessage "github.com/nas03/scholar-ai/backend/pkg/errors"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type IAssessmentService interface {
+	// GetAssessments returns the components of a course with its running and projected grade
+	GetAssessments(ctx context.Context, userID string, courseID int) (*models.CourseAssessment, int)
+
+	// ReplaceAssessments swaps the components of a course, their weights must sum to 100 percent
+	ReplaceAssessments(ctx context.Context, userID string, courseID int, payload *models.AssessmentsRequest) (*models.CourseAssessment, int)
+
+	// UpdateAssessmentScore records or clears the obtained score of a component
+	UpdateAssessmentScore(ctx context.Context, userID string, id int, payload *models.AssessmentScoreRequest) (*models.CourseAssessment, int)
+}
+
+type AssessmentService struct {
+	assessmentRepo repo.IAssessmentRepository
+	courseRepo     repo.ICourseRepository
+	gradingService IGradingService
+}
+
+func NewAssessmentService(
+	assessmentRepository repo.IAssessmentRepository,
+	courseRepository repo.ICourseRepository,
+	gradingService IGradingService,
+) IAssessmentService {
+	return &AssessmentService{
+		assessmentRepo: assessmentRepository,
+		courseRepo:     courseRepository,
+		gradingService: gradingService,
+	}
+}
+
+func (s *AssessmentService) GetAssessments(ctx context.Context, userID string, courseID int) (*models.CourseAssessment, int) {
+	course, code := s.getCourse(ctx, userID, courseID)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+
+	components, err := s.assessmentRepo.GetAssessments(ctx, userID, []int{courseID})
+	if err != nil {
+		global.Log.Error("Error getting assessment components", zap.Error(err), zap.String("userID", userID), zap.Int("courseID", courseID))
+		return nil, response.CodeFailedGetAssessment
+	}
+
+	scale, code := s.gradingService.GetCourseGradingScale(ctx, userID, course)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+	return assessCourse(courseID, components, scale), response.CodeSuccess
+}
+
+func (s *AssessmentService) ReplaceAssessments(ctx context.Context, userID string, courseID int, payload *models.AssessmentsRequest) (*models.CourseAssessment, int) {
+	if _, code := s.getCourse(ctx, userID, courseID); code != response.CodeSuccess {
+		return nil, code
+	}
+
+	// Components sent with an id must be existing ones of the course, each listed once
+	current, err := s.assessmentRepo.GetAssessments(ctx, userID, []int{courseID})
+	if err != nil {
+		global.Log.Error("Error getting assessment components", zap.Error(err), zap.String("userID", userID), zap.Int("courseID", courseID))
+		return nil, response.CodeFailedGetAssessment
+	}
+	unclaimed := make(map[int]bool, len(current))
+	for _, component := range current {
+		unclaimed[component.ID] = true
+	}
+
+	components := make([]*models.AssessmentComponent, 0, len(payload.Components))
+	var totalWeight float64
+	for _, item := range payload.Components {
+		name := strings.TrimSpace(item.Name)
+		if name == "" {
+			return nil, response.CodeInvalidInput
+		}
+		var id int
+		if item.ID != nil {
+			if !unclaimed[*item.ID] {
+				global.Log.Warn(errMessage.ErrAssessmentNotFound.Error(), zap.String("userID", userID), zap.Int("courseID", courseID), zap.Int("assessmentID", *item.ID))
+				return nil, response.CodeAssessmentNotFound
+			}
+			unclaimed[*item.ID] = false
+			id = *item.ID
+		}
+		if item.Score != nil && *item.Score > item.MaxScore {
+			global.Log.Warn(errMessage.ErrInvalidAssessmentScore.Error(), zap.String("userID", userID), zap.Int("courseID", courseID))
+			return nil, response.CodeInvalidAssessmentScore
+		}
+
+		var dueDate *time.Time
+		if item.DueDate != nil {
+			parsed, err := utils.ParseDate(*item.DueDate)
+			if err != nil {
+				return nil, response.CodeInvalidInput
+			}
+			dueDate = &parsed
+		}
+
+		totalWeight += item.Weight
+		components = append(components, &models.AssessmentComponent{
+			ID:       id,
+			CourseID: courseID,
+			Name:     name,
+			Weight:   item.Weight,
+			MaxScore: item.MaxScore,
+			Score:    item.Score,
+			DueDate:  dueDate,
+		})
+	}
+	if len(components) > 0 && math.Abs(totalWeight-100) > consts.ASSESSMENT_WEIGHT_TOLERANCE {
+		global.Log.Warn(errMessage.ErrInvalidAssessmentWeights.Error(), zap.String("userID", userID), zap.Int("courseID", courseID), zap.Float64("totalWeight", totalWeight))
+		return nil, response.CodeInvalidAssessmentWeights
+	}
+
+	if err := s.assessmentRepo.ReplaceAssessments(ctx, courseID, components); err != nil {
+		// The course can be deleted between the check and the write
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			global.Log.Warn(errMessage.ErrCourseNotFound.Error(), zap.String("userID", userID), zap.Int("courseID", courseID))
+			return nil, response.CodeCourseNotFound
+		}
+
+		global.Log.Error("Error replacing assessment components", zap.Error(err), zap.String("userID", userID), zap.Int("courseID", courseID))
+		return nil, response.CodeFailedUpdateAssessment
+	}
+
+	global.Log.Info("Assessment components replaced", zap.String("userID", userID), zap.Int("courseID", courseID), zap.Int("count", len(components)))
+	return s.GetAssessments(ctx, userID, courseID)
+}
+
+func (s *AssessmentService) UpdateAssessmentScore(ctx context.Context, userID string, id int, payload *models.AssessmentScoreRequest) (*models.CourseAssessment, int) {
+	component, err := s.assessmentRepo.GetAssessmentByID(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Log.Warn(errMessage.ErrAssessmentNotFound.Error(), zap.String("userID", userID), zap.Int("assessmentID", id))
+			return nil, response.CodeAssessmentNotFound
+		}
+
+		global.Log.Error("Error getting assessment component by ID", zap.Error(err), zap.String("userID", userID), zap.Int("assessmentID", id))
+		return nil, response.CodeFailedGetAssessment
+	}
+	if payload.Score != nil && *payload.Score > component.MaxScore {
+		global.Log.Warn(errMessage.ErrInvalidAssessmentScore.Error(), zap.String("userID", userID), zap.Int("assessmentID", id))
+		return nil, response.CodeInvalidAssessmentScore
+	}
+
+	rowsAffected, err := s.assessmentRepo.UpdateAssessment(ctx, userID, id, map[string]interface{}{"score": payload.Score})
+	if err != nil {
+		global.Log.Error("Error updating assessment component", zap.Error(err), zap.String("userID", userID), zap.Int("assessmentID", id))
+		return nil, response.CodeFailedUpdateAssessment
+	}
+	if rowsAffected == 0 {
+		global.Log.Warn(errMessage.ErrAssessmentNotFound.Error(), zap.String("userID", userID), zap.Int("assessmentID", id))
+		return nil, response.CodeAssessmentNotFound
+	}
+
+	global.Log.Info("Assessment score updated", zap.String("userID", userID), zap.Int("assessmentID", id))
+	return s.GetAssessments(ctx, userID, component.CourseID)
+}
+
+func (s *AssessmentService) getCourse(ctx context.Context, userID string, courseID int) (*models.Course, int) {
+	course, err := s.courseRepo.GetCourseByID(ctx, userID, courseID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Log.Warn(errMessage.ErrCourseNotFound.Error(), zap.String("userID", userID), zap.Int("courseID", courseID))
+			return nil, response.CodeCourseNotFound
+		}
+
+		global.Log.Error("Error getting course by ID", zap.Error(err), zap.String("userID", userID), zap.Int("courseID", courseID))
+		return nil, response.CodeFailedGetCourse
+	}
+	return course, response.CodeSuccess
+}
+
+// assessCourse sums up the graded components of a course. The projection assumes
+// the components left go as well as the graded ones.
+func assessCourse(courseID int, components []*models.AssessmentComponent, scale *models.GradingScale) *models.CourseAssessment {
+	if components == nil {
+		components = []*models.AssessmentComponent{}
+	}
+	assessment := &models.CourseAssessment{CourseID: courseID, Components: components}
+
+	var graded, secured float64
+	for _, component := range components {
+		if component.Score == nil {
+			continue
+		}
+		graded += component.Weight
+		secured += component.Weight * *component.Score / component.MaxScore
+	}
+	assessment.GradedWeight = roundPoints(graded)
+	assessment.SecuredWeight = roundPoints(secured)
+	if graded == 0 {
+		return assessment
+	}
+
+	running := roundPoints(secured / graded * 100)
+	assessment.RunningGrade = &running
+	if grade, value, ok := projectGrade(scale, running); ok {
+		points := roundPoints(value.points)
+		assessment.ProjectedGrade = &grade
+		assessment.ProjectedPoints = &points
+	}
+	return assessment
+}
+
+// projectGrade puts a percent on a scale, the grade is the band letter or the score on a linear scale
+func projectGrade(scale *models.GradingScale, percent float64) (string, gradeValue, bool) {
+	grade := strconv.FormatFloat(roundPoints(percent/100*scale.MaxScore), 'f', -1, 64)
+	value, ok := evaluateGrade(scale, grade)
+	if ok && value.letter != "" {
+		grade = value.letter
+	}
+	return grade, value, ok
+}
@@ -0,0 +1,596 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/consts"
+	"github.com/nas03/scholar-ai/backend/internal/helper"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	repo "github.com/nas03/scholar-ai/backend/internal/repositories"
+	"github.com/nas03/scholar-ai/backend/internal/utils"
+	errMessage "github.com/nas03/scholar-ai/backend/pkg/errors"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// dummyPasswordHash is compared against when the user does not exist,
+// so unknown accounts take as long to reject as wrong passwords
+const dummyPasswordHash = "$2a$10$Y66A2h9uqSFbtJCkvu8BSOQSifnfWBKVvcCtm.oLdcl/q23EA5RPS"
+
+// refreshTokenBytes is the entropy of the secret part of a refresh token
+const refreshTokenBytes = 32
+
+// resetTokenBytes is the entropy of a password reset token
+const resetTokenBytes = 32
+
+type IAuthService interface {
+	// Login completes with tokens, or returns a challenge when the code is response.CodeTwoFactorRequired
+	Login(ctx context.Context, identifier, password, userAgent, ipAddress string) (*models.LoginResult, int)
+	LoginWithUser(ctx context.Context, user *models.User, userAgent, ipAddress string) (*models.LoginResult, int)
+	VerifyTwoFactorLogin(ctx context.Context, challengeToken, code, recoveryCode, userAgent, ipAddress string) (*models.LoginResult, int)
+	RefreshToken(ctx context.Context, refreshToken string) (*models.AuthTokens, int)
+	Logout(ctx context.Context, refreshToken string) int
+	ForgotPassword(ctx context.Context, email string) int
+	ResetPassword(ctx context.Context, token, newPassword string) int
+	GetLoginAttempts(ctx context.Context, identifier, ipAddress string) ([]*models.LoginAttemptStatus, int)
+}
+
+type AuthService struct {
+	userRepo            repo.IUserRepository
+	sessionRepo         repo.ISessionRepository
+	loginAttemptService ILoginAttemptService
+	twoFactorService    ITwoFactorService
+}
+
+func NewAuthService(
+	userRepository repo.IUserRepository,
+	sessionRepository repo.ISessionRepository,
+	loginAttemptService ILoginAttemptService,
+	twoFactorService ITwoFactorService,
+) IAuthService {
+	return &AuthService{
+		userRepo:            userRepository,
+		sessionRepo:         sessionRepository,
+		loginAttemptService: loginAttemptService,
+		twoFactorService:    twoFactorService,
+	}
+}
+
+// Login checks the credentials of a verified, active user and opens a new session
+func (s *AuthService) Login(ctx context.Context, identifier, password, userAgent, ipAddress string) (*models.LoginResult, int) {
+	if identifier == "" || password == "" {
+		global.Log.Warn(errMessage.ErrInvalidCredentials.Error(), zap.String("identifier", identifier))
+		return nil, response.CodeInvalidCredentials
+	}
+
+	user, err := s.getUserByIdentifier(ctx, identifier)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Log.Error("Error getting user for login", zap.Error(err), zap.String("identifier", identifier))
+			return nil, response.CodeLoginInternalError
+		}
+		user = nil
+	}
+
+	// Locked accounts and clients are refused before the password is checked
+	ipLock, accountLock := s.loginAttemptService.CheckLocked(ctx, user, ipAddress)
+	if lockout := max(ipLock, accountLock); lockout > 0 {
+		global.Log.Warn(errMessage.ErrLoginLocked.Error(), zap.String("identifier", identifier), zap.String("ip", ipAddress))
+		return &models.LoginResult{RetryAfter: int64(lockout.Seconds())}, response.CodeLoginLocked
+	}
+
+	if user == nil {
+		_ = bcrypt.CompareHashAndPassword([]byte(dummyPasswordHash), []byte(password))
+		global.Log.Warn(errMessage.ErrInvalidCredentials.Error(), zap.String("identifier", identifier))
+		return s.loginFailed(ctx, nil, ipAddress)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		global.Log.Warn(errMessage.ErrInvalidCredentials.Error(), zap.String("userID", user.UserID))
+		return s.loginFailed(ctx, user, ipAddress)
+	}
+
+	// Only reveal account state once the password is proven. The failure counters are
+	// reset once the login completes, so a second factor is guessed under the same limit.
+	return s.LoginWithUser(ctx, user, userAgent, ipAddress)
+}
+
+// LoginWithUser opens a session for a user whose identity is already proven, e.g. by an sso provider.
+// The second factor is still required when enabled.
+func (s *AuthService) LoginWithUser(ctx context.Context, user *models.User, userAgent, ipAddress string) (*models.LoginResult, int) {
+	if user.IsEmailVerified != 1 {
+		global.Log.Warn(errMessage.ErrEmailNotVerified.Error(), zap.String("userID", user.UserID))
+		return nil, response.CodeEmailNotVerified
+	}
+	if user.AccountStatus != consts.UserAccountStatus.ACTIVE {
+		global.Log.Warn(errMessage.ErrAccountInactive.Error(), zap.String("userID", user.UserID))
+		return nil, response.CodeAccountInactive
+	}
+
+	if user.IsTotpEnabled == 1 {
+		return s.createTwoFactorChallenge(ctx, user.UserID)
+	}
+
+	tokens, code := s.createSession(ctx, user.UserID, userAgent, ipAddress)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+	s.loginAttemptService.Reset(ctx, user.UserID)
+
+	global.Log.Info("Success logging in", zap.String("userID", user.UserID))
+	return &models.LoginResult{Tokens: tokens}, response.CodeSuccess
+}
+
+// VerifyTwoFactorLogin completes a login challenge with a totp or recovery code
+func (s *AuthService) VerifyTwoFactorLogin(ctx context.Context, challengeToken, code, recoveryCode, userAgent, ipAddress string) (*models.LoginResult, int) {
+	if code == "" && recoveryCode == "" {
+		global.Log.Warn(errMessage.ErrInvalidTwoFactor.Error())
+		return nil, response.CodeInvalidTwoFactor
+	}
+
+	cache := utils.NewRedisCache()
+	challengeHash := utils.HashToken(challengeToken)
+	challengeKey := fmt.Sprintf(consts.REDIS_KEY_AUTH_2FA_CHALLENGE_PREFIX, challengeHash)
+	attemptsKey := fmt.Sprintf(consts.REDIS_KEY_AUTH_2FA_CHALLENGE_ATTEMPTS_PREFIX, challengeHash)
+
+	userID, err := cache.Get(ctx, challengeKey)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			global.Log.Warn(errMessage.ErrInvalidChallenge.Error())
+			return nil, response.CodeInvalidChallenge
+		}
+
+		global.Log.Error("Failed to get login challenge", zap.Error(err))
+		return nil, response.CodeLoginInternalError
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		global.Log.Error("Error getting user for login challenge", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeInvalidChallenge
+	}
+
+	// A lockout started while the challenge was open applies to it as well
+	ipLock, accountLock := s.loginAttemptService.CheckLocked(ctx, user, ipAddress)
+	if lockout := max(ipLock, accountLock); lockout > 0 {
+		global.Log.Warn(errMessage.ErrLoginLocked.Error(), zap.String("userID", user.UserID), zap.String("ip", ipAddress))
+		_ = cache.Del(ctx, challengeKey, attemptsKey)
+		return &models.LoginResult{RetryAfter: int64(lockout.Seconds())}, response.CodeLoginLocked
+	}
+
+	if resCode := s.twoFactorService.VerifyCode(ctx, user, code, recoveryCode); resCode != response.CodeSuccess {
+		// Wrong codes count as failed logins, and the challenge dies after too many of them
+		var lockout time.Duration
+		if resCode == response.CodeInvalidTwoFactor {
+			ipLock, accountLock := s.loginAttemptService.RecordFailure(ctx, user, ipAddress)
+			lockout = max(ipLock, accountLock)
+		}
+		attempts, err := cache.Incr(ctx, attemptsKey)
+		if err == nil && attempts == 1 {
+			_ = cache.Expire(ctx, attemptsKey, consts.REDIS_2FA_CHALLENGE_EXPIRATION)
+		}
+		if lockout > 0 || (err == nil && attempts >= int64(consts.OTP_MAX_ATTEMPTS)) {
+			_ = cache.Del(ctx, challengeKey, attemptsKey)
+		}
+		if lockout > 0 {
+			return &models.LoginResult{RetryAfter: int64(lockout.Seconds())}, response.CodeLoginLocked
+		}
+		return nil, resCode
+	}
+	_ = cache.Del(ctx, challengeKey, attemptsKey)
+
+	tokens, resCode := s.createSession(ctx, user.UserID, userAgent, ipAddress)
+	if resCode != response.CodeSuccess {
+		return nil, resCode
+	}
+	s.loginAttemptService.Reset(ctx, user.UserID)
+
+	global.Log.Info("Success logging in with two-factor", zap.String("userID", user.UserID))
+	return &models.LoginResult{Tokens: tokens}, response.CodeSuccess
+}
+
+// RefreshToken rotates a refresh token. Presenting an already rotated token revokes the session.
+func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (*models.AuthTokens, int) {
+	session, code := s.getSessionByRefreshToken(ctx, refreshToken)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+
+	currentHash := utils.HashToken(refreshToken)
+	if subtle.ConstantTimeCompare([]byte(session.RefreshTokenHash), []byte(currentHash)) != 1 {
+		return nil, s.revokeReusedSession(ctx, session)
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, session.UserID)
+	if err != nil {
+		global.Log.Error("Error getting user for refresh", zap.Error(err), zap.String("userID", session.UserID))
+		return nil, response.CodeInvalidRefreshToken
+	}
+	if user.AccountStatus != consts.UserAccountStatus.ACTIVE {
+		s.revokeSession(ctx, session.SessionID)
+		global.Log.Warn(errMessage.ErrAccountInactive.Error(), zap.String("userID", user.UserID))
+		return nil, response.CodeAccountInactive
+	}
+
+	newRefreshToken, err := newRefreshToken(session.SessionID)
+	if err != nil {
+		global.Log.Error("Error generating refresh token", zap.Error(err))
+		return nil, response.CodeLoginInternalError
+	}
+
+	rotated, err := s.sessionRepo.RotateRefreshToken(ctx, session.SessionID, currentHash, utils.HashToken(newRefreshToken), time.Now().Add(refreshTokenTTL()))
+	if err != nil {
+		global.Log.Error("Error rotating refresh token", zap.Error(err), zap.String("sessionID", session.SessionID))
+		return nil, response.CodeLoginInternalError
+	}
+	if !rotated {
+		// A concurrent request already used this token
+		return nil, s.revokeReusedSession(ctx, session)
+	}
+
+	accessToken, err := utils.GenerateAccessToken(session.UserID, session.SessionID, accessTokenTTL())
+	if err != nil {
+		global.Log.Error("Error generating access token", zap.Error(err))
+		return nil, response.CodeLoginInternalError
+	}
+
+	global.Log.Info("Success refreshing token", zap.String("userID", session.UserID), zap.String("sessionID", session.SessionID))
+	return newAuthTokens(accessToken, newRefreshToken), response.CodeSuccess
+}
+
+// Logout revokes the session the refresh token belongs to
+func (s *AuthService) Logout(ctx context.Context, refreshToken string) int {
+	session, code := s.getSessionByRefreshToken(ctx, refreshToken)
+	if code != response.CodeSuccess {
+		return code
+	}
+
+	currentHash := utils.HashToken(refreshToken)
+	if subtle.ConstantTimeCompare([]byte(session.RefreshTokenHash), []byte(currentHash)) != 1 {
+		return s.revokeReusedSession(ctx, session)
+	}
+
+	if code := s.revokeSession(ctx, session.SessionID); code != response.CodeSuccess {
+		return code
+	}
+
+	global.Log.Info("Success logging out", zap.String("userID", session.UserID), zap.String("sessionID", session.SessionID))
+	return response.CodeSuccess
+}
+
+// ForgotPassword mails a single-use reset link. It always succeeds so callers cannot probe
+// which emails are registered.
+func (s *AuthService) ForgotPassword(ctx context.Context, email string) int {
+	if email == "" {
+		global.Log.Warn(errMessage.ErrInvalidEmail.Error(), zap.String("email", email))
+		return response.CodeInvalidEmail
+	}
+
+	user, err := s.userRepo.GetUserByEmail(ctx, email)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Log.Error("Error getting user by email", zap.Error(err), zap.String("email", email))
+		}
+		return response.CodeSuccess
+	}
+
+	token, err := utils.GenerateOpaqueToken(resetTokenBytes)
+	if err != nil {
+		global.Log.Error("Error generating password reset token", zap.Error(err))
+		return response.CodeSuccess
+	}
+
+	cache := utils.NewRedisCache()
+	tokenHash := utils.HashToken(token)
+
+	// Only the latest link stays valid
+	userKey := fmt.Sprintf(consts.REDIS_KEY_URS_PASSWORD_RESET_PREFIX, user.UserID)
+	if previousHash, err := cache.Get(ctx, userKey); err == nil {
+		_ = cache.Del(ctx, fmt.Sprintf(consts.REDIS_KEY_AUTH_PASSWORD_RESET_PREFIX, previousHash))
+	}
+
+	tokenKey := fmt.Sprintf(consts.REDIS_KEY_AUTH_PASSWORD_RESET_PREFIX, tokenHash)
+	if err := cache.SetEx(ctx, tokenKey, user.UserID, consts.REDIS_PASSWORD_RESET_EXPIRATION); err != nil {
+		global.Log.Error("Failed to store password reset token", zap.Error(err), zap.String("userID", user.UserID))
+		return response.CodeSuccess
+	}
+	if err := cache.SetEx(ctx, userKey, tokenHash, consts.REDIS_PASSWORD_RESET_EXPIRATION); err != nil {
+		global.Log.Error("Failed to store password reset owner", zap.Error(err), zap.String("userID", user.UserID))
+	}
+
+	// Send in the background so response time does not depend on whether the email exists
+	go s.sendPasswordResetMail(user.Email, token)
+
+	global.Log.Info("Password reset requested", zap.String("userID", user.UserID))
+	return response.CodeSuccess
+}
+
+// ResetPassword consumes a reset token, stores the new password and signs out every session
+func (s *AuthService) ResetPassword(ctx context.Context, token, newPassword string) int {
+	if token == "" {
+		global.Log.Warn(errMessage.ErrInvalidResetToken.Error())
+		return response.CodeInvalidResetToken
+	}
+
+	// Check the policy first so a weak password does not burn the token
+	if err := utils.ValidatePassword(newPassword); err != nil {
+		global.Log.Warn(err.Error())
+		return passwordPolicyCode(err)
+	}
+
+	cache := utils.NewRedisCache()
+	userID, err := cache.GetDel(ctx, fmt.Sprintf(consts.REDIS_KEY_AUTH_PASSWORD_RESET_PREFIX, utils.HashToken(token)))
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			global.Log.Warn(errMessage.ErrInvalidResetToken.Error())
+			return response.CodeInvalidResetToken
+		}
+
+		global.Log.Error("Failed to get password reset token", zap.Error(err))
+		return response.CodeFailedUpdateUser
+	}
+	_ = cache.Del(ctx, fmt.Sprintf(consts.REDIS_KEY_URS_PASSWORD_RESET_PREFIX, userID))
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		global.Log.Error("Error generating hashedPassword", zap.Error(err))
+		return response.CodeFailedUpdateUser
+	}
+	if err := s.userRepo.UpdateUserPassword(ctx, userID, string(hashedPassword)); err != nil {
+		global.Log.Error("Error updating user password", zap.Error(err), zap.String("userID", userID))
+		return response.CodeFailedUpdateUser
+	}
+
+	if code := s.revokeAllSessions(ctx, userID); code != response.CodeSuccess {
+		return code
+	}
+
+	global.Log.Info("Success resetting password", zap.String("userID", userID))
+	return response.CodeSuccess
+}
+
+// GetLoginAttempts returns the limiter state of an account and/or a client ip
+func (s *AuthService) GetLoginAttempts(ctx context.Context, identifier, ipAddress string) ([]*models.LoginAttemptStatus, int) {
+	if identifier == "" && ipAddress == "" {
+		global.Log.Warn(errMessage.ErrInvalidInput.Error())
+		return nil, response.CodeInvalidInput
+	}
+
+	statuses := make([]*models.LoginAttemptStatus, 0, 2)
+	if identifier != "" {
+		user, err := s.getUserByIdentifier(ctx, identifier)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				global.Log.Warn(errMessage.ErrUserNotFound.Error(), zap.String("identifier", identifier))
+				return nil, response.CodeUserNotFound
+			}
+
+			global.Log.Error("Error getting user by identifier", zap.Error(err), zap.String("identifier", identifier))
+			return nil, response.CodeFailedGetUser
+		}
+
+		status, err := s.loginAttemptService.Inspect(ctx, consts.LoginAttemptScope.ACCOUNT, user.UserID)
+		if err != nil {
+			global.Log.Error("Error inspecting login attempts", zap.Error(err), zap.String("userID", user.UserID))
+			return nil, response.CodeLoginInternalError
+		}
+		statuses = append(statuses, status)
+	}
+	if ipAddress != "" {
+		status, err := s.loginAttemptService.Inspect(ctx, consts.LoginAttemptScope.IP, ipAddress)
+		if err != nil {
+			global.Log.Error("Error inspecting login attempts", zap.Error(err), zap.String("ip", ipAddress))
+			return nil, response.CodeLoginInternalError
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, response.CodeSuccess
+}
+
+func (s *AuthService) sendPasswordResetMail(email, token string) {
+	link := fmt.Sprintf("%s/reset-password?token=%s", strings.TrimRight(global.Config.Frontend.BaseURL, "/"), url.QueryEscape(token))
+
+	_, err := helper.NewMailHelper().SendMail(
+		context.Background(),
+		email,
+		"ScholarAI Password Reset",
+		fmt.Sprintf("<p>Click the link below to reset your password. It expires in %d minutes.</p><p><a href=\"%s\">%s</a></p>",
+			int(consts.REDIS_PASSWORD_RESET_EXPIRATION.Minutes()), link, link),
+	)
+	if err != nil {
+		global.Log.Error("Failed to send password reset email", zap.String("email", email), zap.Error(err))
+	}
+}
+
+// loginFailed records a failed attempt and reports the lockout it may have triggered
+func (s *AuthService) loginFailed(ctx context.Context, user *models.User, ipAddress string) (*models.LoginResult, int) {
+	ipLock, accountLock := s.loginAttemptService.RecordFailure(ctx, user, ipAddress)
+	if lockout := max(ipLock, accountLock); lockout > 0 {
+		return &models.LoginResult{RetryAfter: int64(lockout.Seconds())}, response.CodeLoginLocked
+	}
+	return nil, response.CodeInvalidCredentials
+}
+
+// createTwoFactorChallenge stores a short-lived token that must be completed with a second factor
+func (s *AuthService) createTwoFactorChallenge(ctx context.Context, userID string) (*models.LoginResult, int) {
+	challengeToken, err := utils.GenerateOpaqueToken(refreshTokenBytes)
+	if err != nil {
+		global.Log.Error("Error generating login challenge", zap.Error(err))
+		return nil, response.CodeLoginInternalError
+	}
+
+	key := fmt.Sprintf(consts.REDIS_KEY_AUTH_2FA_CHALLENGE_PREFIX, utils.HashToken(challengeToken))
+	if err := utils.NewRedisCache().SetEx(ctx, key, userID, consts.REDIS_2FA_CHALLENGE_EXPIRATION); err != nil {
+		global.Log.Error("Failed to store login challenge", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeLoginInternalError
+	}
+
+	global.Log.Info("Two-factor challenge issued", zap.String("userID", userID))
+	return &models.LoginResult{ChallengeToken: challengeToken}, response.CodeTwoFactorRequired
+}
+
+// getUserByIdentifier looks a user up by email or username
+func (s *AuthService) getUserByIdentifier(ctx context.Context, identifier string) (*models.User, error) {
+	if strings.Contains(identifier, "@") {
+		return s.userRepo.GetUserByEmail(ctx, identifier)
+	}
+	return s.userRepo.GetUserByUsername(ctx, identifier)
+}
+
+// createSession stores a new token family and issues its first token pair
+func (s *AuthService) createSession(ctx context.Context, userID, userAgent, ipAddress string) (*models.AuthTokens, int) {
+	sessionUUID, err := uuid.NewRandom()
+	if err != nil {
+		global.Log.Error("Error creating UUID", zap.Error(err))
+		return nil, response.CodeLoginInternalError
+	}
+	sessionID := sessionUUID.String()
+
+	refreshToken, err := newRefreshToken(sessionID)
+	if err != nil {
+		global.Log.Error("Error generating refresh token", zap.Error(err))
+		return nil, response.CodeLoginInternalError
+	}
+
+	accessToken, err := utils.GenerateAccessToken(userID, sessionID, accessTokenTTL())
+	if err != nil {
+		global.Log.Error("Error generating access token", zap.Error(err))
+		return nil, response.CodeLoginInternalError
+	}
+
+	if len(userAgent) > 255 {
+		userAgent = userAgent[:255]
+	}
+	session := &models.Session{
+		SessionID:        sessionID,
+		UserID:           userID,
+		RefreshTokenHash: utils.HashToken(refreshToken),
+		UserAgent:        userAgent,
+		IPAddress:        ipAddress,
+		ExpiresAt:        time.Now().Add(refreshTokenTTL()),
+	}
+	if err := s.sessionRepo.CreateSession(ctx, session); err != nil {
+		global.Log.Error("Error creating session", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeLoginInternalError
+	}
+
+	return newAuthTokens(accessToken, refreshToken), response.CodeSuccess
+}
+
+// getSessionByRefreshToken resolves the session encoded in a refresh token and checks it is usable
+func (s *AuthService) getSessionByRefreshToken(ctx context.Context, refreshToken string) (*models.Session, int) {
+	sessionID, _, ok := strings.Cut(refreshToken, ".")
+	if !ok || sessionID == "" {
+		global.Log.Warn(errMessage.ErrInvalidRefreshToken.Error())
+		return nil, response.CodeInvalidRefreshToken
+	}
+
+	session, err := s.sessionRepo.GetSessionByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Log.Warn(errMessage.ErrInvalidRefreshToken.Error(), zap.String("sessionID", sessionID))
+			return nil, response.CodeInvalidRefreshToken
+		}
+
+		global.Log.Error("Error getting session", zap.Error(err), zap.String("sessionID", sessionID))
+		return nil, response.CodeLoginInternalError
+	}
+	if session.RevokedAt.Valid {
+		global.Log.Warn(errMessage.ErrInvalidRefreshToken.Error(), zap.String("sessionID", sessionID), zap.String("reason", "revoked"))
+		return nil, response.CodeInvalidRefreshToken
+	}
+	if time.Now().After(session.ExpiresAt) {
+		global.Log.Warn(errMessage.ErrRefreshTokenExpired.Error(), zap.String("sessionID", sessionID))
+		return nil, response.CodeInvalidRefreshToken
+	}
+
+	return session, response.CodeSuccess
+}
+
+// revokeReusedSession kills a token family after one of its old tokens was replayed
+func (s *AuthService) revokeReusedSession(ctx context.Context, session *models.Session) int {
+	global.Log.Warn(errMessage.ErrRefreshTokenReused.Error(), zap.String("userID", session.UserID), zap.String("sessionID", session.SessionID))
+	if code := s.revokeSession(ctx, session.SessionID); code != response.CodeSuccess {
+		return code
+	}
+	return response.CodeRefreshTokenReused
+}
+
+// revokeSession revokes a session and blacklists it until its access tokens expire
+func (s *AuthService) revokeSession(ctx context.Context, sessionID string) int {
+	if err := s.sessionRepo.RevokeSession(ctx, sessionID); err != nil {
+		global.Log.Error("Error revoking session", zap.Error(err), zap.String("sessionID", sessionID))
+		return response.CodeLoginInternalError
+	}
+
+	key := fmt.Sprintf(consts.REDIS_KEY_AUTH_REVOKED_SESSION_PREFIX, sessionID)
+	if err := utils.NewRedisCache().SetEx(ctx, key, 1, accessTokenTTL()); err != nil {
+		global.Log.Error("Failed to blacklist revoked session", zap.Error(err), zap.String("sessionID", sessionID))
+	}
+
+	return response.CodeSuccess
+}
+
+// revokeAllSessions signs a user out everywhere
+func (s *AuthService) revokeAllSessions(ctx context.Context, userID string) int {
+	sessionIDs, err := s.sessionRepo.RevokeUserSessions(ctx, userID)
+	if err != nil {
+		global.Log.Error("Error revoking user sessions", zap.Error(err), zap.String("userID", userID))
+		return response.CodeFailedUpdateUser
+	}
+
+	cache := utils.NewRedisCache()
+	for _, sessionID := range sessionIDs {
+		key := fmt.Sprintf(consts.REDIS_KEY_AUTH_REVOKED_SESSION_PREFIX, sessionID)
+		if err := cache.SetEx(ctx, key, 1, accessTokenTTL()); err != nil {
+			global.Log.Error("Failed to blacklist revoked session", zap.Error(err), zap.String("sessionID", sessionID))
+		}
+	}
+
+	global.Log.Info("Revoked all user sessions", zap.String("userID", userID), zap.Int("sessions", len(sessionIDs)))
+	return response.CodeSuccess
+}
+
+// newRefreshToken builds an opaque "<sessionID>.<secret>" refresh token
+func newRefreshToken(sessionID string) (string, error) {
+	secret, err := utils.GenerateOpaqueToken(refreshTokenBytes)
+	if err != nil {
+		return "", err
+	}
+	return sessionID + "." + secret, nil
+}
+
+func newAuthTokens(accessToken, refreshToken string) *models.AuthTokens {
+	return &models.AuthTokens{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(accessTokenTTL().Seconds()),
+	}
+}
+
+func accessTokenTTL() time.Duration {
+	if global.Config.Jwt.AccessTokenTTL > 0 {
+		return time.Duration(global.Config.Jwt.AccessTokenTTL) * time.Second
+	}
+	return consts.DEFAULT_ACCESS_TOKEN_TTL
+}
+
+func refreshTokenTTL() time.Duration {
+	if global.Config.Jwt.RefreshTokenTTL > 0 {
+		return time.Duration(global.Config.Jwt.RefreshTokenTTL) * time.Second
+	}
+	return consts.DEFAULT_REFRESH_TOKEN_TTL
+}
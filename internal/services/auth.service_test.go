@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	repo "github.com/nas03/scholar-ai/backend/internal/repositories"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type fakeUserRepository struct {
+	repo.IUserRepository
+	user *models.User
+}
+
+func (r *fakeUserRepository) GetUserByUsername(context.Context, string) (*models.User, error) {
+	return r.user, nil
+}
+
+// fakeLoginAttemptService reports fixed lockouts, checked before the password and triggered by a failure
+type fakeLoginAttemptService struct {
+	ILoginAttemptService
+	ipLock, accountLock               time.Duration
+	failureIPLock, failureAccountLock time.Duration
+}
+
+func (s *fakeLoginAttemptService) CheckLocked(context.Context, *models.User, string) (time.Duration, time.Duration) {
+	return s.ipLock, s.accountLock
+}
+
+func (s *fakeLoginAttemptService) RecordFailure(context.Context, *models.User, string) (time.Duration, time.Duration) {
+	return s.failureIPLock, s.failureAccountLock
+}
+
+func TestLoginReportsLockouts(t *testing.T) {
+	global.Log = zap.NewNop()
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("GenerateFromPassword() error = %v", err)
+	}
+	user := &models.User{UserID: "user-1", Password: string(hash)}
+
+	tests := []struct {
+		name           string
+		attempts       *fakeLoginAttemptService
+		wantCode       int
+		wantRetryAfter int64
+	}{
+		{name: "ip locked", attempts: &fakeLoginAttemptService{ipLock: 90 * time.Second}, wantCode: response.CodeLoginLocked, wantRetryAfter: 90},
+		{name: "account locked", attempts: &fakeLoginAttemptService{accountLock: 2 * time.Minute}, wantCode: response.CodeLoginLocked, wantRetryAfter: 120},
+		{name: "both locked", attempts: &fakeLoginAttemptService{ipLock: time.Minute, accountLock: 4 * time.Minute}, wantCode: response.CodeLoginLocked, wantRetryAfter: 240},
+		{name: "failure locks the ip", attempts: &fakeLoginAttemptService{failureIPLock: time.Minute}, wantCode: response.CodeLoginLocked, wantRetryAfter: 60},
+		{name: "failure locks the account", attempts: &fakeLoginAttemptService{failureAccountLock: time.Minute}, wantCode: response.CodeLoginLocked, wantRetryAfter: 60},
+		{name: "failure below the limits", attempts: &fakeLoginAttemptService{}, wantCode: response.CodeInvalidCredentials},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &AuthService{userRepo: &fakeUserRepository{user: user}, loginAttemptService: tt.attempts}
+
+			result, code := s.Login(context.Background(), "student", "wrong password", "test", "203.0.113.7")
+			if code != tt.wantCode {
+				t.Fatalf("Login() code = %d, want %d", code, tt.wantCode)
+			}
+			if tt.wantRetryAfter == 0 {
+				return
+			}
+			if result == nil || result.RetryAfter != tt.wantRetryAfter {
+				t.Errorf("Login() result = %+v, want RetryAfter %d", result, tt.wantRetryAfter)
+			}
+		})
+	}
+}
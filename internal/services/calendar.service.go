@@ -0,0 +1,340 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/consts"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	repo "github.com/nas03/scholar-ai/backend/internal/repositories"
+	"github.com/nas03/scholar-ai/backend/internal/utils"
+	errMessage "github.com/nas03/scholar-ai/backend/pkg/errors"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	calendarProductID    = "-//Scholar AI//Calendar//EN"
+	calendarUIDDomain    = "scholar-ai"
+	calendarFeedPath     = "/api/v1/calendar/feed/"
+	calendarFeedTokenLen = 32
+)
+
+// classSessionTypeNames label class sessions in calendar events, by consts.ClassSessionType
+var classSessionTypeNames = map[int8]string{
+	consts.ClassSessionType.LECTURE:  "Lecture",
+	consts.ClassSessionType.LAB:      "Lab",
+	consts.ClassSessionType.TUTORIAL: "Tutorial",
+}
+
+type ICalendarService interface {
+	// ExportCalendar renders the user's class sessions as recurring events bounded by their semester
+	// and the user's reminders as to-dos, in iCalendar (RFC 5545) format
+	ExportCalendar(ctx context.Context, userID string) (string, int)
+
+	// GetSubscription describes the user's calendar feed, without its URL
+	GetSubscription(ctx context.Context, userID string) (*models.CalendarSubscription, int)
+
+	// RotateSubscription issues a new feed token and returns its URL, the previous URL stops working
+	RotateSubscription(ctx context.Context, userID string) (*models.CalendarSubscription, int)
+	RevokeSubscription(ctx context.Context, userID string) int
+
+	// GetFeed renders the calendar of the user the feed token belongs to
+	GetFeed(ctx context.Context, token string) (string, int)
+}
+
+type CalendarService struct {
+	userRepo         repo.IUserRepository
+	semesterRepo     repo.ISemesterRepository
+	classSessionRepo repo.IClassSessionRepository
+	reminderRepo     repo.IReminderRepository
+	calendarFeedRepo repo.ICalendarFeedRepository
+}
+
+func NewCalendarService(
+	userRepository repo.IUserRepository,
+	semesterRepository repo.ISemesterRepository,
+	classSessionRepository repo.IClassSessionRepository,
+	reminderRepository repo.IReminderRepository,
+	calendarFeedRepository repo.ICalendarFeedRepository,
+) ICalendarService {
+	return &CalendarService{
+		userRepo:         userRepository,
+		semesterRepo:     semesterRepository,
+		classSessionRepo: classSessionRepository,
+		reminderRepo:     reminderRepository,
+		calendarFeedRepo: calendarFeedRepository,
+	}
+}
+
+func (s *CalendarService) ExportCalendar(ctx context.Context, userID string) (string, int) {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Log.Warn(errMessage.ErrUserNotFound.Error(), zap.String("userID", userID))
+			return "", response.CodeUserNotFound
+		}
+
+		global.Log.Error("Error getting user by ID", zap.Error(err), zap.String("userID", userID))
+		return "", response.CodeFailedGetUser
+	}
+
+	return s.renderCalendar(ctx, user)
+}
+
+func (s *CalendarService) GetSubscription(ctx context.Context, userID string) (*models.CalendarSubscription, int) {
+	feed, err := s.calendarFeedRepo.GetCalendarFeedByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Log.Warn(errMessage.ErrCalendarFeedNotFound.Error(), zap.String("userID", userID))
+			return nil, response.CodeCalendarFeedNotFound
+		}
+
+		global.Log.Error("Error getting calendar feed", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedGetCalendar
+	}
+
+	return &models.CalendarSubscription{CreatedAt: feed.CreatedAt, RotatedAt: feed.UpdatedAt}, response.CodeSuccess
+}
+
+func (s *CalendarService) RotateSubscription(ctx context.Context, userID string) (*models.CalendarSubscription, int) {
+	token, err := utils.GenerateOpaqueToken(calendarFeedTokenLen)
+	if err != nil {
+		global.Log.Error("Error generating calendar feed token", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedUpdateCalendarFeed
+	}
+
+	feed := &models.CalendarFeed{UserID: userID, TokenHash: utils.HashToken(token)}
+	if err := s.calendarFeedRepo.UpsertCalendarFeed(ctx, feed); err != nil {
+		global.Log.Error("Error saving calendar feed", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedUpdateCalendarFeed
+	}
+
+	// Read back the feed, the upsert keeps the creation time of an existing one
+	subscription, code := s.GetSubscription(ctx, userID)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+	subscription.URL = strings.TrimRight(global.Config.Server.PublicURL, "/") + calendarFeedPath + token + ".ics"
+
+	global.Log.Info("Calendar feed token rotated", zap.String("userID", userID))
+	return subscription, response.CodeSuccess
+}
+
+func (s *CalendarService) RevokeSubscription(ctx context.Context, userID string) int {
+	rows, err := s.calendarFeedRepo.DeleteCalendarFeed(ctx, userID)
+	if err != nil {
+		global.Log.Error("Error deleting calendar feed", zap.Error(err), zap.String("userID", userID))
+		return response.CodeFailedUpdateCalendarFeed
+	}
+
+	if rows == 0 {
+		global.Log.Warn(errMessage.ErrCalendarFeedNotFound.Error(), zap.String("userID", userID))
+		return response.CodeCalendarFeedNotFound
+	}
+
+	global.Log.Info("Calendar feed revoked", zap.String("userID", userID))
+	return response.CodeSuccess
+}
+
+func (s *CalendarService) GetFeed(ctx context.Context, token string) (string, int) {
+	feed, err := s.calendarFeedRepo.GetCalendarFeedByTokenHash(ctx, utils.HashToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Log.Warn(errMessage.ErrCalendarFeedNotFound.Error())
+			return "", response.CodeCalendarFeedNotFound
+		}
+
+		global.Log.Error("Error getting calendar feed by token", zap.Error(err))
+		return "", response.CodeFailedGetCalendar
+	}
+
+	return s.ExportCalendar(ctx, feed.UserID)
+}
+
+// renderCalendar builds the iCalendar document of the user, times are written in the user's timezone
+func (s *CalendarService) renderCalendar(ctx context.Context, user *models.User) (string, int) {
+	semesters, err := s.semesterRepo.GetSemesters(ctx, user.UserID)
+	if err != nil {
+		global.Log.Error("Error getting semesters", zap.Error(err), zap.String("userID", user.UserID))
+		return "", response.CodeFailedGetCalendar
+	}
+
+	sessions, err := s.classSessionRepo.GetClassSessions(ctx, user.UserID, models.ClassSessionFilter{})
+	if err != nil {
+		global.Log.Error("Error getting class sessions", zap.Error(err), zap.String("userID", user.UserID))
+		return "", response.CodeFailedGetCalendar
+	}
+
+	now := time.Now()
+	reminders, err := s.reminderRepo.GetReminders(ctx, user.UserID, models.ReminderFilter{}, now)
+	if err != nil {
+		global.Log.Error("Error getting reminders", zap.Error(err), zap.String("userID", user.UserID))
+		return "", response.CodeFailedGetCalendar
+	}
+
+	location := locationOf(user)
+	semestersByID := make(map[int]*models.Semester, len(semesters))
+	for _, semester := range semesters {
+		semestersByID[semester.ID] = semester
+	}
+
+	var w utils.ICalWriter
+	w.Line("BEGIN", "VCALENDAR")
+	w.Line("VERSION", "2.0")
+	w.Line("PRODID", calendarProductID)
+	w.Line("CALSCALE", "GREGORIAN")
+	w.Line("METHOD", "PUBLISH")
+	w.Text("X-WR-CALNAME", "Scholar AI")
+	w.Line("X-WR-TIMEZONE", location.String())
+	w.Timezone(location, now.In(location).Year())
+
+	for _, session := range sessions {
+		if semester := semestersByID[session.Course.SemesterID]; semester != nil {
+			writeClassSessionEvent(&w, session, semester, location, now)
+		}
+	}
+	for _, reminder := range reminders {
+		writeReminderTodo(&w, reminder, location, now)
+	}
+
+	w.Line("END", "VCALENDAR")
+	return w.String(), response.CodeSuccess
+}
+
+// writeClassSessionEvent writes a session as a weekly VEVENT from its first meeting until the semester ends,
+// every other week for sessions held in odd or even weeks only
+func writeClassSessionEvent(w *utils.ICalWriter, session *models.ClassSession, semester *models.Semester, location *time.Location, stamp time.Time) {
+	first, ok := firstSessionDate(session, semester)
+	if !ok {
+		return
+	}
+
+	date := first.Format(utils.DateLayout)
+	startClock, err := utils.WallClock(session.StartTime)
+	if err != nil {
+		global.Log.Error("Error parsing stored session time", zap.Error(err), zap.Int("sessionID", session.ID))
+		return
+	}
+	endClock, err := utils.WallClock(session.EndTime)
+	if err != nil {
+		global.Log.Error("Error parsing stored session time", zap.Error(err), zap.Int("sessionID", session.ID))
+		return
+	}
+	start, err := utils.ParseDateTimeIn(date, startClock, location)
+	if err != nil {
+		return
+	}
+	end, err := utils.ParseDateTimeIn(date, endClock, location)
+	if err != nil {
+		return
+	}
+	until, ok := dateEnd(semester.EndDate, location)
+	if !ok {
+		return
+	}
+
+	rule := "FREQ=WEEKLY"
+	if session.WeekParity != consts.WeekParity.EVERY {
+		rule += ";INTERVAL=2"
+	}
+	rule += ";UNTIL=" + until.UTC().Format(utils.ICalUTCDateTimeLayout)
+
+	course := session.Course
+	w.Line("BEGIN", "VEVENT")
+	w.Line("UID", fmt.Sprintf("class-session-%d@%s", session.ID, calendarUIDDomain))
+	w.Time("DTSTAMP", stamp, nil)
+	w.Time("DTSTART", start, location)
+	w.Time("DTEND", end, location)
+	w.Line("RRULE", rule)
+	w.Text("SUMMARY", fmt.Sprintf("%s %s (%s)", course.CourseID, course.CourseName, classSessionTypeNames[session.Type]))
+	if session.Location != nil {
+		w.Text("LOCATION", *session.Location)
+	}
+	w.Text("CATEGORIES", classSessionTypeNames[session.Type])
+	w.Line("END", "VEVENT")
+}
+
+// writeReminderTodo writes a reminder as a VTODO due at its due time, a series as one starting at its
+// due time with a zero duration, its recurrence and exception dates. Detached occurrences are reminders of their own and skipped by their series.
+func writeReminderTodo(w *utils.ICalWriter, reminder *models.Reminder, location *time.Location, stamp time.Time) {
+	var rule *utils.RRule
+	if reminder.RRule != nil {
+		var err error
+		if rule, err = utils.ParseRRule(*reminder.RRule); err != nil {
+			global.Log.Error("Error parsing stored recurrence rule", zap.Error(err), zap.Int("reminderID", reminder.ID))
+			return
+		}
+
+		// UNTIL has to be a UTC instant when DTSTART carries a timezone
+		if rule.Until != nil && rule.UntilDate {
+			if until, ok := dateEnd(*rule.Until, location); ok {
+				rule.Until = &until
+				rule.UntilDate = false
+			}
+		}
+	}
+
+	w.Line("BEGIN", "VTODO")
+	w.Line("UID", fmt.Sprintf("reminder-%d@%s", reminder.ID, calendarUIDDomain))
+	w.Time("DTSTAMP", stamp, nil)
+
+	if rule != nil {
+		// Recurrence expands from DTSTART and DUE has to be later than it, so a zero
+		// DURATION makes each occurrence due at its start
+		w.Time("DTSTART", reminder.DueAt, location)
+		w.Line("DURATION", "PT0S")
+		w.Line("RRULE", rule.String())
+		for _, exDate := range reminder.ExDates {
+			w.Time("EXDATE", occurrenceAt(reminder, exDate.Date, location), location)
+		}
+	} else {
+		w.Time("DUE", reminder.DueAt, location)
+	}
+	w.Text("SUMMARY", reminder.Title)
+	if reminder.Description != nil {
+		w.Text("DESCRIPTION", *reminder.Description)
+	}
+
+	if reminder.Status == consts.ReminderStatus.COMPLETED {
+		w.Line("STATUS", "COMPLETED")
+		if reminder.CompletedAt != nil {
+			w.Time("COMPLETED", *reminder.CompletedAt, nil)
+		}
+	} else {
+		w.Line("STATUS", "NEEDS-ACTION")
+	}
+	w.Line("END", "VTODO")
+}
+
+// firstSessionDate returns the first date on or after the semester start a session is held,
+// false when the semester ends before it
+func firstSessionDate(session *models.ClassSession, semester *models.Semester) (time.Time, bool) {
+	week, step := 1, 7
+	switch session.WeekParity {
+	case consts.WeekParity.ODD:
+		step = 14
+	case consts.WeekParity.EVEN:
+		week, step = 2, 14
+	}
+
+	date := weekStart(semester.StartDate).AddDate(0, 0, 7*(week-1)+int(session.DayOfWeek)-1)
+	if date.Before(semester.StartDate) {
+		date = date.AddDate(0, 0, step)
+	}
+	return date, !date.After(semester.EndDate)
+}
+
+// dateEnd returns the last second of a calendar date in location
+func dateEnd(date time.Time, location *time.Location) (time.Time, bool) {
+	next, err := utils.ParseDateTimeIn(date.AddDate(0, 0, 1).Format(utils.DateLayout), "00:00", location)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return next.Add(-time.Second), true
+}
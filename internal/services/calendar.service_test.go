@@ -0,0 +1,68 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nas03/scholar-ai/backend/internal/consts"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"github.com/nas03/scholar-ai/backend/internal/utils"
+)
+
+func exportedTodo(t *testing.T, reminder *models.Reminder) *utils.ICalComponent {
+	t.Helper()
+	var w utils.ICalWriter
+	writeReminderTodo(&w, reminder, time.UTC, time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC))
+	calendar, err := utils.ParseICal("BEGIN:VCALENDAR\r\n" + w.String() + "END:VCALENDAR\r\n")
+	if err != nil {
+		t.Fatalf("ParseICal() error = %v", err)
+	}
+	return calendar.Components[0]
+}
+
+func TestWriteReminderTodo(t *testing.T) {
+	dueAt := time.Date(2026, 10, 19, 9, 30, 0, 0, time.UTC)
+	rrule := "FREQ=WEEKLY;COUNT=3"
+
+	todo := exportedTodo(t, &models.Reminder{ID: 1, Title: "Essay", DueAt: dueAt, Status: consts.ReminderStatus.PENDING})
+	if todo.Property("DTSTART") != nil || todo.Property("DURATION") != nil {
+		t.Errorf("one-off reminder has DTSTART or DURATION")
+	}
+	if due := todo.Property("DUE"); due == nil || due.Value != "20261019T093000Z" {
+		t.Errorf("DUE = %+v, want 20261019T093000Z", due)
+	}
+
+	todo = exportedTodo(t, &models.Reminder{ID: 2, Title: "Quiz", DueAt: dueAt, RRule: &rrule, Status: consts.ReminderStatus.PENDING})
+	if todo.Property("DUE") != nil {
+		t.Errorf("series has a DUE alongside DTSTART")
+	}
+	if start := todo.Property("DTSTART"); start == nil || start.Value != "20261019T093000Z" {
+		t.Errorf("DTSTART = %+v, want 20261019T093000Z", start)
+	}
+	if got := todo.Text("DURATION"); got != "PT0S" {
+		t.Errorf("DURATION = %q, want PT0S", got)
+	}
+	if got := todo.Text("RRULE"); got != rrule {
+		t.Errorf("RRULE = %q, want %q", got, rrule)
+	}
+}
+
+func TestWriteClassSessionEventReadsWallClocks(t *testing.T) {
+	semester := &models.Semester{StartDate: time.Date(2026, 9, 7, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, 12, 20, 0, 0, 0, 0, time.UTC)}
+
+	for _, clocks := range [][2]string{{"09:00:00", "10:30:00"}, {"09:00", "10:30"}} {
+		t.Run(clocks[0], func(t *testing.T) {
+			session := &models.ClassSession{ID: 1, DayOfWeek: 1, StartTime: clocks[0], EndTime: clocks[1], WeekParity: consts.WeekParity.EVERY, Course: &models.Course{CourseID: "CS101"}}
+			var w utils.ICalWriter
+			writeClassSessionEvent(&w, session, semester, time.UTC, time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC))
+
+			out := w.String()
+			for _, want := range []string{"DTSTART:20260907T090000Z\r\n", "DTEND:20260907T103000Z\r\n"} {
+				if !strings.Contains(out, want) {
+					t.Errorf("event lacks %q:\n%s", strings.TrimSpace(want), out)
+				}
+			}
+		})
+	}
+}
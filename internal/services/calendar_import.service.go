@@ -0,0 +1,557 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/consts"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	repo "github.com/nas03/scholar-ai/backend/internal/repositories"
+	"github.com/nas03/scholar-ai/backend/internal/utils"
+	errMessage "github.com/nas03/scholar-ai/backend/pkg/errors"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// courseCodePattern finds a course code such as "CS101", "MATH 2010" or "IT-3040E" in an event summary,
+// an upper case prefix keeps out words such as "Lab 02"
+var courseCodePattern = regexp.MustCompile(`\b([A-Z]{2,5})[ -]?(\d{2,4}[A-Z]?)\b`)
+
+// notCoursePrefixes are upper case words followed by a number that name no course, e.g. "ROOM 101"
+var notCoursePrefixes = map[string]bool{
+	"LAB": true, "ROOM": true, "HALL": true, "WEEK": true, "DAY": true, "UNIT": true, "PART": true,
+	"TEST": true, "QUIZ": true, "EXAM": true, "CLASS": true, "GROUP": true, "TEAM": true, "SLOT": true,
+	"TUT": true, "SEM": true, "YEAR": true, "FLOOR": true, "BLOCK": true, "LEVEL": true, "PAGE": true,
+}
+
+// courseCodeSeparators split an event summary into words that may be, or with the next one make up, a course code
+var courseCodeSeparators = regexp.MustCompile(`[\s:;,|/()\[\]_-]+`)
+
+// Reasons an event is left out of an import
+const (
+	importSkipCancelled      = "event is cancelled"
+	importSkipOverride       = "changes to a single occurrence are not imported"
+	importSkipNoStart        = "event has no valid start"
+	importSkipRecurrence     = "only weekly events repeating every week or every other week become class sessions"
+	importSkipRecurringTodo  = "recurring to-dos are not imported"
+	importSkipNoCourse       = "no course of the semester matches the summary"
+	importSkipNoCourseCode   = "no course code found in the summary to create a course from"
+	importSkipSessionTime    = "class session must start and end on the same day"
+	importSkipNotInSemester  = "event does not take place during the semester"
+	importSkipSessionExists  = "class session already exists"
+	importSkipConflict       = "class session overlaps other sessions, allow conflicts to import it"
+	importSkipReminderExists = "reminder already exists"
+)
+
+type ICalendarImportService interface {
+	// ImportCalendar maps the events of an iCalendar file onto the courses of a semester: weekly events become
+	// class sessions and one-off events and to-dos become reminders. Nothing is saved unless request.Commit
+	// is set, then everything of the preview is created in one transaction.
+	ImportCalendar(ctx context.Context, userID string, data string, request models.CalendarImportRequest) (*models.CalendarImportPreview, int)
+}
+
+type CalendarImportService struct {
+	userRepo           repo.IUserRepository
+	semesterRepo       repo.ISemesterRepository
+	courseRepo         repo.ICourseRepository
+	classSessionRepo   repo.IClassSessionRepository
+	reminderRepo       repo.IReminderRepository
+	calendarImportRepo repo.ICalendarImportRepository
+}
+
+func NewCalendarImportService(
+	userRepository repo.IUserRepository,
+	semesterRepository repo.ISemesterRepository,
+	courseRepository repo.ICourseRepository,
+	classSessionRepository repo.IClassSessionRepository,
+	reminderRepository repo.IReminderRepository,
+	calendarImportRepository repo.ICalendarImportRepository,
+) ICalendarImportService {
+	return &CalendarImportService{
+		userRepo:           userRepository,
+		semesterRepo:       semesterRepository,
+		courseRepo:         courseRepository,
+		classSessionRepo:   classSessionRepository,
+		reminderRepo:       reminderRepository,
+		calendarImportRepo: calendarImportRepository,
+	}
+}
+
+// calendarImport holds the state of an import while its events are mapped
+type calendarImport struct {
+	userID         string
+	semester       *models.Semester
+	location       *time.Location
+	createCourses  bool
+	allowConflicts bool
+
+	courses      []*models.Course          // of the semester, existing ones first
+	newCourses   map[string]*models.Course // by normalized code
+	sessions     map[string]bool           // existing and planned sessions, by sessionKey
+	sessionList  []*models.ClassSession    // existing and planned sessions, to find conflicts
+	reminders    map[string]bool           // existing and planned reminders, by reminderKey
+	unknownZones map[string]bool           // TZIDs already warned about
+
+	preview *models.CalendarImportPreview
+}
+
+func (s *CalendarImportService) ImportCalendar(ctx context.Context, userID string, data string, request models.CalendarImportRequest) (*models.CalendarImportPreview, int) {
+	calendar, err := utils.ParseICal(data)
+	if err != nil {
+		global.Log.Warn(err.Error(), zap.String("userID", userID))
+		return nil, response.CodeInvalidCalendarFile
+	}
+
+	var events []*utils.ICalComponent
+	for _, component := range calendar.Components {
+		if component.Name == "VEVENT" || component.Name == "VTODO" {
+			events = append(events, component)
+		}
+	}
+	if len(events) > consts.CALENDAR_IMPORT_MAX_EVENTS {
+		global.Log.Warn(errMessage.ErrInvalidICalendar.Error(), zap.String("userID", userID), zap.Int("events", len(events)))
+		return nil, response.CodeInvalidCalendarFile
+	}
+
+	state, code := s.loadImport(ctx, userID, request)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+
+	for _, event := range events {
+		state.addEvent(event)
+	}
+
+	preview := state.preview
+	if !request.Commit {
+		return preview, response.CodeSuccess
+	}
+
+	if err := s.calendarImportRepo.ImportCalendar(ctx, preview.Courses, preview.ClassSessions, preview.Reminders); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			global.Log.Warn(errMessage.ErrCourseAlreadyExists.Error(), zap.String("userID", userID))
+			return nil, response.CodeCourseAlreadyExists
+		}
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			global.Log.Warn(errMessage.ErrSemesterNotFound.Error(), zap.String("userID", userID), zap.Int("semesterID", request.SemesterID))
+			return nil, response.CodeSemesterNotFound
+		}
+
+		global.Log.Error("Error importing calendar", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedImportCalendar
+	}
+
+	preview.Committed = true
+	global.Log.Info("Calendar imported",
+		zap.String("userID", userID),
+		zap.Int("courses", len(preview.Courses)),
+		zap.Int("classSessions", len(preview.ClassSessions)),
+		zap.Int("reminders", len(preview.Reminders)),
+	)
+	return preview, response.CodeSuccess
+}
+
+// loadImport loads the semester with its courses and sessions, and the user's reminders, to match events against
+func (s *CalendarImportService) loadImport(ctx context.Context, userID string, request models.CalendarImportRequest) (*calendarImport, int) {
+	location, code := getUserLocation(ctx, s.userRepo, userID)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+
+	semester, err := s.semesterRepo.GetSemesterByID(ctx, userID, request.SemesterID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Log.Warn(errMessage.ErrSemesterNotFound.Error(), zap.String("userID", userID), zap.Int("semesterID", request.SemesterID))
+			return nil, response.CodeSemesterNotFound
+		}
+
+		global.Log.Error("Error getting semester by ID", zap.Error(err), zap.String("userID", userID), zap.Int("semesterID", request.SemesterID))
+		return nil, response.CodeFailedGetSemester
+	}
+
+	courses, err := s.courseRepo.GetCourses(ctx, userID, models.CourseFilter{SemesterID: semester.ID})
+	if err != nil {
+		global.Log.Error("Error getting courses", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedGetCourse
+	}
+
+	sessions, err := s.classSessionRepo.GetClassSessions(ctx, userID, models.ClassSessionFilter{SemesterID: semester.ID})
+	if err != nil {
+		global.Log.Error("Error getting class sessions", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedGetClassSession
+	}
+
+	reminders, err := s.reminderRepo.GetReminders(ctx, userID, models.ReminderFilter{}, time.Now())
+	if err != nil {
+		global.Log.Error("Error getting reminders", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedGetReminder
+	}
+
+	state := &calendarImport{
+		userID:         userID,
+		semester:       semester,
+		location:       location,
+		createCourses:  request.CreateCourses,
+		allowConflicts: request.AllowConflicts,
+		courses:        courses,
+		newCourses:     make(map[string]*models.Course),
+		sessions:       make(map[string]bool, len(sessions)),
+		sessionList:    sessions,
+		reminders:      make(map[string]bool, len(reminders)),
+		unknownZones:   make(map[string]bool),
+		preview: &models.CalendarImportPreview{
+			Courses:       []*models.Course{},
+			ClassSessions: []*models.ClassSession{},
+			Reminders:     []*models.Reminder{},
+			Skipped:       []models.CalendarImportSkip{},
+			Conflicts:     []models.SessionConflict{},
+			Warnings:      []string{},
+		},
+	}
+
+	for _, session := range sessions {
+		state.sessions[sessionKey(session.Course, session)] = true
+	}
+	for _, reminder := range reminders {
+		state.reminders[reminderKey(reminder.Title, reminder.DueAt)] = true
+	}
+	return state, response.CodeSuccess
+}
+
+// addEvent maps one VEVENT or VTODO onto a class session or a reminder, or records why it is skipped
+func (c *calendarImport) addEvent(event *utils.ICalComponent) {
+	summary := event.Text("SUMMARY")
+	skip := func(reason string) {
+		c.preview.Skipped = append(c.preview.Skipped, models.CalendarImportSkip{UID: event.Text("UID"), Summary: summary, Reason: reason})
+	}
+
+	if strings.EqualFold(event.Text("STATUS"), "CANCELLED") {
+		skip(importSkipCancelled)
+		return
+	}
+	if event.Property("RECURRENCE-ID") != nil {
+		skip(importSkipOverride)
+		return
+	}
+
+	start := event.Property("DTSTART")
+	if event.Name == "VTODO" {
+		if due := event.Property("DUE"); due != nil {
+			start = due
+		}
+	}
+	if start == nil {
+		skip(importSkipNoStart)
+		return
+	}
+	c.checkZone(start)
+	startAt, allDay, err := start.Time(c.location)
+	if err != nil {
+		skip(importSkipNoStart)
+		return
+	}
+
+	rrule := event.Property("RRULE")
+	if rrule == nil {
+		c.addReminder(event, summary, startAt, allDay)
+		return
+	}
+	if event.Name == "VTODO" {
+		skip(importSkipRecurringTodo)
+		return
+	}
+
+	rule, err := utils.ParseRRule(rrule.Value)
+	if err != nil || rule.Freq != utils.FreqWeekly || rule.Interval > 2 || allDay {
+		skip(importSkipRecurrence)
+		return
+	}
+
+	course, reason := c.course(summary)
+	if course == nil {
+		skip(reason)
+		return
+	}
+	if reason := c.addSessions(event, course, summary, startAt, rule); reason != "" {
+		skip(reason)
+	}
+}
+
+// addSessions creates a session per weekday of a weekly event, held every week or, every other week,
+// in the weeks of the semester its first occurrence falls in. The sessions span the whole semester.
+func (c *calendarImport) addSessions(event *utils.ICalComponent, course *models.Course, summary string, startAt time.Time, rule *utils.RRule) string {
+	start := startAt.In(c.location)
+	end, ok := c.eventEnd(event, startAt)
+	if !ok || !end.After(start) || !sameDate(start, end) {
+		return importSkipSessionTime
+	}
+
+	parity := consts.WeekParity.EVERY
+	if rule.Interval == 2 {
+		date := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+		// Weeks since week 1 of the semester, which is odd
+		weeks := int(weekStart(date).Sub(weekStart(c.semester.StartDate)).Hours()) / (24 * 7)
+		if weeks%2 == 0 {
+			parity = consts.WeekParity.ODD
+		} else {
+			parity = consts.WeekParity.EVEN
+		}
+	}
+
+	days := []time.Weekday{start.Weekday()}
+	if len(rule.ByDay) > 0 {
+		days = days[:0]
+		for _, day := range rule.ByDay {
+			days = append(days, day.Weekday)
+		}
+	}
+
+	var location *string
+	if value := event.Text("LOCATION"); value != "" {
+		location = trimOptional(&value)
+	}
+
+	added, held, conflicted := false, false, false
+	for _, day := range days {
+		session := &models.ClassSession{
+			DayOfWeek:  int8((int(day)+6)%7 + 1),
+			StartTime:  start.Format("15:04") + ":00",
+			EndTime:    end.Format("15:04") + ":00",
+			Location:   location,
+			Type:       sessionTypeOf(summary),
+			WeekParity: parity,
+			Course:     course,
+		}
+		if _, ok := firstSessionDate(session, c.semester); !ok {
+			continue
+		}
+		held = true
+
+		key := sessionKey(course, session)
+		if c.sessions[key] {
+			continue
+		}
+
+		// Overlaps are found the way they are when a session is added by hand
+		var conflicts []*models.ClassSession
+		for _, other := range c.sessionList {
+			if sessionsOverlap(session, other) {
+				conflicts = append(conflicts, other)
+			}
+		}
+		if len(conflicts) > 0 {
+			c.preview.Conflicts = append(c.preview.Conflicts, models.SessionConflict{Session: session, With: conflicts})
+			if !c.allowConflicts {
+				conflicted = true
+				continue
+			}
+		}
+
+		c.sessions[key] = true
+		c.sessionList = append(c.sessionList, session)
+		c.preview.ClassSessions = append(c.preview.ClassSessions, session)
+		added = true
+	}
+
+	switch {
+	case !held:
+		return importSkipNotInSemester
+	case !added && conflicted:
+		return importSkipConflict
+	case !added:
+		return importSkipSessionExists
+	}
+	return ""
+}
+
+// checkZone warns once per TZID when a time names a zone that is not known and is read in the user's timezone
+func (c *calendarImport) checkZone(property *utils.ICalProperty) {
+	tzid, unknown := property.UnknownZone()
+	if !unknown || c.unknownZones[tzid] {
+		return
+	}
+	c.unknownZones[tzid] = true
+	c.preview.Warnings = append(c.preview.Warnings, fmt.Sprintf("unknown timezone %q, its times are read in %s", tzid, c.location.String()))
+}
+
+// addReminder creates a reminder due when a one-off event starts or a to-do is due,
+// all-day events are due at the end of their day. The course is optional for reminders.
+func (c *calendarImport) addReminder(event *utils.ICalComponent, summary string, startAt time.Time, allDay bool) {
+	var due time.Time
+	if allDay {
+		var err error
+		if due, err = utils.ParseDateTimeIn(startAt.Format(utils.DateLayout), "23:59", c.location); err != nil {
+			return
+		}
+	} else {
+		due = startAt.In(c.location)
+	}
+
+	title := summary
+	if title == "" {
+		title = "Untitled event"
+	}
+	if len([]rune(title)) > 255 {
+		title = string([]rune(title)[:255])
+	}
+
+	key := reminderKey(title, due)
+	if c.reminders[key] {
+		c.preview.Skipped = append(c.preview.Skipped, models.CalendarImportSkip{UID: event.Text("UID"), Summary: summary, Reason: importSkipReminderExists})
+		return
+	}
+	c.reminders[key] = true
+
+	course, _ := c.course(summary)
+	reminder := &models.Reminder{
+		Title:   title,
+		DueDate: time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, time.UTC),
+		DueTime: due.Format("15:04") + ":00",
+		DueAt:   due,
+		UserID:  c.userID,
+		Type:    consts.ReminderType.ASSIGNMENT,
+		Status:  openReminderStatus(due),
+		Course:  course,
+	}
+	if description := event.Text("DESCRIPTION"); description != "" {
+		reminder.Description = &description
+	}
+	c.preview.Reminders = append(c.preview.Reminders, reminder)
+}
+
+// course finds the course of the semester an event summary names, by code and then by name, or plans one
+// from the code in the summary when courses may be created. The reason is set when there is none.
+func (c *calendarImport) course(summary string) (*models.Course, string) {
+	candidates := courseCodeCandidates(summary)
+	for _, course := range c.courses {
+		if code := normalizeCourseCode(course.CourseID); code != "" && candidates[code] {
+			return course, ""
+		}
+	}
+	lower := strings.ToLower(summary)
+	var best *models.Course
+	for _, course := range c.courses {
+		name := strings.ToLower(course.CourseName)
+		if name != "" && strings.Contains(lower, name) && (best == nil || len(name) > len(best.CourseName)) {
+			best = course
+		}
+	}
+	if best != nil {
+		return best, ""
+	}
+
+	if !c.createCourses {
+		return nil, importSkipNoCourse
+	}
+	var match []int
+	for _, found := range courseCodePattern.FindAllStringSubmatchIndex(summary, -1) {
+		if !notCoursePrefixes[summary[found[2]:found[3]]] {
+			match = found
+			break
+		}
+	}
+	if match == nil {
+		return nil, importSkipNoCourseCode
+	}
+
+	code := strings.ToUpper(summary[match[2]:match[3]] + summary[match[4]:match[5]])
+	if course := c.newCourses[code]; course != nil {
+		return course, ""
+	}
+
+	name := strings.Trim(summary[:match[0]]+" "+summary[match[1]:], " -:|()[]")
+	if name == "" {
+		name = code
+	}
+	course := &models.Course{
+		CourseID:   code,
+		CourseName: name,
+		UserID:     c.userID,
+		Credits:    consts.COURSE_MIN_CREDITS,
+		SemesterID: c.semester.ID,
+		Semester:   *c.semester,
+	}
+	c.newCourses[code] = course
+	c.courses = append(c.courses, course)
+	c.preview.Courses = append(c.preview.Courses, course)
+	return course, ""
+}
+
+// eventEnd returns when an event ends in location, from DTEND or DURATION
+func (c *calendarImport) eventEnd(event *utils.ICalComponent, start time.Time) (time.Time, bool) {
+	location := c.location
+	if end := event.Property("DTEND"); end != nil {
+		c.checkZone(end)
+		t, allDay, err := end.Time(location)
+		if err != nil || allDay {
+			return time.Time{}, false
+		}
+		return t.In(location), true
+	}
+	if duration := event.Property("DURATION"); duration != nil {
+		d, err := utils.ParseICalDuration(duration.Value)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return start.Add(d).In(location), true
+	}
+	return time.Time{}, false
+}
+
+// sessionTypeOf guesses the session type from keywords of an event summary
+func sessionTypeOf(summary string) int8 {
+	lower := strings.ToLower(summary)
+	switch {
+	case strings.Contains(lower, "lab"), strings.Contains(lower, "practical"):
+		return consts.ClassSessionType.LAB
+	case strings.Contains(lower, "tutorial"), strings.Contains(lower, "seminar"):
+		return consts.ClassSessionType.TUTORIAL
+	}
+	return consts.ClassSessionType.LECTURE
+}
+
+// normalizeCourseCode upper cases a code and drops spaces and dashes, so "cs 101" matches "CS-101"
+func normalizeCourseCode(value string) string {
+	return strings.NewReplacer(" ", "", "-", "", "_", "").Replace(strings.ToUpper(value))
+}
+
+// courseCodeCandidates lists the normalized words of an event summary, alone and joined with the next one
+// so "CS 101" is found too. Codes match a whole candidate, so "CS10" does not match "CS101".
+func courseCodeCandidates(summary string) map[string]bool {
+	words := courseCodeSeparators.Split(summary, -1)
+	candidates := make(map[string]bool, 2*len(words))
+	for i, word := range words {
+		word = normalizeCourseCode(word)
+		if word == "" {
+			continue
+		}
+		candidates[word] = true
+		if i+1 < len(words) {
+			candidates[word+normalizeCourseCode(words[i+1])] = true
+		}
+	}
+	return candidates
+}
+
+// sessionKey identifies a session by course and weekly time slot, courses to create by their code
+func sessionKey(course *models.Course, session *models.ClassSession) string {
+	return fmt.Sprintf("%d/%s/%d/%s/%s/%d", course.ID, course.CourseID, session.DayOfWeek, session.StartTime, session.EndTime, session.WeekParity)
+}
+
+// reminderKey identifies a reminder by title and due instant
+func reminderKey(title string, dueAt time.Time) string {
+	return fmt.Sprintf("%s/%d", strings.ToLower(title), dueAt.Unix())
+}
+
+// sameDate reports whether two times in the same location fall on the same calendar date
+func sameDate(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nas03/scholar-ai/backend/internal/consts"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"github.com/nas03/scholar-ai/backend/internal/utils"
+)
+
+func newTestImport(createCourses, allowConflicts bool, courses ...*models.Course) *calendarImport {
+	return &calendarImport{
+		userID:         "user-1",
+		semester:       &models.Semester{ID: 1, StartDate: time.Date(2026, 9, 7, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, 12, 20, 0, 0, 0, 0, time.UTC)},
+		location:       time.UTC,
+		createCourses:  createCourses,
+		allowConflicts: allowConflicts,
+		courses:        courses,
+		newCourses:     make(map[string]*models.Course),
+		sessions:       make(map[string]bool),
+		reminders:      make(map[string]bool),
+		unknownZones:   make(map[string]bool),
+		preview:        &models.CalendarImportPreview{},
+	}
+}
+
+func icalEvent(t *testing.T, lines ...string) *utils.ICalComponent {
+	t.Helper()
+	data := "BEGIN:VCALENDAR\nBEGIN:VEVENT\n" + strings.Join(lines, "\n") + "\nEND:VEVENT\nEND:VCALENDAR\n"
+	calendar, err := utils.ParseICal(data)
+	if err != nil {
+		t.Fatalf("ParseICal() error = %v", err)
+	}
+	return calendar.Components[0]
+}
+
+func weeklyRule(t *testing.T) *utils.RRule {
+	t.Helper()
+	rule, err := utils.ParseRRule("FREQ=WEEKLY")
+	if err != nil {
+		t.Fatalf("ParseRRule() error = %v", err)
+	}
+	return rule
+}
+
+func TestCalendarImportCourseMatching(t *testing.T) {
+	cs101 := &models.Course{ID: 1, CourseID: "CS101", CourseName: "Intro to Programming"}
+	it3040 := &models.Course{ID: 2, CourseID: "IT-3040E", CourseName: "Object-Oriented Programming"}
+	cs10 := &models.Course{ID: 3, CourseID: "CS10", CourseName: "Computing Basics"}
+
+	tests := []struct {
+		summary string
+		want    *models.Course
+	}{
+		{summary: "CS101 Lecture", want: cs101},
+		{summary: "cs 101 - lecture", want: cs101},
+		{summary: "Lecture: CS101-Lab", want: cs101},
+		{summary: "IT3040E tutorial", want: it3040},
+		{summary: "[IT 3040E] Lab 02", want: it3040},
+		{summary: "CS10 seminar", want: cs10},
+		{summary: "CS1010 lecture", want: nil},
+		{summary: "Computing Basics review", want: cs10},
+		{summary: "Office hours", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.summary, func(t *testing.T) {
+			c := newTestImport(false, false, cs101, it3040, cs10)
+			got, reason := c.course(tt.summary)
+			if got != tt.want {
+				t.Errorf("course(%q) = %v (%s), want %v", tt.summary, got, reason, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalendarImportCreatesCoursesFromCodes(t *testing.T) {
+	tests := []struct {
+		summary  string
+		wantCode string // empty when no course is created
+	}{
+		{summary: "MATH 2010 Calculus", wantCode: "MATH2010"},
+		{summary: "IT-3040E Lecture", wantCode: "IT3040E"},
+		{summary: "Lab 02 safety briefing", wantCode: ""},
+		{summary: "ROOM 101 meeting", wantCode: ""},
+		{summary: "Week 10 review", wantCode: ""},
+		{summary: "LAB 02 for PHYS 1100", wantCode: "PHYS1100"},
+		{summary: "Team sync", wantCode: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.summary, func(t *testing.T) {
+			c := newTestImport(true, false)
+			got, reason := c.course(tt.summary)
+			switch {
+			case tt.wantCode == "" && got != nil:
+				t.Errorf("course(%q) created %q", tt.summary, got.CourseID)
+			case tt.wantCode == "" && reason != importSkipNoCourseCode:
+				t.Errorf("course(%q) reason = %q", tt.summary, reason)
+			case tt.wantCode != "" && (got == nil || got.CourseID != tt.wantCode):
+				t.Errorf("course(%q) = %v (%s), want code %s", tt.summary, got, reason, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestCalendarImportSessionConflicts(t *testing.T) {
+	course := &models.Course{ID: 1, CourseID: "CS101"}
+	other := &models.Course{ID: 2, CourseID: "MA201"}
+	existing := &models.ClassSession{ID: 9, DayOfWeek: 1, StartTime: "09:00:00", EndTime: "10:30:00", WeekParity: consts.WeekParity.EVERY, Course: other}
+
+	tests := []struct {
+		name           string
+		allowConflicts bool
+		wantReason     string
+		wantSessions   int
+	}{
+		{name: "conflicts skipped by default", wantReason: importSkipConflict, wantSessions: 0},
+		{name: "conflicts allowed", allowConflicts: true, wantSessions: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestImport(false, tt.allowConflicts, course)
+			c.sessionList = []*models.ClassSession{existing}
+
+			// Monday 2026-09-07 10:00 to 11:00, overlapping the existing session
+			start := time.Date(2026, 9, 7, 10, 0, 0, 0, time.UTC)
+			event := icalEvent(t, "DTSTART:20260907T100000Z", "DTEND:20260907T110000Z")
+			reason := c.addSessions(event, course, "CS101 Lecture", start, weeklyRule(t))
+
+			if reason != tt.wantReason || len(c.preview.ClassSessions) != tt.wantSessions {
+				t.Fatalf("addSessions() = %q with %d sessions, want %q with %d", reason, len(c.preview.ClassSessions), tt.wantReason, tt.wantSessions)
+			}
+			if len(c.preview.Conflicts) != 1 || len(c.preview.Conflicts[0].With) != 1 || c.preview.Conflicts[0].With[0] != existing {
+				t.Errorf("conflicts = %+v, want the existing session", c.preview.Conflicts)
+			}
+		})
+	}
+}
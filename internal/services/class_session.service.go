@@ -0,0 +1,296 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/consts"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	repo "github.com/nas03/scholar-ai/backend/internal/repositories"
+	errMessage "github.com/nas03/scholar-ai/backend/pkg/errors"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type IClassSessionService interface {
+	// CreateClassSession and UpdateClassSession reject a session overlapping another one of the
+	// semester unless the payload allows conflicts, the overlapped sessions are returned either way
+	CreateClassSession(ctx context.Context, userID string, payload *models.ClassSessionRequest) (*models.ClassSessionResult, int)
+	GetClassSession(ctx context.Context, userID string, id int) (*models.ClassSession, int)
+	GetClassSessions(ctx context.Context, userID string, filter models.ClassSessionFilter) ([]*models.ClassSession, int)
+	UpdateClassSession(ctx context.Context, userID string, id int, payload *models.ClassSessionRequest) (*models.ClassSessionResult, int)
+	DeleteClassSession(ctx context.Context, userID string, id int) int
+
+	// GetTimetable returns the week grid of a semester, the current one when query.SemesterID is unset
+	GetTimetable(ctx context.Context, userID string, query models.TimetableQuery) (*models.Timetable, int)
+}
+
+type ClassSessionService struct {
+	classSessionRepo repo.IClassSessionRepository
+	courseRepo       repo.ICourseRepository
+	semesterService  ISemesterService
+}
+
+func NewClassSessionService(
+	classSessionRepository repo.IClassSessionRepository,
+	courseRepository repo.ICourseRepository,
+	semesterService ISemesterService,
+) IClassSessionService {
+	return &ClassSessionService{
+		classSessionRepo: classSessionRepository,
+		courseRepo:       courseRepository,
+		semesterService:  semesterService,
+	}
+}
+
+func (s *ClassSessionService) CreateClassSession(ctx context.Context, userID string, payload *models.ClassSessionRequest) (*models.ClassSessionResult, int) {
+	session := &models.ClassSession{
+		CourseID:   payload.CourseID,
+		DayOfWeek:  payload.DayOfWeek,
+		StartTime:  payload.StartTime + ":00",
+		EndTime:    payload.EndTime + ":00",
+		Location:   trimOptional(payload.Location),
+		Type:       payload.Type,
+		WeekParity: payload.WeekParity,
+	}
+
+	conflicts, code := s.validateClassSession(ctx, userID, session, payload.AllowConflicts)
+	if code != response.CodeSuccess {
+		return &models.ClassSessionResult{Conflicts: conflicts}, code
+	}
+
+	if err := s.classSessionRepo.CreateClassSession(ctx, session); err != nil {
+		return nil, s.writeErrorCode(err, userID, "Error creating class session")
+	}
+
+	global.Log.Info("Class session created", zap.String("userID", userID), zap.Int("classSessionID", session.ID))
+	session, code = s.GetClassSession(ctx, userID, session.ID)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+	return &models.ClassSessionResult{Session: session, Conflicts: conflicts}, response.CodeSuccess
+}
+
+func (s *ClassSessionService) GetClassSession(ctx context.Context, userID string, id int) (*models.ClassSession, int) {
+	session, err := s.classSessionRepo.GetClassSessionByID(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Log.Warn(errMessage.ErrClassSessionNotFound.Error(), zap.String("userID", userID), zap.Int("classSessionID", id))
+			return nil, response.CodeClassSessionNotFound
+		}
+
+		global.Log.Error("Error getting class session by ID", zap.Error(err), zap.String("userID", userID), zap.Int("classSessionID", id))
+		return nil, response.CodeFailedGetClassSession
+	}
+	return session, response.CodeSuccess
+}
+
+func (s *ClassSessionService) GetClassSessions(ctx context.Context, userID string, filter models.ClassSessionFilter) ([]*models.ClassSession, int) {
+	sessions, err := s.classSessionRepo.GetClassSessions(ctx, userID, filter)
+	if err != nil {
+		global.Log.Error("Error getting class sessions", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedGetClassSession
+	}
+	return sessions, response.CodeSuccess
+}
+
+func (s *ClassSessionService) UpdateClassSession(ctx context.Context, userID string, id int, payload *models.ClassSessionRequest) (*models.ClassSessionResult, int) {
+	session, code := s.GetClassSession(ctx, userID, id)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+
+	session.CourseID = payload.CourseID
+	session.DayOfWeek = payload.DayOfWeek
+	session.StartTime = payload.StartTime + ":00"
+	session.EndTime = payload.EndTime + ":00"
+	session.Location = trimOptional(payload.Location)
+	session.Type = payload.Type
+	session.WeekParity = payload.WeekParity
+
+	conflicts, code := s.validateClassSession(ctx, userID, session, payload.AllowConflicts)
+	if code != response.CodeSuccess {
+		return &models.ClassSessionResult{Conflicts: conflicts}, code
+	}
+
+	updates := map[string]interface{}{
+		"course_id":   session.CourseID,
+		"day_of_week": session.DayOfWeek,
+		"start_time":  session.StartTime,
+		"end_time":    session.EndTime,
+		"location":    session.Location,
+		"type":        session.Type,
+		"week_parity": session.WeekParity,
+	}
+	if _, err := s.classSessionRepo.UpdateClassSession(ctx, userID, id, updates); err != nil {
+		return nil, s.writeErrorCode(err, userID, "Error updating class session")
+	}
+
+	global.Log.Info("Class session updated", zap.String("userID", userID), zap.Int("classSessionID", id))
+	session, code = s.GetClassSession(ctx, userID, id)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+	return &models.ClassSessionResult{Session: session, Conflicts: conflicts}, response.CodeSuccess
+}
+
+func (s *ClassSessionService) DeleteClassSession(ctx context.Context, userID string, id int) int {
+	rowsAffected, err := s.classSessionRepo.DeleteClassSession(ctx, userID, id)
+	if err != nil {
+		global.Log.Error("Error deleting class session", zap.Error(err), zap.String("userID", userID), zap.Int("classSessionID", id))
+		return response.CodeFailedUpdateClassSession
+	}
+	if rowsAffected == 0 {
+		global.Log.Warn(errMessage.ErrClassSessionNotFound.Error(), zap.String("userID", userID), zap.Int("classSessionID", id))
+		return response.CodeClassSessionNotFound
+	}
+
+	global.Log.Info("Class session deleted", zap.String("userID", userID), zap.Int("classSessionID", id))
+	return response.CodeSuccess
+}
+
+func (s *ClassSessionService) GetTimetable(ctx context.Context, userID string, query models.TimetableQuery) (*models.Timetable, int) {
+	var semester *models.Semester
+	var code int
+	if query.SemesterID != 0 {
+		semester, code = s.semesterService.GetSemester(ctx, userID, query.SemesterID)
+	} else {
+		semester, code = s.semesterService.GetCurrentSemester(ctx, userID, "")
+	}
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+
+	// Weeks run Monday to Sunday, week 1 holds the first day of the semester
+	var monday time.Time
+	if query.Week != 0 {
+		monday = weekStart(semester.StartDate).AddDate(0, 0, 7*(query.Week-1))
+		if monday.After(semester.EndDate) {
+			return nil, response.CodeInvalidInput
+		}
+	}
+
+	sessions, code := s.GetClassSessions(ctx, userID, models.ClassSessionFilter{SemesterID: semester.ID})
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+
+	timetable := &models.Timetable{
+		Semester: semester,
+		Week:     query.Week,
+		Days:     make([]models.TimetableDay, 7),
+	}
+	for i := range timetable.Days {
+		day := &timetable.Days[i]
+		day.DayOfWeek = int8(i + 1)
+		day.Name = time.Weekday((i + 1) % 7).String()
+		day.Sessions = []*models.TimetableEntry{}
+		if query.Week != 0 {
+			date := monday.AddDate(0, 0, i)
+			day.Date = &date
+		}
+	}
+
+	for _, session := range sessions {
+		if query.Week != 0 && !heldInWeek(session, query.Week) {
+			continue
+		}
+		day := &timetable.Days[session.DayOfWeek-1]
+		day.Sessions = append(day.Sessions, &models.TimetableEntry{ClassSession: session})
+	}
+
+	// Sessions arrive by start time, so every later entry of a day is compared once
+	for _, day := range timetable.Days {
+		for i, entry := range day.Sessions {
+			for _, other := range day.Sessions[i+1:] {
+				if sessionsOverlap(entry.ClassSession, other.ClassSession) {
+					entry.ConflictsWith = append(entry.ConflictsWith, other.ID)
+					other.ConflictsWith = append(other.ConflictsWith, entry.ID)
+				}
+			}
+		}
+	}
+	return timetable, response.CodeSuccess
+}
+
+// validateClassSession checks the course belongs to the user and the time range, and finds the
+// sessions of the semester the session overlaps. Overlaps fail validation unless allowed.
+func (s *ClassSessionService) validateClassSession(ctx context.Context, userID string, session *models.ClassSession, allowConflicts bool) ([]*models.ClassSession, int) {
+	if session.EndTime <= session.StartTime {
+		global.Log.Warn(errMessage.ErrInvalidSessionTime.Error(), zap.String("userID", userID))
+		return nil, response.CodeInvalidSessionTime
+	}
+
+	course, err := s.courseRepo.GetCourseByID(ctx, userID, session.CourseID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Log.Warn(errMessage.ErrCourseNotFound.Error(), zap.String("userID", userID), zap.Int("courseID", session.CourseID))
+			return nil, response.CodeCourseNotFound
+		}
+
+		global.Log.Error("Error getting course by ID", zap.Error(err), zap.String("userID", userID), zap.Int("courseID", session.CourseID))
+		return nil, response.CodeFailedGetCourse
+	}
+
+	sessions, err := s.classSessionRepo.GetClassSessions(ctx, userID, models.ClassSessionFilter{SemesterID: course.SemesterID})
+	if err != nil {
+		global.Log.Error("Error getting class sessions", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedGetClassSession
+	}
+
+	var conflicts []*models.ClassSession
+	for _, other := range sessions {
+		if other.ID != session.ID && sessionsOverlap(session, other) {
+			conflicts = append(conflicts, other)
+		}
+	}
+
+	if len(conflicts) > 0 && !allowConflicts {
+		global.Log.Warn(errMessage.ErrClassSessionConflict.Error(), zap.String("userID", userID), zap.Int("conflicts", len(conflicts)))
+		return conflicts, response.CodeClassSessionConflict
+	}
+	return conflicts, response.CodeSuccess
+}
+
+// writeErrorCode maps constraint violations of a class session insert or update to response codes
+func (s *ClassSessionService) writeErrorCode(err error, userID, message string) int {
+	// The course can be deleted between the ownership check and the write
+	if errors.Is(err, gorm.ErrForeignKeyViolated) {
+		global.Log.Warn(errMessage.ErrCourseNotFound.Error(), zap.String("userID", userID))
+		return response.CodeCourseNotFound
+	}
+
+	global.Log.Error(message, zap.Error(err), zap.String("userID", userID))
+	return response.CodeFailedUpdateClassSession
+}
+
+// sessionsOverlap reports whether two sessions share time on the same weekday in some week
+func sessionsOverlap(a, b *models.ClassSession) bool {
+	if a.DayOfWeek != b.DayOfWeek {
+		return false
+	}
+	if a.WeekParity != consts.WeekParity.EVERY && b.WeekParity != consts.WeekParity.EVERY && a.WeekParity != b.WeekParity {
+		return false
+	}
+	// Times are "15:04:05" strings, so they compare in order
+	return a.StartTime < b.EndTime && b.StartTime < a.EndTime
+}
+
+// heldInWeek reports whether a session takes place in the given week of its semester
+func heldInWeek(session *models.ClassSession, week int) bool {
+	switch session.WeekParity {
+	case consts.WeekParity.ODD:
+		return week%2 == 1
+	case consts.WeekParity.EVEN:
+		return week%2 == 0
+	}
+	return true
+}
+
+// weekStart returns the Monday of the week holding date
+func weekStart(date time.Time) time.Time {
+	return date.AddDate(0, 0, -(int(date.Weekday())+6)%7)
+}
@@ -0,0 +1,238 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/consts"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	repo "github.com/nas03/scholar-ai/backend/internal/repositories"
+	errMessage "github.com/nas03/scholar-ai/backend/pkg/errors"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type ICourseService interface {
+	CreateCourse(ctx context.Context, userID string, payload *models.CourseRequest) (*models.Course, int)
+	GetCourse(ctx context.Context, userID string, id int) (*models.Course, int)
+	GetCourses(ctx context.Context, userID string, filter models.CourseFilter) ([]*models.Course, int)
+	UpdateCourse(ctx context.Context, userID string, id int, payload *models.CourseRequest) (*models.Course, int)
+	DeleteCourse(ctx context.Context, userID string, id int) int
+}
+
+type CourseService struct {
+	courseRepo     repo.ICourseRepository
+	semesterRepo   repo.ISemesterRepository
+	lecturerRepo   repo.ILecturerRepository
+	gradingService IGradingService
+}
+
+func NewCourseService(
+	courseRepository repo.ICourseRepository,
+	semesterRepository repo.ISemesterRepository,
+	lecturerRepository repo.ILecturerRepository,
+	gradingService IGradingService,
+) ICourseService {
+	return &CourseService{
+		courseRepo:     courseRepository,
+		semesterRepo:   semesterRepository,
+		lecturerRepo:   lecturerRepository,
+		gradingService: gradingService,
+	}
+}
+
+func (s *CourseService) CreateCourse(ctx context.Context, userID string, payload *models.CourseRequest) (*models.Course, int) {
+	if code := validateCourse(userID, payload); code != response.CodeSuccess {
+		return nil, code
+	}
+	if code := s.checkSemester(ctx, userID, payload.SemesterID); code != response.CodeSuccess {
+		return nil, code
+	}
+	lecturerIDs, code := s.checkLecturers(ctx, userID, payload.LecturerIDs)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+	if code := s.gradingService.CheckGrade(ctx, userID, payload.GradingScaleID, payload.Grade, payload.IsPassFail); code != response.CodeSuccess {
+		return nil, code
+	}
+
+	lecturers := make([]models.Lecturer, 0, len(lecturerIDs))
+	for _, lecturerID := range lecturerIDs {
+		lecturers = append(lecturers, models.Lecturer{ID: lecturerID})
+	}
+
+	course := &models.Course{
+		CourseID:       strings.TrimSpace(payload.CourseID),
+		CourseName:     strings.TrimSpace(payload.CourseName),
+		UserID:         userID,
+		Description:    payload.Description,
+		Credits:        payload.Credits,
+		SemesterID:     payload.SemesterID,
+		Grade:          trimOptional(payload.Grade),
+		IsPassFail:     boolFlag(payload.IsPassFail),
+		ExcludeFromGPA: boolFlag(payload.ExcludeFromGPA),
+		GradingScaleID: payload.GradingScaleID,
+		Lecturers:      lecturers,
+	}
+	if err := s.courseRepo.CreateCourse(ctx, course); err != nil {
+		return nil, s.writeErrorCode(err, userID, "Error creating course")
+	}
+
+	global.Log.Info("Course created", zap.String("userID", userID), zap.Int("courseID", course.ID))
+	return s.GetCourse(ctx, userID, course.ID)
+}
+
+func (s *CourseService) GetCourse(ctx context.Context, userID string, id int) (*models.Course, int) {
+	course, err := s.courseRepo.GetCourseByID(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Log.Warn(errMessage.ErrCourseNotFound.Error(), zap.String("userID", userID), zap.Int("courseID", id))
+			return nil, response.CodeCourseNotFound
+		}
+
+		global.Log.Error("Error getting course by ID", zap.Error(err), zap.String("userID", userID), zap.Int("courseID", id))
+		return nil, response.CodeFailedGetCourse
+	}
+	return course, response.CodeSuccess
+}
+
+func (s *CourseService) GetCourses(ctx context.Context, userID string, filter models.CourseFilter) ([]*models.Course, int) {
+	if filter.TagID != 0 {
+		filter.TagIDs = append(filter.TagIDs, filter.TagID)
+	}
+	filter.TagIDs = uniqueIDs(filter.TagIDs)
+
+	courses, err := s.courseRepo.GetCourses(ctx, userID, filter)
+	if err != nil {
+		global.Log.Error("Error getting courses", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedGetCourse
+	}
+	return courses, response.CodeSuccess
+}
+
+func (s *CourseService) UpdateCourse(ctx context.Context, userID string, id int, payload *models.CourseRequest) (*models.Course, int) {
+	if code := validateCourse(userID, payload); code != response.CodeSuccess {
+		return nil, code
+	}
+	if _, code := s.GetCourse(ctx, userID, id); code != response.CodeSuccess {
+		return nil, code
+	}
+	if code := s.checkSemester(ctx, userID, payload.SemesterID); code != response.CodeSuccess {
+		return nil, code
+	}
+	lecturerIDs, code := s.checkLecturers(ctx, userID, payload.LecturerIDs)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+	if code := s.gradingService.CheckGrade(ctx, userID, payload.GradingScaleID, payload.Grade, payload.IsPassFail); code != response.CodeSuccess {
+		return nil, code
+	}
+
+	updates := map[string]interface{}{
+		"course_id":        strings.TrimSpace(payload.CourseID),
+		"course_name":      strings.TrimSpace(payload.CourseName),
+		"description":      payload.Description,
+		"credits":          payload.Credits,
+		"semester_id":      payload.SemesterID,
+		"grade":            trimOptional(payload.Grade),
+		"is_pass_fail":     boolFlag(payload.IsPassFail),
+		"exclude_from_gpa": boolFlag(payload.ExcludeFromGPA),
+		"grading_scale_id": payload.GradingScaleID,
+	}
+	if err := s.courseRepo.UpdateCourse(ctx, userID, id, updates, lecturerIDs); err != nil {
+		return nil, s.writeErrorCode(err, userID, "Error updating course")
+	}
+
+	global.Log.Info("Course updated", zap.String("userID", userID), zap.Int("courseID", id))
+	return s.GetCourse(ctx, userID, id)
+}
+
+func (s *CourseService) DeleteCourse(ctx context.Context, userID string, id int) int {
+	rowsAffected, err := s.courseRepo.DeleteCourse(ctx, userID, id)
+	if err != nil {
+		global.Log.Error("Error deleting course", zap.Error(err), zap.String("userID", userID), zap.Int("courseID", id))
+		return response.CodeFailedUpdateCourse
+	}
+	if rowsAffected == 0 {
+		global.Log.Warn(errMessage.ErrCourseNotFound.Error(), zap.String("userID", userID), zap.Int("courseID", id))
+		return response.CodeCourseNotFound
+	}
+
+	global.Log.Info("Course deleted", zap.String("userID", userID), zap.Int("courseID", id))
+	return response.CodeSuccess
+}
+
+// writeErrorCode maps constraint violations of a course insert or update to response codes
+func (s *CourseService) writeErrorCode(err error, userID, message string) int {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		global.Log.Warn(errMessage.ErrCourseAlreadyExists.Error(), zap.String("userID", userID))
+		return response.CodeCourseAlreadyExists
+	}
+	if errors.Is(err, gorm.ErrForeignKeyViolated) {
+		global.Log.Warn(errMessage.ErrSemesterNotFound.Error(), zap.String("userID", userID))
+		return response.CodeSemesterNotFound
+	}
+
+	global.Log.Error(message, zap.Error(err), zap.String("userID", userID))
+	return response.CodeFailedUpdateCourse
+}
+
+// checkSemester makes sure the course goes into a semester of the same user
+func (s *CourseService) checkSemester(ctx context.Context, userID string, semesterID int) int {
+	if _, err := s.semesterRepo.GetSemesterByID(ctx, userID, semesterID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Log.Warn(errMessage.ErrSemesterNotFound.Error(), zap.String("userID", userID), zap.Int("semesterID", semesterID))
+			return response.CodeSemesterNotFound
+		}
+
+		global.Log.Error("Error getting semester by ID", zap.Error(err), zap.String("userID", userID))
+		return response.CodeFailedGetSemester
+	}
+	return response.CodeSuccess
+}
+
+// checkLecturers deduplicates the lecturer ids and makes sure they all belong to the user
+func (s *CourseService) checkLecturers(ctx context.Context, userID string, lecturerIDs []int) ([]int, int) {
+	unique := uniqueIDs(lecturerIDs)
+	if len(unique) == 0 {
+		return unique, response.CodeSuccess
+	}
+
+	count, err := s.lecturerRepo.CountLecturers(ctx, userID, unique)
+	if err != nil {
+		global.Log.Error("Error counting lecturers", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedGetLecturer
+	}
+	if count != int64(len(unique)) {
+		global.Log.Warn(errMessage.ErrLecturerNotFound.Error(), zap.String("userID", userID), zap.Ints("lecturerIDs", unique))
+		return nil, response.CodeLecturerNotFound
+	}
+	return unique, response.CodeSuccess
+}
+
+// uniqueIDs drops repeated ids, keeping the first occurrence order
+func uniqueIDs(ids []int) []int {
+	seen := make(map[int]bool, len(ids))
+	unique := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+func validateCourse(userID string, payload *models.CourseRequest) int {
+	if strings.TrimSpace(payload.CourseID) == "" || strings.TrimSpace(payload.CourseName) == "" {
+		return response.CodeInvalidInput
+	}
+	if payload.Credits < consts.COURSE_MIN_CREDITS || payload.Credits > consts.COURSE_MAX_CREDITS {
+		global.Log.Warn(errMessage.ErrInvalidCredits.Error(), zap.String("userID", userID), zap.Int("credits", payload.Credits))
+		return response.CodeInvalidCredits
+	}
+	return response.CodeSuccess
+}
@@ -0,0 +1,610 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/consts"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	repo "github.com/nas03/scholar-ai/backend/internal/repositories"
+	"github.com/nas03/scholar-ai/backend/internal/utils"
+	errMessage "github.com/nas03/scholar-ai/backend/pkg/errors"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// courseworkTransitions lists the statuses an assignment or exam may move to from each status
+var courseworkTransitions = map[int8][]int8{
+	consts.CourseworkStatus.PENDING:     {consts.CourseworkStatus.IN_PROGRESS, consts.CourseworkStatus.SUBMITTED},
+	consts.CourseworkStatus.IN_PROGRESS: {consts.CourseworkStatus.PENDING, consts.CourseworkStatus.SUBMITTED},
+	consts.CourseworkStatus.SUBMITTED:   {consts.CourseworkStatus.IN_PROGRESS, consts.CourseworkStatus.GRADED},
+	consts.CourseworkStatus.GRADED:      {consts.CourseworkStatus.SUBMITTED},
+}
+
+// courseworkKind tells an assignment from an exam in the helpers shared by both
+type courseworkKind struct {
+	name         string // logged with the id, e.g. assignmentID
+	label        string // starts the log messages
+	notFound     error
+	notFoundCode int
+}
+
+var (
+	assignmentKind = courseworkKind{
+		name:         "assignment",
+		label:        "Assignment",
+		notFound:     errMessage.ErrAssignmentNotFound,
+		notFoundCode: response.CodeAssignmentNotFound,
+	}
+	examKind = courseworkKind{
+		name:         "exam",
+		label:        "Exam",
+		notFound:     errMessage.ErrExamNotFound,
+		notFoundCode: response.CodeExamNotFound,
+	}
+)
+
+type ICourseworkService interface {
+	// Assignments and exams come with reminders at offsets before their deadline or start, the reminders
+	// are rebuilt from the offsets when the date changes and complete while the work is submitted.
+	CreateAssignment(ctx context.Context, userID string, payload *models.AssignmentRequest) (*models.Assignment, int)
+	GetAssignment(ctx context.Context, userID string, id int) (*models.Assignment, int)
+	GetAssignments(ctx context.Context, userID string, filter models.CourseworkFilter) ([]*models.Assignment, int)
+	UpdateAssignment(ctx context.Context, userID string, id int, payload *models.AssignmentRequest) (*models.Assignment, int)
+	UpdateAssignmentStatus(ctx context.Context, userID string, id int, payload *models.CourseworkStatusRequest) (*models.Assignment, int)
+	DeleteAssignment(ctx context.Context, userID string, id int) int
+
+	CreateExam(ctx context.Context, userID string, payload *models.ExamRequest) (*models.Exam, int)
+	GetExam(ctx context.Context, userID string, id int) (*models.Exam, int)
+	GetExams(ctx context.Context, userID string, filter models.CourseworkFilter) ([]*models.Exam, int)
+	UpdateExam(ctx context.Context, userID string, id int, payload *models.ExamRequest) (*models.Exam, int)
+	UpdateExamStatus(ctx context.Context, userID string, id int, payload *models.CourseworkStatusRequest) (*models.Exam, int)
+	DeleteExam(ctx context.Context, userID string, id int) int
+
+	// GetUpcoming lists the open assignments and exams of every course ahead, soonest first
+	GetUpcoming(ctx context.Context, userID string, query models.UpcomingQuery) ([]*models.CourseworkItem, int)
+}
+
+type CourseworkService struct {
+	assignmentRepo repo.ICourseworkRepository[models.Assignment]
+	examRepo       repo.ICourseworkRepository[models.Exam]
+	courseRepo     repo.ICourseRepository
+	assessmentRepo repo.IAssessmentRepository
+	userRepo       repo.IUserRepository
+}
+
+func NewCourseworkService(
+	assignmentRepository repo.ICourseworkRepository[models.Assignment],
+	examRepository repo.ICourseworkRepository[models.Exam],
+	courseRepository repo.ICourseRepository,
+	assessmentRepository repo.IAssessmentRepository,
+	userRepository repo.IUserRepository,
+) ICourseworkService {
+	return &CourseworkService{
+		assignmentRepo: assignmentRepository,
+		examRepo:       examRepository,
+		courseRepo:     courseRepository,
+		assessmentRepo: assessmentRepository,
+		userRepo:       userRepository,
+	}
+}
+
+func (s *CourseworkService) CreateAssignment(ctx context.Context, userID string, payload *models.AssignmentRequest) (*models.Assignment, int) {
+	dueAt, location, code := s.validateAssignment(ctx, userID, payload)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+
+	offsets := courseworkOffsets(payload.ReminderOffsets)
+	assignment := &models.Assignment{
+		CourseID:        payload.CourseID,
+		Title:           strings.TrimSpace(payload.Title),
+		Description:     payload.Description,
+		DueAt:           dueAt,
+		ComponentID:     payload.ComponentID,
+		ReminderOffsets: encodeOffsets(offsets),
+		Status:          consts.CourseworkStatus.PENDING,
+	}
+	reminders := courseworkReminders(userID, payload.CourseID, "Due: "+assignment.Title, consts.ReminderType.ASSIGNMENT, dueAt, offsets, assignment.Status, location)
+
+	if err := s.assignmentRepo.CreateCoursework(ctx, assignment, reminders); err != nil {
+		return nil, writeCourseworkError(err, userID, "Error creating assignment")
+	}
+
+	global.Log.Info("Assignment created", zap.String("userID", userID), zap.Int("assignmentID", assignment.ID), zap.Int("reminders", len(reminders)))
+	return s.GetAssignment(ctx, userID, assignment.ID)
+}
+
+func (s *CourseworkService) GetAssignment(ctx context.Context, userID string, id int) (*models.Assignment, int) {
+	return getCoursework(ctx, s.assignmentRepo, assignmentKind, userID, id)
+}
+
+func (s *CourseworkService) GetAssignments(ctx context.Context, userID string, filter models.CourseworkFilter) ([]*models.Assignment, int) {
+	return listCoursework(ctx, s.assignmentRepo, assignmentKind, userID, filter)
+}
+
+func (s *CourseworkService) UpdateAssignment(ctx context.Context, userID string, id int, payload *models.AssignmentRequest) (*models.Assignment, int) {
+	assignment, code := s.GetAssignment(ctx, userID, id)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+	dueAt, location, code := s.validateAssignment(ctx, userID, payload)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+
+	title := strings.TrimSpace(payload.Title)
+	offsets, offsetsChanged := nextOffsets(assignment.ReminderOffsets, payload.ReminderOffsets)
+	updates := map[string]interface{}{
+		"course_id":        payload.CourseID,
+		"title":            title,
+		"description":      payload.Description,
+		"due_at":           dueAt,
+		"component_id":     payload.ComponentID,
+		"reminder_offsets": encodeOffsets(offsets),
+	}
+
+	var reminderUpdates map[int]map[string]interface{}
+	var reminders []*models.Reminder
+	if offsetsChanged || !dueAt.Equal(assignment.DueAt) {
+		reminders = courseworkReminders(userID, payload.CourseID, "Due: "+title, consts.ReminderType.ASSIGNMENT, dueAt, offsets, assignment.Status, location)
+	} else {
+		current, code := getCourseworkReminders(ctx, s.assignmentRepo, assignmentKind, userID, id)
+		if code != response.CodeSuccess {
+			return nil, code
+		}
+		reminderUpdates = followReminders(current, payload.CourseID, "Due: "+title)
+	}
+
+	if code := saveCoursework(ctx, s.assignmentRepo, assignmentKind, userID, id, updates, reminderUpdates, reminders); code != response.CodeSuccess {
+		return nil, code
+	}
+	return s.GetAssignment(ctx, userID, id)
+}
+
+func (s *CourseworkService) UpdateAssignmentStatus(ctx context.Context, userID string, id int, payload *models.CourseworkStatusRequest) (*models.Assignment, int) {
+	assignment, code := s.GetAssignment(ctx, userID, id)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+	if code := updateCourseworkStatus(ctx, s.assignmentRepo, assignmentKind, userID, id, assignment.Status, *payload.Status); code != response.CodeSuccess {
+		return nil, code
+	}
+	return s.GetAssignment(ctx, userID, id)
+}
+
+func (s *CourseworkService) DeleteAssignment(ctx context.Context, userID string, id int) int {
+	return deleteCoursework(ctx, s.assignmentRepo, assignmentKind, userID, id)
+}
+
+func (s *CourseworkService) CreateExam(ctx context.Context, userID string, payload *models.ExamRequest) (*models.Exam, int) {
+	startAt, endAt, location, code := s.validateExam(ctx, userID, payload)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+
+	offsets := courseworkOffsets(payload.ReminderOffsets)
+	exam := &models.Exam{
+		CourseID:        payload.CourseID,
+		Title:           strings.TrimSpace(payload.Title),
+		Description:     payload.Description,
+		StartAt:         startAt,
+		EndAt:           endAt,
+		Location:        trimOptional(payload.Location),
+		ComponentID:     payload.ComponentID,
+		ReminderOffsets: encodeOffsets(offsets),
+		Status:          consts.CourseworkStatus.PENDING,
+	}
+	reminders := courseworkReminders(userID, payload.CourseID, "Exam: "+exam.Title, consts.ReminderType.EXAM, startAt, offsets, exam.Status, location)
+
+	if err := s.examRepo.CreateCoursework(ctx, exam, reminders); err != nil {
+		return nil, writeCourseworkError(err, userID, "Error creating exam")
+	}
+
+	global.Log.Info("Exam created", zap.String("userID", userID), zap.Int("examID", exam.ID), zap.Int("reminders", len(reminders)))
+	return s.GetExam(ctx, userID, exam.ID)
+}
+
+func (s *CourseworkService) GetExam(ctx context.Context, userID string, id int) (*models.Exam, int) {
+	return getCoursework(ctx, s.examRepo, examKind, userID, id)
+}
+
+func (s *CourseworkService) GetExams(ctx context.Context, userID string, filter models.CourseworkFilter) ([]*models.Exam, int) {
+	return listCoursework(ctx, s.examRepo, examKind, userID, filter)
+}
+
+func (s *CourseworkService) UpdateExam(ctx context.Context, userID string, id int, payload *models.ExamRequest) (*models.Exam, int) {
+	exam, code := s.GetExam(ctx, userID, id)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+	startAt, endAt, location, code := s.validateExam(ctx, userID, payload)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+
+	title := strings.TrimSpace(payload.Title)
+	offsets, offsetsChanged := nextOffsets(exam.ReminderOffsets, payload.ReminderOffsets)
+	updates := map[string]interface{}{
+		"course_id":        payload.CourseID,
+		"title":            title,
+		"description":      payload.Description,
+		"start_at":         startAt,
+		"end_at":           endAt,
+		"location":         trimOptional(payload.Location),
+		"component_id":     payload.ComponentID,
+		"reminder_offsets": encodeOffsets(offsets),
+	}
+
+	var reminderUpdates map[int]map[string]interface{}
+	var reminders []*models.Reminder
+	if offsetsChanged || !startAt.Equal(exam.StartAt) {
+		reminders = courseworkReminders(userID, payload.CourseID, "Exam: "+title, consts.ReminderType.EXAM, startAt, offsets, exam.Status, location)
+	} else {
+		current, code := getCourseworkReminders(ctx, s.examRepo, examKind, userID, id)
+		if code != response.CodeSuccess {
+			return nil, code
+		}
+		reminderUpdates = followReminders(current, payload.CourseID, "Exam: "+title)
+	}
+
+	if code := saveCoursework(ctx, s.examRepo, examKind, userID, id, updates, reminderUpdates, reminders); code != response.CodeSuccess {
+		return nil, code
+	}
+	return s.GetExam(ctx, userID, id)
+}
+
+func (s *CourseworkService) UpdateExamStatus(ctx context.Context, userID string, id int, payload *models.CourseworkStatusRequest) (*models.Exam, int) {
+	exam, code := s.GetExam(ctx, userID, id)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+	if code := updateCourseworkStatus(ctx, s.examRepo, examKind, userID, id, exam.Status, *payload.Status); code != response.CodeSuccess {
+		return nil, code
+	}
+	return s.GetExam(ctx, userID, id)
+}
+
+func (s *CourseworkService) DeleteExam(ctx context.Context, userID string, id int) int {
+	return deleteCoursework(ctx, s.examRepo, examKind, userID, id)
+}
+
+func (s *CourseworkService) GetUpcoming(ctx context.Context, userID string, query models.UpcomingQuery) ([]*models.CourseworkItem, int) {
+	days := query.Days
+	if days == 0 {
+		days = consts.COURSEWORK_UPCOMING_DAYS
+	}
+	from := time.Now()
+	to := from.AddDate(0, 0, days)
+
+	assignments, err := s.assignmentRepo.GetUpcomingCoursework(ctx, userID, query.CourseID, from, to)
+	if err != nil {
+		global.Log.Error("Error getting upcoming assignments", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedGetCoursework
+	}
+	exams, err := s.examRepo.GetUpcomingCoursework(ctx, userID, query.CourseID, from, to)
+	if err != nil {
+		global.Log.Error("Error getting upcoming exams", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedGetCoursework
+	}
+
+	items := make([]*models.CourseworkItem, 0, len(assignments)+len(exams))
+	for _, assignment := range assignments {
+		items = append(items, &models.CourseworkItem{Type: consts.CourseworkType.ASSIGNMENT, At: assignment.DueAt, Assignment: assignment})
+	}
+	for _, exam := range exams {
+		items = append(items, &models.CourseworkItem{Type: consts.CourseworkType.EXAM, At: exam.StartAt, Exam: exam})
+	}
+	// Both lists come sorted, a stable sort keeps their order on equal times
+	sort.SliceStable(items, func(i, j int) bool { return items[i].At.Before(items[j].At) })
+	return items, response.CodeSuccess
+}
+
+// validateAssignment makes sure the course belongs to the user and reads the deadline in the user's timezone
+func (s *CourseworkService) validateAssignment(ctx context.Context, userID string, payload *models.AssignmentRequest) (time.Time, *time.Location, int) {
+	if strings.TrimSpace(payload.Title) == "" {
+		return time.Time{}, nil, response.CodeInvalidInput
+	}
+	if code := s.checkCourse(ctx, userID, payload.CourseID, payload.ComponentID); code != response.CodeSuccess {
+		return time.Time{}, nil, code
+	}
+
+	location, code := getUserLocation(ctx, s.userRepo, userID)
+	if code != response.CodeSuccess {
+		return time.Time{}, nil, code
+	}
+	dueAt, err := utils.ParseDateTimeIn(payload.DueDate, payload.DueTime, location)
+	if err != nil {
+		return time.Time{}, nil, response.CodeInvalidInput
+	}
+	return dueAt, location, response.CodeSuccess
+}
+
+// validateExam makes sure the course belongs to the user and reads the exam times in the user's timezone
+func (s *CourseworkService) validateExam(ctx context.Context, userID string, payload *models.ExamRequest) (time.Time, *time.Time, *time.Location, int) {
+	if strings.TrimSpace(payload.Title) == "" {
+		return time.Time{}, nil, nil, response.CodeInvalidInput
+	}
+	if code := s.checkCourse(ctx, userID, payload.CourseID, payload.ComponentID); code != response.CodeSuccess {
+		return time.Time{}, nil, nil, code
+	}
+
+	location, code := getUserLocation(ctx, s.userRepo, userID)
+	if code != response.CodeSuccess {
+		return time.Time{}, nil, nil, code
+	}
+	startAt, err := utils.ParseDateTimeIn(payload.Date, payload.StartTime, location)
+	if err != nil {
+		return time.Time{}, nil, nil, response.CodeInvalidInput
+	}
+	if payload.EndTime == nil {
+		return startAt, nil, location, response.CodeSuccess
+	}
+
+	endAt, err := utils.ParseDateTimeIn(payload.Date, *payload.EndTime, location)
+	if err != nil {
+		return time.Time{}, nil, nil, response.CodeInvalidInput
+	}
+	if !endAt.After(startAt) {
+		global.Log.Warn(errMessage.ErrInvalidExamTime.Error(), zap.String("userID", userID))
+		return time.Time{}, nil, nil, response.CodeInvalidExamTime
+	}
+	return startAt, &endAt, location, response.CodeSuccess
+}
+
+// checkCourse makes sure the course belongs to the user, and the assessment component, when there is one, to the course
+func (s *CourseworkService) checkCourse(ctx context.Context, userID string, courseID int, componentID *int) int {
+	if _, err := s.courseRepo.GetCourseByID(ctx, userID, courseID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Log.Warn(errMessage.ErrCourseNotFound.Error(), zap.String("userID", userID), zap.Int("courseID", courseID))
+			return response.CodeCourseNotFound
+		}
+
+		global.Log.Error("Error getting course by ID", zap.Error(err), zap.String("userID", userID))
+		return response.CodeFailedGetCourse
+	}
+	if componentID == nil {
+		return response.CodeSuccess
+	}
+
+	component, err := s.assessmentRepo.GetAssessmentByID(ctx, userID, *componentID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		global.Log.Error("Error getting assessment component by ID", zap.Error(err), zap.String("userID", userID), zap.Int("assessmentID", *componentID))
+		return response.CodeFailedGetAssessment
+	}
+	if err != nil || component.CourseID != courseID {
+		global.Log.Warn(errMessage.ErrAssessmentNotFound.Error(), zap.String("userID", userID), zap.Int("courseID", courseID), zap.Int("assessmentID", *componentID))
+		return response.CodeAssessmentNotFound
+	}
+	return response.CodeSuccess
+}
+
+// getCoursework retrieves an assignment or exam of one of the user's courses
+func getCoursework[T any](ctx context.Context, repository repo.ICourseworkRepository[T], kind courseworkKind, userID string, id int) (*T, int) {
+	item, err := repository.GetCourseworkByID(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Log.Warn(kind.notFound.Error(), zap.String("userID", userID), zap.Int(kind.name+"ID", id))
+			return nil, kind.notFoundCode
+		}
+
+		global.Log.Error("Error getting "+kind.name+" by ID", zap.Error(err), zap.String("userID", userID), zap.Int(kind.name+"ID", id))
+		return nil, response.CodeFailedGetCoursework
+	}
+	return item, response.CodeSuccess
+}
+
+// listCoursework lists the assignments or exams of the user's courses matching the filter
+func listCoursework[T any](ctx context.Context, repository repo.ICourseworkRepository[T], kind courseworkKind, userID string, filter models.CourseworkFilter) ([]*T, int) {
+	items, err := repository.GetCoursework(ctx, userID, filter)
+	if err != nil {
+		global.Log.Error("Error getting "+kind.name+"s", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedGetCoursework
+	}
+	return items, response.CodeSuccess
+}
+
+// getCourseworkReminders lists the reminders of an assignment or exam
+func getCourseworkReminders[T any](ctx context.Context, repository repo.ICourseworkRepository[T], kind courseworkKind, userID string, id int) ([]*models.Reminder, int) {
+	reminders, err := repository.GetCourseworkReminders(ctx, id)
+	if err != nil {
+		global.Log.Error("Error getting "+kind.name+" reminders", zap.Error(err), zap.String("userID", userID), zap.Int(kind.name+"ID", id))
+		return nil, response.CodeFailedGetCoursework
+	}
+	return reminders, response.CodeSuccess
+}
+
+// saveCoursework writes the updates of an assignment or exam and of its reminders, see ICourseworkRepository.UpdateCoursework
+func saveCoursework[T any](ctx context.Context, repository repo.ICourseworkRepository[T], kind courseworkKind, userID string, id int, updates map[string]interface{}, reminderUpdates map[int]map[string]interface{}, reminders []*models.Reminder) int {
+	rowsAffected, err := repository.UpdateCoursework(ctx, userID, id, updates, reminderUpdates, reminders)
+	if err != nil {
+		return writeCourseworkError(err, userID, "Error updating "+kind.name)
+	}
+	if rowsAffected == 0 {
+		global.Log.Warn(kind.notFound.Error(), zap.String("userID", userID), zap.Int(kind.name+"ID", id))
+		return kind.notFoundCode
+	}
+
+	global.Log.Info(kind.label+" updated", zap.String("userID", userID), zap.Int(kind.name+"ID", id), zap.Bool("remindersRebuilt", reminders != nil))
+	return response.CodeSuccess
+}
+
+// updateCourseworkStatus moves an assignment or exam along the status workflow and its reminders with it
+func updateCourseworkStatus[T any](ctx context.Context, repository repo.ICourseworkRepository[T], kind courseworkKind, userID string, id int, from, to int8) int {
+	if code := checkTransition(userID, from, to); code != response.CodeSuccess {
+		return code
+	}
+	reminders, code := getCourseworkReminders(ctx, repository, kind, userID, id)
+	if code != response.CodeSuccess {
+		return code
+	}
+
+	updates := map[string]interface{}{"status": to}
+	return saveCoursework(ctx, repository, kind, userID, id, updates, statusReminders(reminders, from, to), nil)
+}
+
+// deleteCoursework removes an assignment or exam of one of the user's courses
+func deleteCoursework[T any](ctx context.Context, repository repo.ICourseworkRepository[T], kind courseworkKind, userID string, id int) int {
+	rowsAffected, err := repository.DeleteCoursework(ctx, userID, id)
+	if err != nil {
+		global.Log.Error("Error deleting "+kind.name, zap.Error(err), zap.String("userID", userID), zap.Int(kind.name+"ID", id))
+		return response.CodeFailedUpdateCoursework
+	}
+	if rowsAffected == 0 {
+		global.Log.Warn(kind.notFound.Error(), zap.String("userID", userID), zap.Int(kind.name+"ID", id))
+		return kind.notFoundCode
+	}
+
+	global.Log.Info(kind.label+" deleted", zap.String("userID", userID), zap.Int(kind.name+"ID", id))
+	return response.CodeSuccess
+}
+
+// writeCourseworkError maps constraint violations of an assignment or exam write to response codes
+func writeCourseworkError(err error, userID, message string) int {
+	// The course or the assessment component can be deleted between the check and the write
+	if errors.Is(err, gorm.ErrForeignKeyViolated) {
+		global.Log.Warn(errMessage.ErrCourseNotFound.Error(), zap.String("userID", userID))
+		return response.CodeCourseNotFound
+	}
+
+	global.Log.Error(message, zap.Error(err), zap.String("userID", userID))
+	return response.CodeFailedUpdateCoursework
+}
+
+// checkTransition makes sure the status workflow allows moving from one status to the other
+func checkTransition(userID string, from, to int8) int {
+	if from == to {
+		return response.CodeSuccess
+	}
+	for _, allowed := range courseworkTransitions[from] {
+		if allowed == to {
+			return response.CodeSuccess
+		}
+	}
+
+	global.Log.Warn(errMessage.ErrInvalidStatusTransition.Error(), zap.String("userID", userID), zap.Int8("from", from), zap.Int8("to", to))
+	return response.CodeInvalidStatusTransition
+}
+
+// courseworkDone tells whether the work is handed in, its reminders are then completed
+func courseworkDone(status int8) bool {
+	return status == consts.CourseworkStatus.SUBMITTED || status == consts.CourseworkStatus.GRADED
+}
+
+// courseworkOffsets returns the requested reminder offsets in minutes, else the configured ones
+func courseworkOffsets(minutes []int) []int {
+	if minutes != nil {
+		return minutes
+	}
+
+	offsets := global.Config.Reminder.Coursework
+	if len(offsets) == 0 {
+		offsets = consts.DEFAULT_COURSEWORK_REMINDER_OFFSETS
+	}
+	minutes = make([]int, 0, len(offsets))
+	for _, offset := range offsets {
+		minutes = append(minutes, int(offset/time.Minute))
+	}
+	return minutes
+}
+
+// encodeOffsets stores reminder offsets as a JSON array
+func encodeOffsets(minutes []int) sql.NullString {
+	data, err := json.Marshal(minutes)
+	if err != nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: string(data), Valid: true}
+}
+
+// decodeOffsets reads stored reminder offsets, the configured ones when none were stored
+func decodeOffsets(stored sql.NullString) []int {
+	var minutes []int
+	if !stored.Valid || json.Unmarshal([]byte(stored.String), &minutes) != nil {
+		return courseworkOffsets(nil)
+	}
+	return courseworkOffsets(minutes)
+}
+
+// nextOffsets returns the reminder offsets after an update, the stored ones unless new ones are requested,
+// and whether they changed
+func nextOffsets(stored sql.NullString, requested []int) ([]int, bool) {
+	current := decodeOffsets(stored)
+	if requested == nil {
+		return current, false
+	}
+	return requested, !slices.Equal(current, requested)
+}
+
+// courseworkReminders builds the reminders due offsets minutes before at, skipping the ones already past.
+// The reminders come completed when the work is already handed in.
+func courseworkReminders(userID string, courseID int, title string, reminderType int8, at time.Time, offsets []int, status int8, location *time.Location) []*models.Reminder {
+	now := time.Now()
+	seen := make(map[int]bool, len(offsets))
+	reminders := make([]*models.Reminder, 0, len(offsets))
+	for _, offset := range offsets {
+		dueAt := at.Add(-time.Duration(offset) * time.Minute)
+		if seen[offset] || !dueAt.After(now) {
+			continue
+		}
+		seen[offset] = true
+
+		local := dueAt.In(location)
+		reminder := &models.Reminder{
+			Title:    title,
+			DueDate:  time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC),
+			DueTime:  local.Format("15:04:05"),
+			DueAt:    dueAt,
+			UserID:   userID,
+			CourseID: &courseID,
+			Type:     reminderType,
+			Status:   consts.ReminderStatus.PENDING,
+		}
+		if courseworkDone(status) {
+			reminder.Status = consts.ReminderStatus.COMPLETED
+			reminder.CompletedAt = &now
+		}
+		reminders = append(reminders, reminder)
+	}
+	return reminders
+}
+
+// followReminders moves the reminders of an assignment or exam onto its course and title when they changed
+func followReminders(reminders []*models.Reminder, courseID int, title string) map[int]map[string]interface{} {
+	updates := make(map[int]map[string]interface{}, len(reminders))
+	for _, reminder := range reminders {
+		if reminder.CourseID != nil && *reminder.CourseID == courseID && reminder.Title == title {
+			continue
+		}
+		updates[reminder.ID] = map[string]interface{}{"course_id": courseID, "title": title}
+	}
+	return updates
+}
+
+// statusReminders completes the open reminders of an assignment or exam once it is submitted or graded,
+// and reopens its completed ones when it goes back to pending or in progress
+func statusReminders(reminders []*models.Reminder, from, to int8) map[int]map[string]interface{} {
+	if courseworkDone(from) == courseworkDone(to) {
+		return nil
+	}
+
+	now := time.Now()
+	updates := make(map[int]map[string]interface{}, len(reminders))
+	for _, reminder := range reminders {
+		switch {
+		case courseworkDone(to) && reminder.Status != consts.ReminderStatus.COMPLETED:
+			updates[reminder.ID] = map[string]interface{}{"status": consts.ReminderStatus.COMPLETED, "completed_at": now}
+		case !courseworkDone(to) && reminder.Status == consts.ReminderStatus.COMPLETED:
+			updates[reminder.ID] = map[string]interface{}{"status": openReminderStatus(reminder.DueAt), "completed_at": nil}
+		}
+	}
+	return updates
+}
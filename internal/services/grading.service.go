@@ -0,0 +1,1132 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/consts"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	repo "github.com/nas03/scholar-ai/backend/internal/repositories"
+	errMessage "github.com/nas03/scholar-ai/backend/pkg/errors"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Grades of pass-fail courses, compared case-insensitively
+var (
+	passGrades = map[string]bool{"P": true, "PASS": true, "S": true, "SAT": true}
+	failGrades = map[string]bool{"F": true, "FAIL": true, "U": true, "NP": true}
+)
+
+type IGradingService interface {
+	// Grading scales of the user, or the system scales when userID is empty.
+	// Users see the system scales next to their own but only change their own.
+	CreateGradingScale(ctx context.Context, userID string, payload *models.GradingScaleRequest) (*models.GradingScale, int)
+	GetGradingScale(ctx context.Context, userID string, id int) (*models.GradingScale, int)
+	GetGradingScales(ctx context.Context, userID string) ([]*models.GradingScale, int)
+	UpdateGradingScale(ctx context.Context, userID string, id int, payload *models.GradingScaleRequest) (*models.GradingScale, int)
+	DeleteGradingScale(ctx context.Context, userID string, id int) int
+
+	// GetGpaSetting returns the user's GPA settings, the defaults when none are saved
+	GetGpaSetting(ctx context.Context, userID string) (*models.GpaSetting, int)
+	UpdateGpaSetting(ctx context.Context, userID string, payload *models.GpaSettingRequest) (*models.GpaSetting, int)
+
+	// CheckGrade makes sure scaleID, when set, is a scale the user can pick and the grade is valid
+	// on the scale the course grades with
+	CheckGrade(ctx context.Context, userID string, scaleID *int, grade *string, passFail bool) int
+
+	// GetCourseGradingScale returns the scale the course grades with
+	GetCourseGradingScale(ctx context.Context, userID string, course *models.Course) (*models.GradingScale, int)
+
+	// GetGPA computes the semester and cumulative GPA and credit totals of the user's courses,
+	// optionally grading ungraded courses with the projection of their assessment components
+	GetGPA(ctx context.Context, userID string, query models.GpaQuery) (*models.GpaReport, int)
+
+	// PlanTargetGPA works out the grades the planned courses need for the cumulative GPA to reach the target
+	PlanTargetGPA(ctx context.Context, userID string, payload *models.GpaTargetRequest) (*models.GpaTargetPlan, int)
+
+	// SimulateGPA computes the GPA report with hypothetical grades, without saving them
+	SimulateGPA(ctx context.Context, userID string, payload *models.GpaWhatIfRequest) (*models.GpaWhatIf, int)
+}
+
+type GradingService struct {
+	gradingScaleRepo repo.IGradingScaleRepository
+	courseRepo       repo.ICourseRepository
+	assessmentRepo   repo.IAssessmentRepository
+	userRepo         repo.IUserRepository
+	semesterService  ISemesterService
+}
+
+func NewGradingService(
+	gradingScaleRepository repo.IGradingScaleRepository,
+	courseRepository repo.ICourseRepository,
+	assessmentRepository repo.IAssessmentRepository,
+	userRepository repo.IUserRepository,
+	semesterService ISemesterService,
+) IGradingService {
+	return &GradingService{
+		gradingScaleRepo: gradingScaleRepository,
+		courseRepo:       courseRepository,
+		assessmentRepo:   assessmentRepository,
+		userRepo:         userRepository,
+		semesterService:  semesterService,
+	}
+}
+
+// grading is what grading the courses of a user needs
+type grading struct {
+	setting *models.GpaSetting
+	scale   *models.GradingScale         // the user's scale, the GPA is expressed on it
+	scales  map[int]*models.GradingScale // course overrides by id
+}
+
+// scaleOf returns the scale the course grades with
+func (g *grading) scaleOf(course *models.Course) *models.GradingScale {
+	if course.GradingScaleID != nil && g.scales[*course.GradingScaleID] != nil {
+		return g.scales[*course.GradingScaleID]
+	}
+	return g.scale
+}
+
+// gradeValue is what a raw grade is worth on a scale
+type gradeValue struct {
+	letter string
+	points float64
+	passed bool
+}
+
+func (s *GradingService) CreateGradingScale(ctx context.Context, userID string, payload *models.GradingScaleRequest) (*models.GradingScale, int) {
+	bands, code := validateGradingScale(userID, payload)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+
+	scale := &models.GradingScale{
+		Name:      strings.TrimSpace(payload.Name),
+		Type:      payload.Type,
+		MaxPoints: payload.MaxPoints,
+		MaxScore:  payload.MaxScore,
+		PassScore: payload.PassScore,
+		Bands:     bands,
+	}
+	if userID != "" {
+		scale.UserID = &userID
+	} else {
+		scale.University = trimOptional(payload.University)
+		scale.IsDefault = boolFlag(payload.IsDefault)
+	}
+
+	if err := s.gradingScaleRepo.CreateGradingScale(ctx, scale); err != nil {
+		global.Log.Error("Error creating grading scale", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedUpdateGradingScale
+	}
+	if code := s.keepSingleDefault(ctx, scale); code != response.CodeSuccess {
+		return nil, code
+	}
+
+	global.Log.Info("Grading scale created", zap.String("userID", userID), zap.Int("gradingScaleID", scale.ID))
+	return s.GetGradingScale(ctx, userID, scale.ID)
+}
+
+func (s *GradingService) GetGradingScale(ctx context.Context, userID string, id int) (*models.GradingScale, int) {
+	scale, err := s.gradingScaleRepo.GetGradingScaleByID(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Log.Warn(errMessage.ErrGradingScaleNotFound.Error(), zap.String("userID", userID), zap.Int("gradingScaleID", id))
+			return nil, response.CodeGradingScaleNotFound
+		}
+
+		global.Log.Error("Error getting grading scale by ID", zap.Error(err), zap.String("userID", userID), zap.Int("gradingScaleID", id))
+		return nil, response.CodeFailedGetGradingScale
+	}
+	return scale, response.CodeSuccess
+}
+
+func (s *GradingService) GetGradingScales(ctx context.Context, userID string) ([]*models.GradingScale, int) {
+	scales, err := s.gradingScaleRepo.GetGradingScales(ctx, userID)
+	if err != nil {
+		global.Log.Error("Error getting grading scales", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedGetGradingScale
+	}
+	return scales, response.CodeSuccess
+}
+
+func (s *GradingService) UpdateGradingScale(ctx context.Context, userID string, id int, payload *models.GradingScaleRequest) (*models.GradingScale, int) {
+	bands, code := validateGradingScale(userID, payload)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+
+	updates := map[string]interface{}{
+		"name":       strings.TrimSpace(payload.Name),
+		"type":       payload.Type,
+		"max_points": payload.MaxPoints,
+		"max_score":  payload.MaxScore,
+		"pass_score": payload.PassScore,
+	}
+	if userID == "" {
+		updates["university"] = trimOptional(payload.University)
+		updates["is_default"] = boolFlag(payload.IsDefault)
+	}
+
+	rowsAffected, err := s.gradingScaleRepo.UpdateGradingScale(ctx, userID, id, updates, bands)
+	if err != nil {
+		global.Log.Error("Error updating grading scale", zap.Error(err), zap.String("userID", userID), zap.Int("gradingScaleID", id))
+		return nil, response.CodeFailedUpdateGradingScale
+	}
+	if rowsAffected == 0 {
+		global.Log.Warn(errMessage.ErrGradingScaleNotFound.Error(), zap.String("userID", userID), zap.Int("gradingScaleID", id))
+		return nil, response.CodeGradingScaleNotFound
+	}
+
+	scale, code := s.GetGradingScale(ctx, userID, id)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+	if code := s.keepSingleDefault(ctx, scale); code != response.CodeSuccess {
+		return nil, code
+	}
+
+	global.Log.Info("Grading scale updated", zap.String("userID", userID), zap.Int("gradingScaleID", id))
+	return scale, response.CodeSuccess
+}
+
+func (s *GradingService) DeleteGradingScale(ctx context.Context, userID string, id int) int {
+	rowsAffected, err := s.gradingScaleRepo.DeleteGradingScale(ctx, userID, id)
+	if err != nil {
+		global.Log.Error("Error deleting grading scale", zap.Error(err), zap.String("userID", userID), zap.Int("gradingScaleID", id))
+		return response.CodeFailedUpdateGradingScale
+	}
+	if rowsAffected == 0 {
+		global.Log.Warn(errMessage.ErrGradingScaleNotFound.Error(), zap.String("userID", userID), zap.Int("gradingScaleID", id))
+		return response.CodeGradingScaleNotFound
+	}
+
+	global.Log.Info("Grading scale deleted", zap.String("userID", userID), zap.Int("gradingScaleID", id))
+	return response.CodeSuccess
+}
+
+func (s *GradingService) GetGpaSetting(ctx context.Context, userID string) (*models.GpaSetting, int) {
+	setting, err := s.gradingScaleRepo.GetGpaSetting(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &models.GpaSetting{UserID: userID, RetakePolicy: consts.RetakePolicy.LATEST}, response.CodeSuccess
+		}
+
+		global.Log.Error("Error getting GPA settings", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedGetGPA
+	}
+	return setting, response.CodeSuccess
+}
+
+func (s *GradingService) UpdateGpaSetting(ctx context.Context, userID string, payload *models.GpaSettingRequest) (*models.GpaSetting, int) {
+	if payload.GradingScaleID != nil {
+		if _, code := s.GetGradingScale(ctx, userID, *payload.GradingScaleID); code != response.CodeSuccess {
+			return nil, code
+		}
+	}
+
+	setting := &models.GpaSetting{
+		UserID:         userID,
+		GradingScaleID: payload.GradingScaleID,
+		RetakePolicy:   payload.RetakePolicy,
+	}
+	if setting.RetakePolicy == "" {
+		setting.RetakePolicy = consts.RetakePolicy.LATEST
+	}
+
+	if err := s.gradingScaleRepo.UpsertGpaSetting(ctx, setting); err != nil {
+		// The scale can be deleted between the check and the write
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			global.Log.Warn(errMessage.ErrGradingScaleNotFound.Error(), zap.String("userID", userID))
+			return nil, response.CodeGradingScaleNotFound
+		}
+
+		global.Log.Error("Error saving GPA settings", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedUpdateGradingScale
+	}
+
+	global.Log.Info("GPA settings updated", zap.String("userID", userID))
+	return s.GetGpaSetting(ctx, userID)
+}
+
+func (s *GradingService) CheckGrade(ctx context.Context, userID string, scaleID *int, grade *string, passFail bool) int {
+	var scale *models.GradingScale
+	if scaleID != nil {
+		var code int
+		if scale, code = s.GetGradingScale(ctx, userID, *scaleID); code != response.CodeSuccess {
+			return code
+		}
+	}
+
+	if grade == nil || strings.TrimSpace(*grade) == "" {
+		return response.CodeSuccess
+	}
+	if passFail && isPassFailGrade(*grade) {
+		return response.CodeSuccess
+	}
+
+	if scale == nil {
+		grading, code := s.loadGrading(ctx, userID, nil)
+		if code != response.CodeSuccess {
+			return code
+		}
+		scale = grading.scale
+	}
+
+	if _, ok := evaluateGrade(scale, *grade); !ok {
+		global.Log.Warn(errMessage.ErrInvalidGrade.Error(), zap.String("userID", userID), zap.String("grade", *grade), zap.Int("gradingScaleID", scale.ID))
+		return response.CodeInvalidGrade
+	}
+	return response.CodeSuccess
+}
+
+func (s *GradingService) GetCourseGradingScale(ctx context.Context, userID string, course *models.Course) (*models.GradingScale, int) {
+	var scaleIDs []int
+	if course.GradingScaleID != nil {
+		scaleIDs = append(scaleIDs, *course.GradingScaleID)
+	}
+
+	grading, code := s.loadGrading(ctx, userID, scaleIDs)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+	return grading.scaleOf(course), response.CodeSuccess
+}
+
+func (s *GradingService) GetGPA(ctx context.Context, userID string, query models.GpaQuery) (*models.GpaReport, int) {
+	courses, grading, code := s.loadCourses(ctx, userID)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+	if !query.Projected {
+		return computeGPA(courses, grading), response.CodeSuccess
+	}
+
+	courses, projected, code := s.projectCourses(ctx, userID, courses, grading)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+
+	report := computeGPA(courses, grading)
+	for _, semester := range report.Semesters {
+		for _, grade := range semester.Courses {
+			grade.Projected = projected[grade.CourseID]
+		}
+	}
+	return report, response.CodeSuccess
+}
+
+// projectCourses grades copies of the ungraded courses of the current semester with the projection of their
+// assessment components, returning the courses and which of them were projected. Ungraded courses of other
+// semesters stay ungraded, a past one was dropped or never graded and a future one has nothing to project yet.
+func (s *GradingService) projectCourses(ctx context.Context, userID string, courses []*models.Course, grading *grading) ([]*models.Course, map[int]bool, int) {
+	projected := make(map[int]bool)
+	semester, code := s.semesterService.GetCurrentSemester(ctx, userID, "")
+	if code == response.CodeSemesterNotFound {
+		return courses, projected, response.CodeSuccess
+	}
+	if code != response.CodeSuccess {
+		return nil, nil, code
+	}
+
+	var ungradedIDs []int
+	for _, course := range courses {
+		if course.SemesterID == semester.ID && (course.Grade == nil || strings.TrimSpace(*course.Grade) == "") {
+			ungradedIDs = append(ungradedIDs, course.ID)
+		}
+	}
+	if len(ungradedIDs) == 0 {
+		return courses, projected, response.CodeSuccess
+	}
+
+	components, err := s.assessmentRepo.GetAssessments(ctx, userID, ungradedIDs)
+	if err != nil {
+		global.Log.Error("Error getting assessment components", zap.Error(err), zap.String("userID", userID))
+		return nil, nil, response.CodeFailedGetAssessment
+	}
+	componentsByCourse := make(map[int][]*models.AssessmentComponent)
+	for _, component := range components {
+		componentsByCourse[component.CourseID] = append(componentsByCourse[component.CourseID], component)
+	}
+
+	result := make([]*models.Course, 0, len(courses))
+	for _, course := range courses {
+		if components := componentsByCourse[course.ID]; len(components) > 0 {
+			if assessment := assessCourse(course.ID, components, grading.scaleOf(course)); assessment.ProjectedGrade != nil {
+				graded := *course
+				graded.Grade = assessment.ProjectedGrade
+				course = &graded
+				projected[course.ID] = true
+			}
+		}
+		result = append(result, course)
+	}
+	return result, projected, response.CodeSuccess
+}
+
+// PlanTargetGPA plans without projections, the planned courses are the ones the plan finds grades for
+// and a projection of another ungraded course would be a grade the plan takes for granted
+func (s *GradingService) PlanTargetGPA(ctx context.Context, userID string, payload *models.GpaTargetRequest) (*models.GpaTargetPlan, int) {
+	courses, grading, code := s.loadCourses(ctx, userID)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+
+	// The planned courses are the ones asked for, else the ungraded ones of the current semester
+	planned := make(map[int]bool)
+	if len(payload.CourseIDs) > 0 {
+		for _, id := range payload.CourseIDs {
+			planned[id] = true
+		}
+		found := 0
+		for _, course := range courses {
+			if planned[course.ID] {
+				found++
+			}
+		}
+		if found != len(planned) {
+			global.Log.Warn(errMessage.ErrCourseNotFound.Error(), zap.String("userID", userID), zap.Ints("courseIDs", payload.CourseIDs))
+			return nil, response.CodeCourseNotFound
+		}
+	} else {
+		semester, code := s.semesterService.GetCurrentSemester(ctx, userID, "")
+		if code != response.CodeSuccess {
+			return nil, code
+		}
+		for _, course := range courses {
+			if course.SemesterID == semester.ID && (course.Grade == nil || strings.TrimSpace(*course.Grade) == "") {
+				planned[course.ID] = true
+			}
+		}
+	}
+
+	return planTarget(courses, planned, grading, payload.TargetGPA), response.CodeSuccess
+}
+
+func (s *GradingService) SimulateGPA(ctx context.Context, userID string, payload *models.GpaWhatIfRequest) (*models.GpaWhatIf, int) {
+	courses, grading, code := s.loadCourses(ctx, userID)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+
+	// Simulated grades go on top of the projections like GetGPA shows them, the current GPA has none
+	base, projected := courses, map[int]bool{}
+	if payload.Projected {
+		if base, projected, code = s.projectCourses(ctx, userID, courses, grading); code != response.CodeSuccess {
+			return nil, code
+		}
+	}
+
+	overrides := make(map[int]*models.WhatIfGrade, len(payload.Grades))
+	for i := range payload.Grades {
+		overrides[payload.Grades[i].CourseID] = &payload.Grades[i]
+	}
+
+	// Courses are copied, the loaded ones keep their grades for the current GPA
+	simulated := make([]*models.Course, 0, len(base))
+	for _, course := range base {
+		override := overrides[course.ID]
+		if override == nil {
+			simulated = append(simulated, course)
+			continue
+		}
+		delete(overrides, course.ID)
+		delete(projected, course.ID)
+
+		hypothetical := *course
+		hypothetical.Grade = trimOptional(override.Grade)
+		if override.IsPassFail != nil {
+			hypothetical.IsPassFail = boolFlag(*override.IsPassFail)
+		}
+		if override.ExcludeFromGPA != nil {
+			hypothetical.ExcludeFromGPA = boolFlag(*override.ExcludeFromGPA)
+		}
+
+		if grade := hypothetical.Grade; grade != nil && !(hypothetical.IsPassFail == 1 && isPassFailGrade(*grade)) {
+			if _, ok := evaluateGrade(grading.scaleOf(&hypothetical), *grade); !ok {
+				global.Log.Warn(errMessage.ErrInvalidGrade.Error(), zap.String("userID", userID), zap.Int("courseID", course.ID), zap.String("grade", *grade))
+				return nil, response.CodeInvalidGrade
+			}
+		}
+		simulated = append(simulated, &hypothetical)
+	}
+	if len(overrides) > 0 {
+		global.Log.Warn(errMessage.ErrCourseNotFound.Error(), zap.String("userID", userID))
+		return nil, response.CodeCourseNotFound
+	}
+
+	report := computeGPA(simulated, grading)
+	for _, semester := range report.Semesters {
+		for _, grade := range semester.Courses {
+			grade.Projected = projected[grade.CourseID]
+		}
+	}
+	return &models.GpaWhatIf{
+		CurrentGPA: computeGPA(courses, grading).GPA,
+		Report:     report,
+	}, response.CodeSuccess
+}
+
+// loadCourses loads the courses of the user and what grading them needs
+func (s *GradingService) loadCourses(ctx context.Context, userID string) ([]*models.Course, *grading, int) {
+	courses, err := s.courseRepo.GetCourses(ctx, userID, models.CourseFilter{})
+	if err != nil {
+		global.Log.Error("Error getting courses", zap.Error(err), zap.String("userID", userID))
+		return nil, nil, response.CodeFailedGetCourse
+	}
+
+	var scaleIDs []int
+	for _, course := range courses {
+		if course.GradingScaleID != nil {
+			scaleIDs = append(scaleIDs, *course.GradingScaleID)
+		}
+	}
+
+	grading, code := s.loadGrading(ctx, userID, uniqueIDs(scaleIDs))
+	if code != response.CodeSuccess {
+		return nil, nil, code
+	}
+	return courses, grading, response.CodeSuccess
+}
+
+// loadGrading loads the user's settings and scale, and the given course scales.
+// The user's scale is the one picked in the settings, else the default of the user's university or the system.
+func (s *GradingService) loadGrading(ctx context.Context, userID string, scaleIDs []int) (*grading, int) {
+	setting, code := s.GetGpaSetting(ctx, userID)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+
+	var scale *models.GradingScale
+	if setting.GradingScaleID != nil {
+		scale, code = s.GetGradingScale(ctx, userID, *setting.GradingScaleID)
+		if code != response.CodeSuccess {
+			return nil, code
+		}
+	} else {
+		user, err := s.userRepo.GetUserByID(ctx, userID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				global.Log.Warn(errMessage.ErrUserNotFound.Error(), zap.String("userID", userID))
+				return nil, response.CodeUserNotFound
+			}
+
+			global.Log.Error("Error getting user by ID", zap.Error(err), zap.String("userID", userID))
+			return nil, response.CodeFailedGetUser
+		}
+
+		scale, err = s.gradingScaleRepo.GetDefaultGradingScale(ctx, user.University.String)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				global.Log.Warn(errMessage.ErrGradingScaleNotFound.Error(), zap.String("userID", userID), zap.String("university", user.University.String))
+				return nil, response.CodeGradingScaleNotFound
+			}
+
+			global.Log.Error("Error getting default grading scale", zap.Error(err), zap.String("userID", userID))
+			return nil, response.CodeFailedGetGradingScale
+		}
+	}
+
+	result := &grading{setting: setting, scale: scale, scales: map[int]*models.GradingScale{scale.ID: scale}}
+	if len(scaleIDs) == 0 {
+		return result, response.CodeSuccess
+	}
+
+	scales, err := s.gradingScaleRepo.GetGradingScalesByIDs(ctx, userID, scaleIDs)
+	if err != nil {
+		global.Log.Error("Error getting grading scales", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedGetGradingScale
+	}
+	for _, scale := range scales {
+		result.scales[scale.ID] = scale
+	}
+	return result, response.CodeSuccess
+}
+
+// keepSingleDefault unmarks the previous system default when scale became the default
+func (s *GradingService) keepSingleDefault(ctx context.Context, scale *models.GradingScale) int {
+	if scale.UserID != nil || scale.IsDefault == 0 {
+		return response.CodeSuccess
+	}
+
+	if err := s.gradingScaleRepo.ClearDefaultGradingScale(ctx, scale.ID); err != nil {
+		global.Log.Error("Error clearing default grading scale", zap.Error(err), zap.Int("gradingScaleID", scale.ID))
+		return response.CodeFailedUpdateGradingScale
+	}
+	return response.CodeSuccess
+}
+
+// computeGPA grades every course. Semester GPAs show each semester as graded, the cumulative GPA
+// counts one attempt of a retaken course, picked by the retake policy.
+func computeGPA(courses []*models.Course, grading *grading) *models.GpaReport {
+	report := &models.GpaReport{
+		Scale:        grading.scale,
+		RetakePolicy: grading.setting.RetakePolicy,
+		Semesters:    []*models.SemesterGpa{},
+	}
+
+	semesters := make(map[int]*models.SemesterGpa)
+	attempts := make(map[string][]*models.Course) // counted attempts by course code
+	grades := make(map[int]*models.CourseGrade, len(courses))
+	for _, course := range courses {
+		grade := gradeCourse(course, grading)
+		grades[course.ID] = grade
+		if grade.Status == consts.CourseGradeStatus.COUNTED {
+			code := normalizeCourseCode(course.CourseID)
+			attempts[code] = append(attempts[code], course)
+		}
+
+		semester := semesters[course.SemesterID]
+		if semester == nil {
+			semesterOfCourse := course.Semester
+			semester = &models.SemesterGpa{Semester: &semesterOfCourse, Courses: []*models.CourseGrade{}}
+			semesters[course.SemesterID] = semester
+			report.Semesters = append(report.Semesters, semester)
+		}
+		semester.Courses = append(semester.Courses, grade)
+	}
+
+	// Every attempt but the one kept is superseded
+	for _, group := range attempts {
+		kept := group[0]
+		for _, course := range group[1:] {
+			if keepAttempt(course, kept, grades, grading.setting.RetakePolicy) {
+				kept = course
+			}
+		}
+		for _, course := range group {
+			if course != kept {
+				grades[course.ID].Status = consts.CourseGradeStatus.SUPERSEDED
+			}
+		}
+	}
+
+	sort.SliceStable(report.Semesters, func(i, j int) bool {
+		a, b := report.Semesters[i].Semester, report.Semesters[j].Semester
+		if !a.StartDate.Equal(b.StartDate) {
+			return a.StartDate.Before(b.StartDate)
+		}
+		return a.ID < b.ID
+	})
+
+	var points, semesterPoints float64
+	for _, semester := range report.Semesters {
+		semesterPoints = 0
+		for _, grade := range semester.Courses {
+			if grade.Status == consts.CourseGradeStatus.UNGRADED || grade.Status == consts.CourseGradeStatus.INVALID {
+				continue
+			}
+
+			semester.Credits.Attempted += grade.Credits
+			report.Credits.Attempted += grade.Credits
+			passed := grade.Passed != nil && *grade.Passed
+			if passed {
+				semester.Credits.Earned += grade.Credits
+			}
+
+			switch grade.Status {
+			case consts.CourseGradeStatus.COUNTED:
+				report.Credits.GPA += grade.Credits
+				points += *grade.Points * float64(grade.Credits)
+				fallthrough
+			case consts.CourseGradeStatus.SUPERSEDED:
+				semester.Credits.GPA += grade.Credits
+				semesterPoints += *grade.Points * float64(grade.Credits)
+			}
+			if passed && grade.Status != consts.CourseGradeStatus.SUPERSEDED {
+				report.Credits.Earned += grade.Credits
+			}
+		}
+		semester.GPA = averagePoints(semesterPoints, semester.Credits.GPA)
+	}
+	report.GPA = averagePoints(points, report.Credits.GPA)
+	return report
+}
+
+// planTarget works out what the planned courses need for the cumulative GPA to reach target.
+// The grades the planned courses already have are ignored.
+func planTarget(courses []*models.Course, planned map[int]bool, grading *grading, target float64) *models.GpaTargetPlan {
+	plan := &models.GpaTargetPlan{
+		Scale:      grading.scale,
+		TargetGPA:  target,
+		CurrentGPA: computeGPA(courses, grading).GPA,
+		Courses:    []*models.PlannedCourse{},
+	}
+
+	base := make([]*models.Course, 0, len(courses))
+	for _, course := range courses {
+		if planned[course.ID] {
+			ungraded := *course
+			ungraded.Grade = nil
+			course = &ungraded
+		}
+		base = append(base, course)
+	}
+	report := computeGPA(base, grading)
+
+	grades := make(map[int]*models.CourseGrade, len(base))
+	for _, semester := range report.Semesters {
+		for _, grade := range semester.Courses {
+			grades[grade.CourseID] = grade
+		}
+	}
+
+	tp := &targetPlan{policy: grading.setting.RetakePolicy}
+	attempts := make(map[string]*models.Course) // counted attempts by course code
+	for _, course := range base {
+		if grade := grades[course.ID]; grade.Status == consts.CourseGradeStatus.COUNTED {
+			attempts[normalizeCourseCode(course.CourseID)] = course
+			tp.points += *grade.Points * float64(grade.Credits)
+			tp.credits += grade.Credits
+		}
+	}
+
+	for _, course := range base {
+		if !planned[course.ID] || course.IsPassFail == 1 || course.ExcludeFromGPA == 1 || course.Credits == 0 {
+			continue
+		}
+
+		pc := &plannedCourse{course: course, scale: grading.scaleOf(course)}
+		if attempt := attempts[normalizeCourseCode(course.CourseID)]; attempt != nil {
+			// An earlier semester planned under the latest attempt policy never counts
+			if tp.policy == consts.RetakePolicy.LATEST && !keepAttempt(course, attempt, nil, tp.policy) {
+				continue
+			}
+			pc.attempt, pc.retake = attempt, grades[attempt.ID]
+			delete(attempts, normalizeCourseCode(course.CourseID))
+		}
+		if pc.scale.Type == consts.GradingScaleType.BANDS {
+			pc.options = gradeOptions(pc.scale, grading.scale.MaxPoints)
+		}
+		tp.courses = append(tp.courses, pc)
+	}
+
+	if len(tp.courses) == 0 {
+		plan.Feasible = report.GPA != nil && *report.GPA >= target
+		return plan
+	}
+
+	average := tp.requiredAverage(target)
+	plan.RequiredAverage = &average
+
+	// Each planned course can do no better than the top grade of its own scale
+	best := make([]float64, len(tp.courses))
+	for i, pc := range tp.courses {
+		best[i] = pc.best(grading.scale.MaxPoints)
+	}
+	plan.Feasible = tp.reaches(best, target)
+
+	for _, pc := range tp.courses {
+		plan.Courses = append(plan.Courses, &models.PlannedCourse{
+			CourseID:     pc.course.ID,
+			Code:         pc.course.CourseID,
+			Name:         pc.course.CourseName,
+			Credits:      pc.course.Credits,
+			MinimumGrade: pc.minimumGrade(average, grading.scale.MaxPoints),
+		})
+	}
+	if plan.Feasible {
+		plan.Combinations = tp.combinations(target)
+	}
+	return plan
+}
+
+// targetPlan computes the cumulative GPA for grades of the planned courses
+type targetPlan struct {
+	courses []*plannedCourse
+	points  float64 // grade points and GPA credits counted without the planned courses
+	credits int
+	policy  string
+}
+
+// plannedCourse is a course of a target plan counting towards the GPA
+type plannedCourse struct {
+	course  *models.Course
+	scale   *models.GradingScale
+	options []gradeOption       // band scales only
+	attempt *models.Course      // the counted attempt of the course it retakes
+	retake  *models.CourseGrade // and its grade
+}
+
+// gradeOption is a letter and its points on the report scale
+type gradeOption struct {
+	letter string
+	points float64
+}
+
+// gpa is the cumulative GPA with the planned courses worth points, rounded like reports are
+func (p *targetPlan) gpa(points []float64) float64 {
+	return roundPoints(p.average(points))
+}
+
+// reaches reports whether the planned courses worth points bring the cumulative GPA to target
+func (p *targetPlan) reaches(points []float64, target float64) bool {
+	return p.average(points) >= target-1e-9
+}
+
+// average is the unrounded cumulative GPA with the planned courses worth points. A retaken attempt
+// the retake policy keeps, one worth more under the best attempt policy, counts instead.
+func (p *targetPlan) average(points []float64) float64 {
+	total, credits := p.points, p.credits
+	for i, pc := range p.courses {
+		if retake := pc.retake; retake != nil {
+			if !pc.replaces(points[i], p.policy) {
+				continue
+			}
+			total -= *retake.Points * float64(retake.Credits)
+			credits -= retake.Credits
+		}
+		total += points[i] * float64(pc.course.Credits)
+		credits += pc.course.Credits
+	}
+	if credits == 0 {
+		return 0
+	}
+	return total / float64(credits)
+}
+
+// requiredAverage is the lowest average, in hundredths, the planned courses need to reach target
+func (p *targetPlan) requiredAverage(target float64) float64 {
+	// Once every planned course replaces the attempt it retakes the average follows from the totals
+	points, credits, plannedCredits := p.points, p.credits, 0
+	highest := 0.0
+	for _, pc := range p.courses {
+		if pc.retake != nil {
+			points -= *pc.retake.Points * float64(pc.retake.Credits)
+			credits -= pc.retake.Credits
+			highest = math.Max(highest, *pc.retake.Points)
+		}
+		plannedCredits += pc.course.Credits
+	}
+	average := math.Max(0, math.Ceil((target*float64(credits+plannedCredits)-points)/float64(plannedCredits)*100-1e-9)/100)
+	if p.policy != consts.RetakePolicy.BEST || highest == 0 {
+		return average
+	}
+
+	// Under the best attempt policy a retaken attempt worth more keeps counting,
+	// so averages up to the best of them are tried one by one
+	uniform := make([]float64, len(p.courses))
+	for hundredths := 0; hundredths <= int(math.Round(highest*100)); hundredths++ {
+		for i := range uniform {
+			uniform[i] = float64(hundredths) / 100
+		}
+		if p.reaches(uniform, target) {
+			return uniform[0]
+		}
+	}
+	return math.Max(average, roundPoints(highest+0.01))
+}
+
+// combinations searches the letter combinations reaching target where no single grade can be lower,
+// nil when a planned course has no letters or the search is too large
+func (p *targetPlan) combinations(target float64) []*models.GradeCombination {
+	size := 1
+	for _, pc := range p.courses {
+		if len(pc.options) == 0 {
+			return nil
+		}
+		size *= len(pc.options)
+		if size > consts.GPA_TARGET_MAX_SEARCH {
+			return nil
+		}
+	}
+
+	n := len(p.courses)
+	choice := make([]int, n)
+	points := make([]float64, n)
+	combinations := []*models.GradeCombination{}
+
+	var search func(i int)
+	search = func(i int) {
+		for j, option := range p.courses[i].options {
+			if len(combinations) >= consts.GPA_TARGET_MAX_COMBINATIONS {
+				return
+			}
+			choice[i], points[i] = j, option.points
+
+			// The lowest letter of the last course reaching the target is the only minimal one
+			if i == n-1 {
+				if p.gpa(points) >= target {
+					if p.minimal(choice, points, target) {
+						combinations = append(combinations, p.combination(choice, points))
+					}
+					return
+				}
+				continue
+			}
+
+			// Skip when even the best letters of the remaining courses fall short
+			for k := i + 1; k < n; k++ {
+				options := p.courses[k].options
+				points[k] = options[len(options)-1].points
+			}
+			if p.gpa(points) >= target {
+				search(i + 1)
+			}
+		}
+	}
+	search(0)
+	return combinations
+}
+
+// minimal reports whether no planned course can go one letter lower and still reach target
+func (p *targetPlan) minimal(choice []int, points []float64, target float64) bool {
+	for i, j := range choice {
+		if j == 0 {
+			continue
+		}
+		chosen := points[i]
+		points[i] = p.courses[i].options[j-1].points
+		reaches := p.gpa(points) >= target
+		points[i] = chosen
+		if reaches {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *targetPlan) combination(choice []int, points []float64) *models.GradeCombination {
+	combination := &models.GradeCombination{Grades: make([]models.PlannedGrade, 0, len(choice)), GPA: p.gpa(points)}
+	for i, j := range choice {
+		combination.Grades = append(combination.Grades, models.PlannedGrade{
+			CourseID: p.courses[i].course.ID,
+			Grade:    p.courses[i].options[j].letter,
+		})
+	}
+	return combination
+}
+
+// replaces reports whether the course worth points counts instead of the attempt it retakes
+func (pc *plannedCourse) replaces(points float64, policy string) bool {
+	grades := map[int]*models.CourseGrade{
+		pc.course.ID:  {Points: &points},
+		pc.attempt.ID: pc.retake,
+	}
+	return keepAttempt(pc.course, pc.attempt, grades, policy)
+}
+
+// best is the most the course can be worth on the report scale, the top band of a band scale
+func (pc *plannedCourse) best(maxPoints float64) float64 {
+	if len(pc.options) > 0 {
+		return pc.options[len(pc.options)-1].points
+	}
+	return maxPoints
+}
+
+// minimumGrade is the lowest grade of the course worth average points on the report scale, nil when out of reach
+func (pc *plannedCourse) minimumGrade(average, maxPoints float64) *string {
+	if pc.scale.Type == consts.GradingScaleType.LINEAR {
+		score := math.Ceil(average/maxPoints*pc.scale.MaxScore*100-1e-9) / 100
+		if score > pc.scale.MaxScore {
+			return nil
+		}
+		grade := strconv.FormatFloat(score, 'f', -1, 64)
+		return &grade
+	}
+
+	for _, option := range pc.options {
+		if option.points >= average {
+			letter := option.letter
+			return &letter
+		}
+	}
+	return nil
+}
+
+// gradeOptions lists the letters of a band scale by points on the report scale, fewest first.
+// Of letters worth the same the lowest band is kept.
+func gradeOptions(scale *models.GradingScale, maxPoints float64) []gradeOption {
+	options := make([]gradeOption, 0, len(scale.Bands))
+	for i := len(scale.Bands) - 1; i >= 0; i-- {
+		band := scale.Bands[i]
+		options = append(options, gradeOption{letter: band.Letter, points: roundPoints(band.Points / scale.MaxPoints * maxPoints)})
+	}
+	sort.SliceStable(options, func(i, j int) bool { return options[i].points < options[j].points })
+
+	unique := options[:0]
+	for _, option := range options {
+		if len(unique) == 0 || unique[len(unique)-1].points != option.points {
+			unique = append(unique, option)
+		}
+	}
+	return unique
+}
+
+// gradeCourse works out what the grade of a course is worth, points on the user's scale
+func gradeCourse(course *models.Course, grading *grading) *models.CourseGrade {
+	grade := &models.CourseGrade{
+		CourseID: course.ID,
+		Code:     course.CourseID,
+		Name:     course.CourseName,
+		Credits:  course.Credits,
+		Grade:    course.Grade,
+		Status:   consts.CourseGradeStatus.UNGRADED,
+	}
+	if course.Grade == nil || strings.TrimSpace(*course.Grade) == "" {
+		return grade
+	}
+
+	if course.IsPassFail == 1 && isPassFailGrade(*course.Grade) {
+		passed := passGrades[strings.ToUpper(strings.TrimSpace(*course.Grade))]
+		grade.Passed = &passed
+		grade.Status = consts.CourseGradeStatus.PASS_FAIL
+		return grade
+	}
+
+	scale := grading.scaleOf(course)
+	value, ok := evaluateGrade(scale, *course.Grade)
+	if !ok {
+		grade.Status = consts.CourseGradeStatus.INVALID
+		return grade
+	}
+
+	points := roundPoints(value.points / scale.MaxPoints * grading.scale.MaxPoints)
+	grade.Letter = value.letter
+	grade.Points = &points
+	grade.Passed = &value.passed
+
+	switch {
+	case course.IsPassFail == 1:
+		grade.Points = nil
+		grade.Status = consts.CourseGradeStatus.PASS_FAIL
+	case course.ExcludeFromGPA == 1:
+		grade.Status = consts.CourseGradeStatus.EXCLUDED
+	default:
+		grade.Status = consts.CourseGradeStatus.COUNTED
+	}
+	return grade
+}
+
+// keepAttempt reports whether attempt counts instead of kept under the retake policy,
+// ties go to the latest attempt
+func keepAttempt(attempt, kept *models.Course, grades map[int]*models.CourseGrade, policy string) bool {
+	later := attempt.Semester.StartDate.After(kept.Semester.StartDate) ||
+		(attempt.Semester.StartDate.Equal(kept.Semester.StartDate) && attempt.ID > kept.ID)
+
+	if policy == consts.RetakePolicy.BEST {
+		points, keptPoints := *grades[attempt.ID].Points, *grades[kept.ID].Points
+		if points != keptPoints {
+			return points > keptPoints
+		}
+	}
+	return later
+}
+
+// evaluateGrade reads a letter or a numeric grade on a scale, false when the scale has no such grade
+func evaluateGrade(scale *models.GradingScale, grade string) (gradeValue, bool) {
+	grade = strings.TrimSpace(grade)
+	if score, err := strconv.ParseFloat(grade, 64); err == nil {
+		if math.IsNaN(score) || score < 0 || score > scale.MaxScore {
+			return gradeValue{}, false
+		}
+		if scale.Type == consts.GradingScaleType.LINEAR {
+			return gradeValue{points: score / scale.MaxScore * scale.MaxPoints, passed: score >= scale.PassScore}, true
+		}
+
+		// Bands are sorted by min score, highest first
+		for _, band := range scale.Bands {
+			if score >= band.MinScore {
+				return gradeValue{letter: band.Letter, points: band.Points, passed: band.IsPassing == 1}, true
+			}
+		}
+		return gradeValue{}, false
+	}
+
+	for _, band := range scale.Bands {
+		if strings.EqualFold(band.Letter, grade) {
+			return gradeValue{letter: band.Letter, points: band.Points, passed: band.IsPassing == 1}, true
+		}
+	}
+	return gradeValue{}, false
+}
+
+// validateGradingScale checks the ranges of a scale and builds its bands, highest first.
+// A band scale needs a band from 0 so every numeric grade falls into one.
+func validateGradingScale(userID string, payload *models.GradingScaleRequest) ([]models.GradingBand, int) {
+	invalid := func(reason string) ([]models.GradingBand, int) {
+		global.Log.Warn(errMessage.ErrInvalidGradingScale.Error(), zap.String("userID", userID), zap.String("reason", reason))
+		return nil, response.CodeInvalidGradingScale
+	}
+
+	if strings.TrimSpace(payload.Name) == "" {
+		return nil, response.CodeInvalidInput
+	}
+	if payload.MaxPoints > consts.GRADING_MAX_POINTS || payload.MaxScore > consts.GRADING_MAX_SCORE {
+		return invalid("range too large")
+	}
+
+	if payload.Type == consts.GradingScaleType.LINEAR {
+		if len(payload.Bands) > 0 {
+			return invalid("linear scales have no bands")
+		}
+		if payload.PassScore > payload.MaxScore {
+			return invalid("pass score above max score")
+		}
+		return nil, response.CodeSuccess
+	}
+
+	if len(payload.Bands) == 0 || len(payload.Bands) > consts.GRADING_MAX_BANDS {
+		return invalid("band count")
+	}
+
+	bands := make([]models.GradingBand, 0, len(payload.Bands))
+	letters := make(map[string]bool, len(payload.Bands))
+	minScores := make(map[float64]bool, len(payload.Bands))
+	for _, band := range payload.Bands {
+		letter := strings.TrimSpace(band.Letter)
+		key := strings.ToUpper(letter)
+		if _, err := strconv.ParseFloat(letter, 64); letter == "" || err == nil || letters[key] {
+			return invalid("letters must be unique and not numeric")
+		}
+		if band.MinScore > payload.MaxScore || band.Points > payload.MaxPoints || minScores[band.MinScore] {
+			return invalid("band out of range or sharing a min score")
+		}
+		letters[key] = true
+		minScores[band.MinScore] = true
+
+		passing := band.IsPassing == nil || *band.IsPassing
+		bands = append(bands, models.GradingBand{Letter: letter, MinScore: band.MinScore, Points: band.Points, IsPassing: boolFlag(passing)})
+	}
+	if !minScores[0] {
+		return invalid("no band from 0")
+	}
+
+	sort.Slice(bands, func(i, j int) bool { return bands[i].MinScore > bands[j].MinScore })
+	return bands, response.CodeSuccess
+}
+
+// isPassFailGrade reports whether a grade is one of the pass-fail grades
+func isPassFailGrade(grade string) bool {
+	grade = strings.ToUpper(strings.TrimSpace(grade))
+	return passGrades[grade] || failGrades[grade]
+}
+
+// averagePoints is the credit weighted average of points, nil without credits
+func averagePoints(points float64, credits int) *float64 {
+	if credits == 0 {
+		return nil
+	}
+	average := roundPoints(points / float64(credits))
+	return &average
+}
+
+// roundPoints rounds grade points to two decimals
+func roundPoints(points float64) float64 {
+	return math.Round(points*100) / 100
+}
+
+// boolFlag stores a bool in a tinyint flag column
+func boolFlag(value bool) int8 {
+	if value {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,362 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nas03/scholar-ai/backend/internal/consts"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	repo "github.com/nas03/scholar-ai/backend/internal/repositories"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+)
+
+var (
+	fall2025   = &models.Semester{ID: 1, StartDate: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)}
+	spring2026 = &models.Semester{ID: 2, StartDate: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)}
+	fall2026   = &models.Semester{ID: 3, StartDate: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)}
+)
+
+// testGrading grades on a 4.0 letter scale (1), with a percentage scale (2)
+// and a scale whose top band is worth 3.0 of 4.0 (3) for course overrides
+func testGrading(policy string) *grading {
+	letters := &models.GradingScale{ID: 1, Type: consts.GradingScaleType.BANDS, MaxPoints: 4, MaxScore: 10, Bands: []models.GradingBand{
+		{Letter: "A", MinScore: 8.5, Points: 4, IsPassing: 1},
+		{Letter: "B", MinScore: 7, Points: 3, IsPassing: 1},
+		{Letter: "C", MinScore: 5.5, Points: 2, IsPassing: 1},
+		{Letter: "D", MinScore: 4, Points: 1, IsPassing: 1},
+		{Letter: "F", MinScore: 0, Points: 0, IsPassing: 0},
+	}}
+	percent := &models.GradingScale{ID: 2, Type: consts.GradingScaleType.LINEAR, MaxPoints: 100, MaxScore: 100, PassScore: 50}
+	capped := &models.GradingScale{ID: 3, Type: consts.GradingScaleType.BANDS, MaxPoints: 4, MaxScore: 10, Bands: []models.GradingBand{
+		{Letter: "S", MinScore: 5, Points: 3, IsPassing: 1},
+		{Letter: "U", MinScore: 0, Points: 0, IsPassing: 0},
+	}}
+
+	return &grading{
+		setting: &models.GpaSetting{RetakePolicy: policy},
+		scale:   letters,
+		scales:  map[int]*models.GradingScale{1: letters, 2: percent, 3: capped},
+	}
+}
+
+func testCourse(id int, code string, credits int, semester *models.Semester, grade string) *models.Course {
+	course := &models.Course{ID: id, CourseID: code, CourseName: code, Credits: credits, SemesterID: semester.ID, Semester: *semester}
+	if grade != "" {
+		course.Grade = &grade
+	}
+	return course
+}
+
+func withScale(course *models.Course, scaleID int) *models.Course {
+	course.GradingScaleID = &scaleID
+	return course
+}
+
+func gradesByCourse(report *models.GpaReport) map[int]*models.CourseGrade {
+	grades := make(map[int]*models.CourseGrade)
+	for _, semester := range report.Semesters {
+		for _, grade := range semester.Courses {
+			grades[grade.CourseID] = grade
+		}
+	}
+	return grades
+}
+
+func TestComputeGPA(t *testing.T) {
+	passFail := testCourse(3, "PE100", 1, fall2025, "P")
+	passFail.IsPassFail = 1
+	excluded := testCourse(5, "HI100", 3, spring2026, "C")
+	excluded.ExcludeFromGPA = 1
+
+	courses := []*models.Course{
+		testCourse(1, "CS101", 3, fall2025, "B"),
+		testCourse(2, "MA101", 4, fall2025, "9"), // an A
+		passFail,
+		withScale(testCourse(4, "LIN200", 2, fall2025, "75"), 2), // 3.0 of 4.0
+		excluded,
+		testCourse(6, "CS 101", 3, spring2026, "D"), // retakes CS101
+		testCourse(7, "PH100", 3, fall2026, ""),
+	}
+
+	tests := []struct {
+		policy     string
+		gpa        float64
+		superseded int
+	}{
+		// MA101 4.0x4, CS 101 1.0x3, LIN200 3.0x2
+		{policy: consts.RetakePolicy.LATEST, gpa: 2.78, superseded: 1},
+		// MA101 4.0x4, CS101 3.0x3, LIN200 3.0x2
+		{policy: consts.RetakePolicy.BEST, gpa: 3.44, superseded: 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			report := computeGPA(courses, testGrading(tt.policy))
+
+			if report.GPA == nil || *report.GPA != tt.gpa {
+				t.Errorf("GPA = %v, want %v", report.GPA, tt.gpa)
+			}
+			if want := (models.GpaCredits{Attempted: 16, Earned: 13, GPA: 9}); report.Credits != want {
+				t.Errorf("Credits = %+v, want %+v", report.Credits, want)
+			}
+
+			grades := gradesByCourse(report)
+			wantStatus := map[int]string{
+				1:             consts.CourseGradeStatus.COUNTED,
+				2:             consts.CourseGradeStatus.COUNTED,
+				3:             consts.CourseGradeStatus.PASS_FAIL,
+				4:             consts.CourseGradeStatus.COUNTED,
+				5:             consts.CourseGradeStatus.EXCLUDED,
+				6:             consts.CourseGradeStatus.COUNTED,
+				7:             consts.CourseGradeStatus.UNGRADED,
+				tt.superseded: consts.CourseGradeStatus.SUPERSEDED,
+			}
+			for id, want := range wantStatus {
+				if grades[id].Status != want {
+					t.Errorf("course %d status = %s, want %s", id, grades[id].Status, want)
+				}
+			}
+			if grades[2].Letter != "A" || *grades[2].Points != 4 || *grades[4].Points != 3 {
+				t.Errorf("numeric grades = %+v, %+v", grades[2], grades[4])
+			}
+
+			// Semesters are ordered and count their superseded attempts
+			if len(report.Semesters) != 3 || report.Semesters[0].Semester.ID != fall2025.ID || report.Semesters[2].Semester.ID != fall2026.ID {
+				t.Fatalf("semesters = %+v", report.Semesters)
+			}
+			if gpa := report.Semesters[0].GPA; gpa == nil || *gpa != 3.44 {
+				t.Errorf("fall 2025 GPA = %v, want 3.44", gpa)
+			}
+			if gpa := report.Semesters[2].GPA; gpa != nil {
+				t.Errorf("ungraded semester GPA = %v, want nil", *gpa)
+			}
+		})
+	}
+}
+
+func TestKeepAttempt(t *testing.T) {
+	early := testCourse(1, "CS101", 3, fall2025, "B")
+	late := testCourse(2, "CS101", 3, spring2026, "C")
+	sameStart := testCourse(3, "CS101", 3, fall2025, "C")
+	points := func(values ...float64) map[int]*models.CourseGrade {
+		grades := make(map[int]*models.CourseGrade)
+		for i := range values {
+			grades[i+1] = &models.CourseGrade{Points: &values[i]}
+		}
+		return grades
+	}
+
+	tests := []struct {
+		name          string
+		attempt, kept *models.Course
+		grades        map[int]*models.CourseGrade
+		policy        string
+		want          bool
+	}{
+		{name: "latest keeps the later attempt", attempt: late, kept: early, grades: points(3, 2), policy: consts.RetakePolicy.LATEST, want: true},
+		{name: "latest drops the earlier attempt", attempt: early, kept: late, grades: points(3, 2), policy: consts.RetakePolicy.LATEST, want: false},
+		{name: "best keeps more points", attempt: early, kept: late, grades: points(3, 2), policy: consts.RetakePolicy.BEST, want: true},
+		{name: "best drops fewer points", attempt: late, kept: early, grades: points(3, 2), policy: consts.RetakePolicy.BEST, want: false},
+		{name: "best ties go to the later attempt", attempt: late, kept: early, grades: points(3, 3), policy: consts.RetakePolicy.BEST, want: true},
+		{name: "same semester start goes to the higher id", attempt: sameStart, kept: early, grades: points(2, 0, 2), policy: consts.RetakePolicy.BEST, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := keepAttempt(tt.attempt, tt.kept, tt.grades, tt.policy); got != tt.want {
+				t.Errorf("keepAttempt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanTarget(t *testing.T) {
+	t.Run("letter combinations", func(t *testing.T) {
+		courses := []*models.Course{
+			testCourse(1, "MA101", 4, fall2025, "A"),
+			testCourse(2, "CS101", 3, fall2025, "B"),
+			testCourse(3, "CS201", 3, fall2026, ""),
+			testCourse(4, "CS202", 3, fall2026, ""),
+		}
+		plan := planTarget(courses, map[int]bool{3: true, 4: true}, testGrading(consts.RetakePolicy.LATEST), 3.5)
+
+		// (3.5 x 13 - 25) / 6
+		if plan.RequiredAverage == nil || *plan.RequiredAverage != 3.42 || !plan.Feasible {
+			t.Fatalf("RequiredAverage = %v, Feasible = %v, want 3.42, true", plan.RequiredAverage, plan.Feasible)
+		}
+		for _, course := range plan.Courses {
+			if course.MinimumGrade == nil || *course.MinimumGrade != "A" {
+				t.Errorf("course %d minimum grade = %v, want A", course.CourseID, course.MinimumGrade)
+			}
+		}
+
+		// A and B in either order, A and A is not minimal
+		if len(plan.Combinations) != 2 {
+			t.Fatalf("Combinations = %+v", plan.Combinations)
+		}
+		for _, combination := range plan.Combinations {
+			a, b := combination.Grades[0].Grade, combination.Grades[1].Grade
+			if !(a == "A" && b == "B" || a == "B" && b == "A") || combination.GPA != 3.54 {
+				t.Errorf("combination = %+v", combination)
+			}
+		}
+	})
+
+	t.Run("planned grades replace the old ones", func(t *testing.T) {
+		courses := []*models.Course{
+			testCourse(1, "MA101", 4, fall2025, "A"),
+			testCourse(2, "CS201", 3, fall2026, "F"),
+		}
+		plan := planTarget(courses, map[int]bool{2: true}, testGrading(consts.RetakePolicy.LATEST), 3.0)
+
+		// The F is ignored, (3.0 x 7 - 16) / 3
+		if plan.RequiredAverage == nil || *plan.RequiredAverage != 1.67 || !plan.Feasible {
+			t.Errorf("RequiredAverage = %v, Feasible = %v, want 1.67, true", plan.RequiredAverage, plan.Feasible)
+		}
+	})
+
+	retakes := []*models.Course{
+		testCourse(1, "CS101", 3, fall2025, "A"),
+		testCourse(2, "MA101", 3, fall2025, "C"),
+		testCourse(3, "CS101", 3, fall2026, ""),
+	}
+	tests := []struct {
+		name     string
+		policy   string
+		target   float64
+		average  float64
+		feasible bool
+	}{
+		// The retake replaces the A, it needs an A again
+		{name: "latest retake", policy: consts.RetakePolicy.LATEST, target: 3.0, average: 4.0, feasible: true},
+		// The A keeps counting whatever the retake gets
+		{name: "best retake already there", policy: consts.RetakePolicy.BEST, target: 3.0, average: 0, feasible: true},
+		// No retake grade beats the A, and replacing it cannot reach the target
+		{name: "best retake out of reach", policy: consts.RetakePolicy.BEST, target: 3.5, average: 5.0, feasible: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := planTarget(retakes, map[int]bool{3: true}, testGrading(tt.policy), tt.target)
+			if plan.RequiredAverage == nil || *plan.RequiredAverage != tt.average || plan.Feasible != tt.feasible {
+				t.Errorf("RequiredAverage = %v, Feasible = %v, want %v, %v", plan.RequiredAverage, plan.Feasible, tt.average, tt.feasible)
+			}
+		})
+	}
+
+	t.Run("best retake needing a better grade", func(t *testing.T) {
+		courses := []*models.Course{
+			testCourse(1, "CS101", 3, fall2025, "D"),
+			testCourse(2, "MA101", 3, fall2025, "A"),
+			testCourse(3, "CS101", 3, fall2026, ""),
+		}
+		plan := planTarget(courses, map[int]bool{3: true}, testGrading(consts.RetakePolicy.BEST), 3.0)
+
+		// The D keeps counting up to 1.0, then (3.0 x 6 - 12) / 3
+		if plan.RequiredAverage == nil || *plan.RequiredAverage != 2.0 || !plan.Feasible {
+			t.Errorf("RequiredAverage = %v, Feasible = %v, want 2, true", plan.RequiredAverage, plan.Feasible)
+		}
+	})
+
+	t.Run("feasibility follows the top band of the course scale", func(t *testing.T) {
+		courses := []*models.Course{
+			testCourse(1, "MA101", 3, fall2025, "A"),
+			withScale(testCourse(2, "TR100", 3, fall2026, ""), 3),
+		}
+		plan := planTarget(courses, map[int]bool{2: true}, testGrading(consts.RetakePolicy.LATEST), 3.6)
+
+		// 3.2 is below 4.0 but the course tops out at 3.0
+		if plan.RequiredAverage == nil || *plan.RequiredAverage != 3.2 || plan.Feasible {
+			t.Errorf("RequiredAverage = %v, Feasible = %v, want 3.2, false", plan.RequiredAverage, plan.Feasible)
+		}
+		if plan.Courses[0].MinimumGrade != nil {
+			t.Errorf("minimum grade = %s, want none", *plan.Courses[0].MinimumGrade)
+		}
+		if plan.Combinations != nil {
+			t.Errorf("Combinations = %+v, want none", plan.Combinations)
+		}
+	})
+
+	t.Run("nothing planned", func(t *testing.T) {
+		courses := []*models.Course{testCourse(1, "MA101", 3, fall2025, "B")}
+		plan := planTarget(courses, map[int]bool{}, testGrading(consts.RetakePolicy.LATEST), 3.0)
+		if plan.RequiredAverage != nil || !plan.Feasible || len(plan.Courses) != 0 {
+			t.Errorf("plan = %+v", plan)
+		}
+	})
+}
+
+// fakeSemesterService answers GetCurrentSemester only
+type fakeSemesterService struct {
+	ISemesterService
+	current *models.Semester
+}
+
+func (f *fakeSemesterService) GetCurrentSemester(ctx context.Context, userID, date string) (*models.Semester, int) {
+	if f.current == nil {
+		return nil, response.CodeSemesterNotFound
+	}
+	return f.current, response.CodeSuccess
+}
+
+// fakeAssessmentRepository answers GetAssessments only and records the courses asked for
+type fakeAssessmentRepository struct {
+	repo.IAssessmentRepository
+	components []*models.AssessmentComponent
+	asked      []int
+}
+
+func (f *fakeAssessmentRepository) GetAssessments(ctx context.Context, userID string, courseIDs []int) ([]*models.AssessmentComponent, error) {
+	f.asked = append(f.asked, courseIDs...)
+	var components []*models.AssessmentComponent
+	for _, component := range f.components {
+		for _, id := range courseIDs {
+			if component.CourseID == id {
+				components = append(components, component)
+			}
+		}
+	}
+	return components, nil
+}
+
+func TestProjectCoursesOfTheCurrentSemester(t *testing.T) {
+	score := 9.0
+	courses := []*models.Course{
+		testCourse(1, "MA101", 3, spring2026, ""), // never graded
+		testCourse(2, "CS201", 3, fall2026, ""),
+		testCourse(3, "CS202", 3, fall2026, "B"),
+	}
+	components := []*models.AssessmentComponent{
+		{CourseID: 1, Weight: 100, MaxScore: 10, Score: &score},
+		{CourseID: 2, Weight: 100, MaxScore: 10, Score: &score},
+	}
+
+	t.Run("current semester", func(t *testing.T) {
+		assessments := &fakeAssessmentRepository{components: components}
+		s := &GradingService{assessmentRepo: assessments, semesterService: &fakeSemesterService{current: fall2026}}
+
+		result, projected, code := s.projectCourses(context.Background(), "user-1", courses, testGrading(consts.RetakePolicy.LATEST))
+		if code != response.CodeSuccess {
+			t.Fatalf("projectCourses() code = %d", code)
+		}
+		if len(assessments.asked) != 1 || assessments.asked[0] != 2 {
+			t.Errorf("components asked for courses %v, want [2]", assessments.asked)
+		}
+		if len(projected) != 1 || !projected[2] || result[1].Grade == nil || *result[1].Grade != "A" {
+			t.Errorf("projected = %v, CS201 grade = %v, want CS201 projected to A", projected, result[1].Grade)
+		}
+		if result[0].Grade != nil || courses[1].Grade != nil {
+			t.Error("a course of another semester was projected or a loaded course changed")
+		}
+	})
+
+	t.Run("no current semester", func(t *testing.T) {
+		assessments := &fakeAssessmentRepository{components: components}
+		s := &GradingService{assessmentRepo: assessments, semesterService: &fakeSemesterService{}}
+
+		result, projected, code := s.projectCourses(context.Background(), "user-1", courses, testGrading(consts.RetakePolicy.LATEST))
+		if code != response.CodeSuccess || len(projected) != 0 || len(assessments.asked) != 0 || len(result) != len(courses) {
+			t.Errorf("projectCourses() = %d projected, code %d, asked %v", len(projected), code, assessments.asked)
+		}
+	})
+}
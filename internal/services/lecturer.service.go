@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	repo "github.com/nas03/scholar-ai/backend/internal/repositories"
+	errMessage "github.com/nas03/scholar-ai/backend/pkg/errors"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type ILecturerService interface {
+	CreateLecturer(ctx context.Context, userID string, payload *models.LecturerRequest) (*models.Lecturer, int)
+	GetLecturer(ctx context.Context, userID string, id int) (*models.Lecturer, int)
+	GetLecturers(ctx context.Context, userID string) ([]*models.Lecturer, int)
+	UpdateLecturer(ctx context.Context, userID string, id int, payload *models.LecturerRequest) (*models.Lecturer, int)
+	DeleteLecturer(ctx context.Context, userID string, id int) int
+
+	// GetLecturerCourses lists the user's courses taught by the lecturer
+	GetLecturerCourses(ctx context.Context, userID string, id int) ([]*models.Course, int)
+}
+
+type LecturerService struct {
+	lecturerRepo repo.ILecturerRepository
+	courseRepo   repo.ICourseRepository
+}
+
+func NewLecturerService(lecturerRepository repo.ILecturerRepository, courseRepository repo.ICourseRepository) ILecturerService {
+	return &LecturerService{
+		lecturerRepo: lecturerRepository,
+		courseRepo:   courseRepository,
+	}
+}
+
+func (s *LecturerService) CreateLecturer(ctx context.Context, userID string, payload *models.LecturerRequest) (*models.Lecturer, int) {
+	name := strings.TrimSpace(payload.Name)
+	if name == "" {
+		return nil, response.CodeInvalidInput
+	}
+
+	lecturer := &models.Lecturer{
+		UserID:      userID,
+		Name:        name,
+		Email:       trimOptional(payload.Email),
+		Office:      trimOptional(payload.Office),
+		OfficeHours: trimOptional(payload.OfficeHours),
+	}
+	if err := s.lecturerRepo.CreateLecturer(ctx, lecturer); err != nil {
+		return nil, s.writeErrorCode(err, userID, "Error creating lecturer")
+	}
+
+	global.Log.Info("Lecturer created", zap.String("userID", userID), zap.Int("lecturerID", lecturer.ID))
+	return lecturer, response.CodeSuccess
+}
+
+func (s *LecturerService) GetLecturer(ctx context.Context, userID string, id int) (*models.Lecturer, int) {
+	lecturer, err := s.lecturerRepo.GetLecturerByID(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Log.Warn(errMessage.ErrLecturerNotFound.Error(), zap.String("userID", userID), zap.Int("lecturerID", id))
+			return nil, response.CodeLecturerNotFound
+		}
+
+		global.Log.Error("Error getting lecturer by ID", zap.Error(err), zap.String("userID", userID), zap.Int("lecturerID", id))
+		return nil, response.CodeFailedGetLecturer
+	}
+	return lecturer, response.CodeSuccess
+}
+
+func (s *LecturerService) GetLecturers(ctx context.Context, userID string) ([]*models.Lecturer, int) {
+	lecturers, err := s.lecturerRepo.GetLecturers(ctx, userID)
+	if err != nil {
+		global.Log.Error("Error getting lecturers", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedGetLecturer
+	}
+	return lecturers, response.CodeSuccess
+}
+
+func (s *LecturerService) UpdateLecturer(ctx context.Context, userID string, id int, payload *models.LecturerRequest) (*models.Lecturer, int) {
+	name := strings.TrimSpace(payload.Name)
+	if name == "" {
+		return nil, response.CodeInvalidInput
+	}
+
+	updates := map[string]interface{}{
+		"name":         name,
+		"email":        trimOptional(payload.Email),
+		"office":       trimOptional(payload.Office),
+		"office_hours": trimOptional(payload.OfficeHours),
+	}
+	if _, err := s.lecturerRepo.UpdateLecturer(ctx, userID, id, updates); err != nil {
+		return nil, s.writeErrorCode(err, userID, "Error updating lecturer")
+	}
+
+	// MySQL reports unchanged rows as unaffected, the lookup tells a missing lecturer apart
+	return s.GetLecturer(ctx, userID, id)
+}
+
+func (s *LecturerService) DeleteLecturer(ctx context.Context, userID string, id int) int {
+	rowsAffected, err := s.lecturerRepo.DeleteLecturer(ctx, userID, id)
+	if err != nil {
+		global.Log.Error("Error deleting lecturer", zap.Error(err), zap.String("userID", userID), zap.Int("lecturerID", id))
+		return response.CodeFailedUpdateLecturer
+	}
+	if rowsAffected == 0 {
+		global.Log.Warn(errMessage.ErrLecturerNotFound.Error(), zap.String("userID", userID), zap.Int("lecturerID", id))
+		return response.CodeLecturerNotFound
+	}
+
+	global.Log.Info("Lecturer deleted", zap.String("userID", userID), zap.Int("lecturerID", id))
+	return response.CodeSuccess
+}
+
+func (s *LecturerService) GetLecturerCourses(ctx context.Context, userID string, id int) ([]*models.Course, int) {
+	if _, code := s.GetLecturer(ctx, userID, id); code != response.CodeSuccess {
+		return nil, code
+	}
+
+	courses, err := s.courseRepo.GetCourses(ctx, userID, models.CourseFilter{LecturerID: id})
+	if err != nil {
+		global.Log.Error("Error getting lecturer courses", zap.Error(err), zap.String("userID", userID), zap.Int("lecturerID", id))
+		return nil, response.CodeFailedGetCourse
+	}
+	return courses, response.CodeSuccess
+}
+
+// writeErrorCode maps constraint violations of a lecturer insert or update to response codes
+func (s *LecturerService) writeErrorCode(err error, userID, message string) int {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		global.Log.Warn(errMessage.ErrLecturerExists.Error(), zap.String("userID", userID))
+		return response.CodeLecturerAlreadyExists
+	}
+
+	global.Log.Error(message, zap.Error(err), zap.String("userID", userID))
+	return response.CodeFailedUpdateLecturer
+}
+
+// trimOptional trims an optional string, turning a blank value into NULL
+func trimOptional(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
@@ -0,0 +1,37 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/nas03/scholar-ai/backend/global"
+	repo "github.com/nas03/scholar-ai/backend/internal/repositories"
+	"github.com/nas03/scholar-ai/backend/internal/utils"
+	errMessage "github.com/nas03/scholar-ai/backend/pkg/errors"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// getUserLocation loads the timezone of the user's profile
+func getUserLocation(ctx context.Context, userRepo repo.IUserRepository, userID string) (*time.Location, int) {
+	user, err := userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Log.Warn(errMessage.ErrUserNotFound.Error(), zap.String("userID", userID))
+			return nil, response.CodeUserNotFound
+		}
+
+		global.Log.Error("Error getting user by ID", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedGetUser
+	}
+
+	location, err := utils.LoadLocation(user.Timezone)
+	if err != nil {
+		// A stale zone name should not block the request, fall back to UTC
+		global.Log.Warn(err.Error(), zap.String("userID", userID), zap.String("timezone", user.Timezone))
+		return time.UTC, response.CodeSuccess
+	}
+	return location, response.CodeSuccess
+}
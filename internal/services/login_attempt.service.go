@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/consts"
+	"github.com/nas03/scholar-ai/backend/internal/helper"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"github.com/nas03/scholar-ai/backend/internal/utils"
+	errMessage "github.com/nas03/scholar-ai/backend/pkg/errors"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// ILoginAttemptService tracks failed logins per account and per client ip
+// and locks them out with exponential backoff
+type ILoginAttemptService interface {
+	// CheckLocked returns the remaining lockout of the ip and of the account, zero when login is allowed.
+	// user may be nil when the identifier does not match an account.
+	CheckLocked(ctx context.Context, user *models.User, ipAddress string) (ipLock, accountLock time.Duration)
+	// RecordFailure counts a failed login and returns the ip and account lockouts it triggered, if any
+	RecordFailure(ctx context.Context, user *models.User, ipAddress string) (ipLock, accountLock time.Duration)
+	// Reset clears the account counters after a successful login
+	Reset(ctx context.Context, userID string)
+	Inspect(ctx context.Context, scope, key string) (*models.LoginAttemptStatus, error)
+}
+
+type LoginAttemptService struct {
+	mailHelper helper.IMailHelper
+}
+
+func NewLoginAttemptService(mailHelper helper.IMailHelper) ILoginAttemptService {
+	return &LoginAttemptService{
+		mailHelper: mailHelper,
+	}
+}
+
+func (s *LoginAttemptService) CheckLocked(ctx context.Context, user *models.User, ipAddress string) (time.Duration, time.Duration) {
+	cache := utils.NewRedisCache()
+
+	ipLock := s.lockRemaining(ctx, cache, consts.LoginAttemptScope.IP, ipAddress)
+	var accountLock time.Duration
+	if user != nil {
+		accountLock = s.lockRemaining(ctx, cache, consts.LoginAttemptScope.ACCOUNT, user.UserID)
+	}
+	return ipLock, accountLock
+}
+
+func (s *LoginAttemptService) RecordFailure(ctx context.Context, user *models.User, ipAddress string) (time.Duration, time.Duration) {
+	cache := utils.NewRedisCache()
+
+	ipLock := s.recordFailure(ctx, cache, consts.LoginAttemptScope.IP, ipAddress, consts.LOGIN_IP_MAX_FAILED_ATTEMPTS)
+	var accountLock time.Duration
+	if user != nil {
+		accountLock = s.recordFailure(ctx, cache, consts.LoginAttemptScope.ACCOUNT, user.UserID, consts.LOGIN_ACCOUNT_MAX_FAILED_ATTEMPTS)
+		if accountLock > 0 {
+			go s.sendSuspiciousSignInMail(user.Email, ipAddress, accountLock)
+		}
+	}
+	return ipLock, accountLock
+}
+
+// Reset only clears the account. Ip counters are left to expire so one valid
+// login cannot wipe the history of a client guessing other accounts.
+func (s *LoginAttemptService) Reset(ctx context.Context, userID string) {
+	cache := utils.NewRedisCache()
+	scope := consts.LoginAttemptScope.ACCOUNT
+
+	err := cache.Del(ctx,
+		fmt.Sprintf(consts.REDIS_KEY_AUTH_LOGIN_FAILS_PREFIX, scope, userID),
+		fmt.Sprintf(consts.REDIS_KEY_AUTH_LOGIN_LOCK_PREFIX, scope, userID),
+		fmt.Sprintf(consts.REDIS_KEY_AUTH_LOGIN_LEVEL_PREFIX, scope, userID),
+	)
+	if err != nil {
+		global.Log.Error("Failed to reset login attempts", zap.Error(err), zap.String("userID", userID))
+	}
+}
+
+func (s *LoginAttemptService) Inspect(ctx context.Context, scope, key string) (*models.LoginAttemptStatus, error) {
+	cache := utils.NewRedisCache()
+
+	failedAttempts, err := getCounter(ctx, cache, fmt.Sprintf(consts.REDIS_KEY_AUTH_LOGIN_FAILS_PREFIX, scope, key))
+	if err != nil {
+		return nil, err
+	}
+	lockoutLevel, err := getCounter(ctx, cache, fmt.Sprintf(consts.REDIS_KEY_AUTH_LOGIN_LEVEL_PREFIX, scope, key))
+	if err != nil {
+		return nil, err
+	}
+	retryAfter := s.lockRemaining(ctx, cache, scope, key)
+
+	return &models.LoginAttemptStatus{
+		Scope:          scope,
+		Key:            key,
+		FailedAttempts: failedAttempts,
+		LockoutLevel:   lockoutLevel,
+		Locked:         retryAfter > 0,
+		RetryAfter:     int64(retryAfter.Seconds()),
+	}, nil
+}
+
+// recordFailure increments the window counter and starts a lockout once the limit is reached
+func (s *LoginAttemptService) recordFailure(ctx context.Context, cache utils.IRedisCache, scope, key string, limit int) time.Duration {
+	failsKey := fmt.Sprintf(consts.REDIS_KEY_AUTH_LOGIN_FAILS_PREFIX, scope, key)
+	fails, err := cache.Incr(ctx, failsKey)
+	if err != nil {
+		global.Log.Error("Failed to increase failed login count", zap.Error(err), zap.String("scope", scope))
+		return 0
+	}
+	if fails == 1 {
+		_ = cache.Expire(ctx, failsKey, consts.LOGIN_FAILED_ATTEMPTS_WINDOW)
+	}
+	if fails < int64(limit) {
+		return 0
+	}
+
+	levelKey := fmt.Sprintf(consts.REDIS_KEY_AUTH_LOGIN_LEVEL_PREFIX, scope, key)
+	level, err := cache.Incr(ctx, levelKey)
+	if err != nil {
+		global.Log.Error("Failed to increase lockout level", zap.Error(err), zap.String("scope", scope))
+		level = 1
+	}
+	_ = cache.Expire(ctx, levelKey, consts.LOGIN_LOCKOUT_LEVEL_WINDOW)
+
+	lockout := lockoutDuration(level)
+	if err := cache.SetEx(ctx, fmt.Sprintf(consts.REDIS_KEY_AUTH_LOGIN_LOCK_PREFIX, scope, key), level, lockout); err != nil {
+		global.Log.Error("Failed to store login lockout", zap.Error(err), zap.String("scope", scope))
+		return 0
+	}
+	_ = cache.Del(ctx, failsKey)
+
+	global.Log.Warn(errMessage.ErrLoginLocked.Error(),
+		zap.String("scope", scope),
+		zap.String("key", key),
+		zap.Int64("level", level),
+		zap.Duration("lockout", lockout),
+	)
+	return lockout
+}
+
+func (s *LoginAttemptService) lockRemaining(ctx context.Context, cache utils.IRedisCache, scope, key string) time.Duration {
+	ttl, err := cache.TTL(ctx, fmt.Sprintf(consts.REDIS_KEY_AUTH_LOGIN_LOCK_PREFIX, scope, key))
+	if err != nil {
+		global.Log.Error("Failed to get login lockout", zap.Error(err), zap.String("scope", scope))
+		return 0
+	}
+	// Negative values mean the key is missing or has no expiry
+	return max(ttl, 0)
+}
+
+func (s *LoginAttemptService) sendSuspiciousSignInMail(email, ipAddress string, lockout time.Duration) {
+	_, err := s.mailHelper.SendMail(
+		context.Background(),
+		email,
+		"ScholarAI Suspicious Sign-in Attempts",
+		fmt.Sprintf("<p>We noticed several failed sign-in attempts on your account from IP address %s.</p>"+
+			"<p>Sign-in has been locked for %s. If this was not you, consider resetting your password.</p>",
+			ipAddress, lockout.Round(time.Second)),
+	)
+	if err != nil {
+		global.Log.Error("Failed to send suspicious sign-in email", zap.String("email", email), zap.Error(err))
+	}
+}
+
+// lockoutDuration doubles the base lockout for every level, up to the maximum
+func lockoutDuration(level int64) time.Duration {
+	lockout := consts.LOGIN_LOCKOUT_BASE
+	for i := int64(1); i < level && lockout < consts.LOGIN_LOCKOUT_MAX; i++ {
+		lockout *= 2
+	}
+	return min(lockout, consts.LOGIN_LOCKOUT_MAX)
+}
+
+func getCounter(ctx context.Context, cache utils.IRedisCache, key string) (int64, error) {
+	value, err := cache.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
@@ -0,0 +1,28 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLockoutDuration(t *testing.T) {
+	tests := []struct {
+		level int64
+		want  time.Duration
+	}{
+		{level: 0, want: time.Minute},
+		{level: 1, want: time.Minute},
+		{level: 2, want: 2 * time.Minute},
+		{level: 3, want: 4 * time.Minute},
+		{level: 6, want: 32 * time.Minute},
+		// 64 minutes is over the cap
+		{level: 7, want: time.Hour},
+		{level: 1 << 40, want: time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := lockoutDuration(tt.level); got != tt.want {
+			t.Errorf("lockoutDuration(%d) = %v, want %v", tt.level, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,375 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/consts"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	repo "github.com/nas03/scholar-ai/backend/internal/repositories"
+	"github.com/nas03/scholar-ai/backend/internal/utils"
+	errMessage "github.com/nas03/scholar-ai/backend/pkg/errors"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type INoteService interface {
+	CreateNote(ctx context.Context, userID string, payload *models.NoteRequest) (*models.Note, int)
+	GetNote(ctx context.Context, userID string, id int) (*models.Note, int)
+	GetNotes(ctx context.Context, userID string, filter models.NoteFilter) ([]*models.Note, int)
+	DeleteNote(ctx context.Context, userID string, id int) int
+
+	// UpdateNote saves a new revision when the title, content, course or lecture date change,
+	// tags are not versioned and replaced in place.
+	UpdateNote(ctx context.Context, userID string, id int, payload *models.NoteRequest) (*models.Note, int)
+
+	// Revisions are never changed, restoring one saves its content again as the latest revision.
+	GetNoteRevisions(ctx context.Context, userID string, id int) ([]*models.NoteRevision, int)
+	GetNoteRevision(ctx context.Context, userID string, id, version int) (*models.NoteRevision, int)
+	DiffNoteRevisions(ctx context.Context, userID string, id int, query models.NoteDiffQuery) (*models.NoteDiff, int)
+	RestoreNoteRevision(ctx context.Context, userID string, id, version int) (*models.Note, int)
+}
+
+type NoteService struct {
+	noteRepo   repo.INoteRepository
+	courseRepo repo.ICourseRepository
+	tagRepo    repo.ITagRepository
+}
+
+func NewNoteService(
+	noteRepository repo.INoteRepository,
+	courseRepository repo.ICourseRepository,
+	tagRepository repo.ITagRepository,
+) INoteService {
+	return &NoteService{
+		noteRepo:   noteRepository,
+		courseRepo: courseRepository,
+		tagRepo:    tagRepository,
+	}
+}
+
+// noteContent holds the versioned fields of a note
+type noteContent struct {
+	courseID    *int
+	title       string
+	format      string
+	content     string
+	lectureDate *time.Time
+}
+
+func (s *NoteService) CreateNote(ctx context.Context, userID string, payload *models.NoteRequest) (*models.Note, int) {
+	content, tagIDs, code := s.validateNote(ctx, userID, payload)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+
+	note := &models.Note{
+		UserID:      userID,
+		CourseID:    content.courseID,
+		Title:       content.title,
+		Format:      content.format,
+		Content:     content.content,
+		LectureDate: content.lectureDate,
+		Version:     1,
+	}
+	if err := s.noteRepo.CreateNote(ctx, note, content.revision(note.Version, nil), tagIDs); err != nil {
+		return nil, s.writeErrorCode(err, userID, "Error creating note")
+	}
+
+	global.Log.Info("Note created", zap.String("userID", userID), zap.Int("noteID", note.ID))
+	return s.GetNote(ctx, userID, note.ID)
+}
+
+func (s *NoteService) GetNote(ctx context.Context, userID string, id int) (*models.Note, int) {
+	note, err := s.noteRepo.GetNoteByID(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Log.Warn(errMessage.ErrNoteNotFound.Error(), zap.String("userID", userID), zap.Int("noteID", id))
+			return nil, response.CodeNoteNotFound
+		}
+
+		global.Log.Error("Error getting note by ID", zap.Error(err), zap.String("userID", userID), zap.Int("noteID", id))
+		return nil, response.CodeFailedGetNote
+	}
+	return note, response.CodeSuccess
+}
+
+func (s *NoteService) GetNotes(ctx context.Context, userID string, filter models.NoteFilter) ([]*models.Note, int) {
+	if filter.TagID != 0 {
+		filter.TagIDs = append(filter.TagIDs, filter.TagID)
+	}
+	filter.TagIDs = uniqueIDs(filter.TagIDs)
+
+	notes, err := s.noteRepo.GetNotes(ctx, userID, filter)
+	if err != nil {
+		global.Log.Error("Error getting notes", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedGetNote
+	}
+	return notes, response.CodeSuccess
+}
+
+func (s *NoteService) UpdateNote(ctx context.Context, userID string, id int, payload *models.NoteRequest) (*models.Note, int) {
+	note, code := s.GetNote(ctx, userID, id)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+	if payload.Version != nil && *payload.Version != note.Version {
+		global.Log.Warn(errMessage.ErrNoteVersionConflict.Error(), zap.String("userID", userID), zap.Int("noteID", id), zap.Int("version", *payload.Version))
+		return nil, response.CodeNoteVersionConflict
+	}
+
+	content, tagIDs, code := s.validateNote(ctx, userID, payload)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+	return s.saveNote(ctx, userID, note, content, tagIDs, nil)
+}
+
+func (s *NoteService) DeleteNote(ctx context.Context, userID string, id int) int {
+	rowsAffected, err := s.noteRepo.DeleteNote(ctx, userID, id)
+	if err != nil {
+		global.Log.Error("Error deleting note", zap.Error(err), zap.String("userID", userID), zap.Int("noteID", id))
+		return response.CodeFailedUpdateNote
+	}
+	if rowsAffected == 0 {
+		global.Log.Warn(errMessage.ErrNoteNotFound.Error(), zap.String("userID", userID), zap.Int("noteID", id))
+		return response.CodeNoteNotFound
+	}
+
+	global.Log.Info("Note deleted", zap.String("userID", userID), zap.Int("noteID", id))
+	return response.CodeSuccess
+}
+
+func (s *NoteService) GetNoteRevisions(ctx context.Context, userID string, id int) ([]*models.NoteRevision, int) {
+	if _, code := s.GetNote(ctx, userID, id); code != response.CodeSuccess {
+		return nil, code
+	}
+
+	revisions, err := s.noteRepo.GetNoteRevisions(ctx, id)
+	if err != nil {
+		global.Log.Error("Error getting note revisions", zap.Error(err), zap.String("userID", userID), zap.Int("noteID", id))
+		return nil, response.CodeFailedGetNote
+	}
+	return revisions, response.CodeSuccess
+}
+
+func (s *NoteService) GetNoteRevision(ctx context.Context, userID string, id, version int) (*models.NoteRevision, int) {
+	if _, code := s.GetNote(ctx, userID, id); code != response.CodeSuccess {
+		return nil, code
+	}
+	return s.getRevision(ctx, userID, id, version)
+}
+
+func (s *NoteService) DiffNoteRevisions(ctx context.Context, userID string, id int, query models.NoteDiffQuery) (*models.NoteDiff, int) {
+	note, code := s.GetNote(ctx, userID, id)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+
+	to := query.To
+	if to == 0 {
+		to = note.Version
+	}
+	from := query.From
+	if from == 0 {
+		from = to - 1
+	}
+
+	toRevision, code := s.getRevision(ctx, userID, id, to)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+	// The first revision is compared against an empty note
+	fromName, fromContent := "/dev/null", ""
+	if from > 0 {
+		fromRevision, code := s.getRevision(ctx, userID, id, from)
+		if code != response.CodeSuccess {
+			return nil, code
+		}
+		fromName, fromContent = fmt.Sprintf("v%d", from), fromRevision.Content
+	}
+
+	return &models.NoteDiff{
+		NoteID: id,
+		From:   from,
+		To:     to,
+		Diff:   utils.UnifiedDiff(fromName, fmt.Sprintf("v%d", to), fromContent, toRevision.Content, consts.NOTE_DIFF_CONTEXT),
+	}, response.CodeSuccess
+}
+
+func (s *NoteService) RestoreNoteRevision(ctx context.Context, userID string, id, version int) (*models.Note, int) {
+	note, code := s.GetNote(ctx, userID, id)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+	revision, code := s.getRevision(ctx, userID, id, version)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+
+	content := &noteContent{
+		courseID:    revision.CourseID,
+		title:       revision.Title,
+		format:      revision.Format,
+		content:     revision.Content,
+		lectureDate: revision.LectureDate,
+	}
+	// The course of an old revision may be gone, the note is then restored without one
+	if content.courseID != nil {
+		count, err := s.courseRepo.CountCourses(ctx, userID, []int{*content.courseID})
+		if err != nil {
+			global.Log.Error("Error counting courses", zap.Error(err), zap.String("userID", userID))
+			return nil, response.CodeFailedGetCourse
+		}
+		if count == 0 {
+			content.courseID = nil
+		}
+	}
+
+	tagIDs := make([]int, 0, len(note.Tags))
+	for _, tag := range note.Tags {
+		tagIDs = append(tagIDs, tag.ID)
+	}
+	return s.saveNote(ctx, userID, note, content, tagIDs, &version)
+}
+
+// saveNote writes the content and tags of a note, adding a revision when the content changed
+func (s *NoteService) saveNote(ctx context.Context, userID string, note *models.Note, content *noteContent, tagIDs []int, restoredFrom *int) (*models.Note, int) {
+	updates := map[string]interface{}{
+		"course_id":    content.courseID,
+		"title":        content.title,
+		"format":       content.format,
+		"content":      content.content,
+		"lecture_date": content.lectureDate,
+	}
+	var revision *models.NoteRevision
+	if content.changes(note) {
+		updates["version"] = note.Version + 1
+		revision = content.revision(note.Version+1, restoredFrom)
+	}
+
+	rowsAffected, err := s.noteRepo.UpdateNote(ctx, userID, note.ID, note.Version, updates, revision, tagIDs)
+	if err != nil {
+		return nil, s.writeErrorCode(err, userID, "Error updating note")
+	}
+	// The note was found at that version, so it has been saved or deleted meanwhile
+	if rowsAffected == 0 {
+		global.Log.Warn(errMessage.ErrNoteVersionConflict.Error(), zap.String("userID", userID), zap.Int("noteID", note.ID), zap.Int("version", note.Version))
+		return nil, response.CodeNoteVersionConflict
+	}
+
+	global.Log.Info("Note updated", zap.String("userID", userID), zap.Int("noteID", note.ID), zap.Bool("revised", revision != nil))
+	return s.GetNote(ctx, userID, note.ID)
+}
+
+// getRevision retrieves a revision of a note the caller made sure belongs to the user
+func (s *NoteService) getRevision(ctx context.Context, userID string, id, version int) (*models.NoteRevision, int) {
+	revision, err := s.noteRepo.GetNoteRevision(ctx, id, version)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Log.Warn(errMessage.ErrNoteRevisionNotFound.Error(), zap.String("userID", userID), zap.Int("noteID", id), zap.Int("version", version))
+			return nil, response.CodeNoteRevisionNotFound
+		}
+
+		global.Log.Error("Error getting note revision", zap.Error(err), zap.String("userID", userID), zap.Int("noteID", id), zap.Int("version", version))
+		return nil, response.CodeFailedGetNote
+	}
+	return revision, response.CodeSuccess
+}
+
+// validateNote checks the content format and makes sure the course and tags belong to the user
+func (s *NoteService) validateNote(ctx context.Context, userID string, payload *models.NoteRequest) (*noteContent, []int, int) {
+	content := &noteContent{
+		courseID: payload.CourseID,
+		title:    strings.TrimSpace(payload.Title),
+		format:   payload.Format,
+		content:  payload.Content,
+	}
+	if content.title == "" {
+		return nil, nil, response.CodeInvalidInput
+	}
+	if content.format == "" {
+		content.format = consts.NoteFormat.MARKDOWN
+	}
+	if content.format == consts.NoteFormat.JSON && !json.Valid([]byte(content.content)) {
+		global.Log.Warn(errMessage.ErrInvalidNoteContent.Error(), zap.String("userID", userID))
+		return nil, nil, response.CodeInvalidNoteContent
+	}
+	if payload.LectureDate != nil {
+		date, err := utils.ParseDate(*payload.LectureDate)
+		if err != nil {
+			return nil, nil, response.CodeInvalidInput
+		}
+		content.lectureDate = &date
+	}
+
+	if content.courseID != nil {
+		count, err := s.courseRepo.CountCourses(ctx, userID, []int{*content.courseID})
+		if err != nil {
+			global.Log.Error("Error counting courses", zap.Error(err), zap.String("userID", userID))
+			return nil, nil, response.CodeFailedGetCourse
+		}
+		if count == 0 {
+			global.Log.Warn(errMessage.ErrCourseNotFound.Error(), zap.String("userID", userID), zap.Int("courseID", *content.courseID))
+			return nil, nil, response.CodeCourseNotFound
+		}
+	}
+
+	tagIDs := uniqueIDs(payload.TagIDs)
+	if len(tagIDs) > 0 {
+		count, err := s.tagRepo.CountTags(ctx, userID, tagIDs)
+		if err != nil {
+			global.Log.Error("Error counting tags", zap.Error(err), zap.String("userID", userID))
+			return nil, nil, response.CodeFailedGetTag
+		}
+		if count != int64(len(tagIDs)) {
+			global.Log.Warn(errMessage.ErrTagNotFound.Error(), zap.String("userID", userID), zap.Ints("tagIDs", tagIDs))
+			return nil, nil, response.CodeTagNotFound
+		}
+	}
+
+	return content, tagIDs, response.CodeSuccess
+}
+
+// writeErrorCode maps constraint violations of a note write to response codes
+func (s *NoteService) writeErrorCode(err error, userID, message string) int {
+	// The course or a tag can be deleted between the check and the write
+	if errors.Is(err, gorm.ErrForeignKeyViolated) {
+		global.Log.Warn(errMessage.ErrCourseNotFound.Error(), zap.String("userID", userID))
+		return response.CodeCourseNotFound
+	}
+	// Another save took the next version first
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		global.Log.Warn(errMessage.ErrNoteVersionConflict.Error(), zap.String("userID", userID))
+		return response.CodeNoteVersionConflict
+	}
+
+	global.Log.Error(message, zap.Error(err), zap.String("userID", userID))
+	return response.CodeFailedUpdateNote
+}
+
+// changes tells whether saving the content would change the note
+func (c *noteContent) changes(note *models.Note) bool {
+	sameCourse := (c.courseID == nil) == (note.CourseID == nil) && (c.courseID == nil || *c.courseID == *note.CourseID)
+	sameLecture := (c.lectureDate == nil) == (note.LectureDate == nil) && (c.lectureDate == nil || sameDate(*c.lectureDate, *note.LectureDate))
+	return !sameCourse || !sameLecture || c.title != note.Title || c.format != note.Format || c.content != note.Content
+}
+
+// revision builds the revision recording the content at version
+func (c *noteContent) revision(version int, restoredFrom *int) *models.NoteRevision {
+	return &models.NoteRevision{
+		Version:      version,
+		CourseID:     c.courseID,
+		Title:        c.title,
+		Format:       c.format,
+		Content:      c.content,
+		LectureDate:  c.lectureDate,
+		RestoredFrom: restoredFrom,
+	}
+}
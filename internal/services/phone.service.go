@@ -0,0 +1,252 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/consts"
+	"github.com/nas03/scholar-ai/backend/internal/helper"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	repo "github.com/nas03/scholar-ai/backend/internal/repositories"
+	"github.com/nas03/scholar-ai/backend/internal/utils"
+	errMessage "github.com/nas03/scholar-ai/backend/pkg/errors"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type IPhoneService interface {
+	// AddPhoneNumber stores the number unverified and returns its E.164 form
+	AddPhoneNumber(ctx context.Context, userID, phoneNumber string) (string, int)
+	// SendPhoneOtp texts a verification code, returning the cooldown in seconds
+	SendPhoneOtp(ctx context.Context, userID string) (int64, int)
+	VerifyPhoneNumber(ctx context.Context, userID, otp string) int
+}
+
+type PhoneService struct {
+	userRepo  repo.IUserRepository
+	smsHelper helper.ISmsHelper
+}
+
+func NewPhoneService(userRepository repo.IUserRepository, smsHelper helper.ISmsHelper) IPhoneService {
+	return &PhoneService{
+		userRepo:  userRepository,
+		smsHelper: smsHelper,
+	}
+}
+
+func (s *PhoneService) AddPhoneNumber(ctx context.Context, userID, phoneNumber string) (string, int) {
+	normalized, err := utils.NormalizePhoneNumber(phoneNumber)
+	if err != nil {
+		global.Log.Warn(err.Error(), zap.String("userID", userID))
+		return "", response.CodeInvalidPhoneNumber
+	}
+
+	user, code := s.getUser(ctx, userID)
+	if code != response.CodeSuccess {
+		return "", code
+	}
+	if user.PhoneNumber.String == normalized && user.IsPhoneVerified == 1 {
+		global.Log.Warn(errMessage.ErrPhoneVerified.Error(), zap.String("userID", userID))
+		return "", response.CodePhoneAlreadyVerified
+	}
+
+	// A changed number must be verified again
+	if err := s.userRepo.UpdateUserPhone(ctx, userID, normalized, 0); err != nil {
+		global.Log.Error("Error updating phone number", zap.Error(err), zap.String("userID", userID))
+		return "", response.CodeFailedUpdateUser
+	}
+
+	global.Log.Info("Phone number added", zap.String("userID", userID))
+	return normalized, response.CodeSuccess
+}
+
+func (s *PhoneService) SendPhoneOtp(ctx context.Context, userID string) (int64, int) {
+	user, code := s.getUnverifiedPhone(ctx, userID)
+	if code != response.CodeSuccess {
+		return 0, code
+	}
+	phoneNumber := user.PhoneNumber.String
+
+	cache := utils.NewRedisCache()
+
+	// Enforce per-user cooldown
+	cooldownKey := fmt.Sprintf(consts.REDIS_KEY_URS_PHONE_OTP_COOLDOWN_PREFIX, userID)
+	cooldown, err := cache.TTL(ctx, cooldownKey)
+	if err != nil {
+		global.Log.Error("Failed to get phone otp cooldown from redis", zap.Error(err))
+		return 0, response.CodeFailedGetUser
+	}
+	if cooldown > 0 {
+		global.Log.Warn(errMessage.ErrOTPResendCooldown.Error(), zap.String("userID", userID), zap.Duration("cooldown", cooldown))
+		return int64(cooldown.Seconds()), response.CodeOTPResendCooldown
+	}
+
+	// Enforce per-day cap, sms costs money
+	dailyKey := fmt.Sprintf(consts.REDIS_KEY_URS_PHONE_OTP_DAILY_PREFIX, userID)
+	sent, err := cache.Get(ctx, dailyKey)
+	if err != nil && !errors.Is(err, redis.Nil) {
+		global.Log.Error("Failed to get phone otp daily count from redis", zap.Error(err))
+		return 0, response.CodeFailedGetUser
+	}
+	if n, _ := strconv.Atoi(sent); n >= consts.OTP_DAILY_SEND_LIMIT {
+		global.Log.Warn(errMessage.ErrOTPResendLimit.Error(), zap.String("userID", userID), zap.Int("sent", n))
+		return 0, response.CodeOTPResendLimitExceeded
+	}
+
+	otp := utils.GenerateSixDigitOtp()
+	otpKey := fmt.Sprintf(consts.REDIS_KEY_URS_PHONE_OTP_PREFIX, userID, phoneNumber)
+	if err := cache.SetEx(ctx, otpKey, otp, consts.REDIS_PHONE_OTP_EXPIRATION); err != nil {
+		global.Log.Error("Failed to store phone otp in redis", zap.Error(err))
+		return 0, response.CodeFailedUpdateUser
+	}
+
+	// A new code starts with a clean attempt counter
+	if err := cache.Del(ctx, fmt.Sprintf(consts.REDIS_KEY_URS_PHONE_OTP_ATTEMPTS_PREFIX, userID, phoneNumber)); err != nil {
+		global.Log.Error("Failed to reset phone otp attempts", zap.Error(err))
+	}
+
+	if err := cache.SetEx(ctx, cooldownKey, 1, consts.REDIS_OTP_RESEND_COOLDOWN); err != nil {
+		global.Log.Error("Failed to store phone otp cooldown in redis", zap.Error(err))
+	}
+
+	count, err := cache.Incr(ctx, dailyKey)
+	if err != nil {
+		global.Log.Error("Failed to increase phone otp daily count", zap.Error(err))
+	} else if count == 1 {
+		if err := cache.Expire(ctx, dailyKey, consts.REDIS_OTP_DAILY_WINDOW); err != nil {
+			global.Log.Error("Failed to set phone otp daily count expiration", zap.Error(err))
+		}
+	}
+
+	_, err = s.smsHelper.SendSms(ctx, phoneNumber, fmt.Sprintf("Your ScholarAI verification code is %d", otp))
+	if err != nil {
+		global.Log.Error("Failed to send verification sms", zap.String("userID", userID), zap.Error(err))
+		return 0, response.CodeSmsSendFailed
+	}
+
+	global.Log.Info("Success sending phone verification otp", zap.String("userID", userID))
+	return int64(consts.REDIS_OTP_RESEND_COOLDOWN.Seconds()), response.CodeSuccess
+}
+
+func (s *PhoneService) VerifyPhoneNumber(ctx context.Context, userID, otp string) int {
+	if otp == "" {
+		global.Log.Warn(errMessage.ErrInvalidOTP.Error(), zap.String("userID", userID))
+		return response.CodeInvalidOTP
+	}
+
+	user, code := s.getUnverifiedPhone(ctx, userID)
+	if code != response.CodeSuccess {
+		return code
+	}
+	phoneNumber := user.PhoneNumber.String
+
+	// Codes are bound to the number they were sent to
+	cache := utils.NewRedisCache()
+	otpKey := fmt.Sprintf(consts.REDIS_KEY_URS_PHONE_OTP_PREFIX, userID, phoneNumber)
+	attemptsKey := fmt.Sprintf(consts.REDIS_KEY_URS_PHONE_OTP_ATTEMPTS_PREFIX, userID, phoneNumber)
+
+	// The attempt is counted before the otp is compared, so parallel guesses cannot all get past the limit
+	attempts, code := s.reserveOtpAttempt(ctx, cache, userID, attemptsKey)
+	if code != response.CodeSuccess {
+		return code
+	}
+
+	storedOtp, err := cache.Get(ctx, otpKey)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			global.Log.Warn(errMessage.ErrOTPExpired.Error(), zap.String("userID", userID))
+			return response.CodeOTPExpired
+		}
+
+		global.Log.Error("Failed to get phone otp from redis", zap.Error(err))
+		return response.CodeFailedGetUser
+	}
+
+	if subtle.ConstantTimeCompare([]byte(storedOtp), []byte(otp)) != 1 {
+		return s.rejectOtp(ctx, cache, userID, otpKey, attempts)
+	}
+
+	if err := s.userRepo.UpdateUserPhone(ctx, userID, phoneNumber, 1); err != nil {
+		global.Log.Error("Error verifying phone number", zap.Error(err), zap.String("userID", userID))
+		return response.CodeFailedUpdateUser
+	}
+
+	// The otp is single-use, a failure here only leaves a dead key behind
+	if err := cache.Del(ctx, otpKey, attemptsKey); err != nil {
+		global.Log.Warn("Failed to delete phone otp from redis", zap.Error(err), zap.String("userID", userID))
+	}
+
+	global.Log.Info("Phone verification successful", zap.String("userID", userID))
+	return response.CodeSuccess
+}
+
+// reserveOtpAttempt counts an attempt at the otp and refuses it once the code is locked
+func (s *PhoneService) reserveOtpAttempt(ctx context.Context, cache utils.IRedisCache, userID, attemptsKey string) (int64, int) {
+	attempts, err := cache.Incr(ctx, attemptsKey)
+	if err != nil {
+		global.Log.Error("Failed to increase phone otp attempts", zap.Error(err))
+		return 0, response.CodeFailedGetUser
+	}
+	if attempts == 1 {
+		if err := cache.Expire(ctx, attemptsKey, consts.REDIS_PHONE_OTP_EXPIRATION); err != nil {
+			global.Log.Error("Failed to set phone otp attempts expiration", zap.Error(err))
+		}
+	}
+
+	if attempts > int64(consts.OTP_MAX_ATTEMPTS) {
+		global.Log.Warn(errMessage.ErrOTPLocked.Error(), zap.String("userID", userID), zap.Int64("attempts", attempts))
+		return attempts, response.CodeOTPLocked
+	}
+	return attempts, response.CodeSuccess
+}
+
+// rejectOtp answers a wrong otp and locks the code when it took the last attempt
+func (s *PhoneService) rejectOtp(ctx context.Context, cache utils.IRedisCache, userID, otpKey string, attempts int64) int {
+	if attempts >= int64(consts.OTP_MAX_ATTEMPTS) {
+		if err := cache.Del(ctx, otpKey); err != nil {
+			global.Log.Error("Failed to delete locked phone otp", zap.Error(err))
+		}
+		global.Log.Warn(errMessage.ErrOTPLocked.Error(), zap.String("userID", userID), zap.Int64("attempts", attempts))
+		return response.CodeOTPLocked
+	}
+
+	global.Log.Warn(errMessage.ErrInvalidOTP.Error(), zap.String("userID", userID), zap.Int64("attempts", attempts))
+	return response.CodeInvalidOTP
+}
+
+// getUnverifiedPhone loads a user that has a phone number still waiting for verification
+func (s *PhoneService) getUnverifiedPhone(ctx context.Context, userID string) (*models.User, int) {
+	user, code := s.getUser(ctx, userID)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+	if !user.PhoneNumber.Valid || user.PhoneNumber.String == "" {
+		global.Log.Warn(errMessage.ErrPhoneMissing.Error(), zap.String("userID", userID))
+		return nil, response.CodePhoneNumberMissing
+	}
+	if user.IsPhoneVerified == 1 {
+		global.Log.Warn(errMessage.ErrPhoneVerified.Error(), zap.String("userID", userID))
+		return nil, response.CodePhoneAlreadyVerified
+	}
+	return user, response.CodeSuccess
+}
+
+func (s *PhoneService) getUser(ctx context.Context, userID string) (*models.User, int) {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Log.Warn(errMessage.ErrUserNotFound.Error(), zap.String("userID", userID))
+			return nil, response.CodeUserNotFound
+		}
+
+		global.Log.Error("Error getting user by ID", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedGetUser
+	}
+	return user, response.CodeSuccess
+}
@@ -0,0 +1,439 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/consts"
+	"github.com/nas03/scholar-ai/backend/internal/helper"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	repo "github.com/nas03/scholar-ai/backend/internal/repositories"
+	"github.com/nas03/scholar-ai/backend/internal/utils"
+	errMessage "github.com/nas03/scholar-ai/backend/pkg/errors"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+type IProfileService interface {
+	GetProfile(ctx context.Context, userID string) (*models.UserProfile, int)
+	UpdateProfile(ctx context.Context, userID string, payload *models.UpdateProfileRequest) (*models.UserProfile, int)
+
+	// RequestEmailChange mails an otp to the new address, returning the resend cooldown in seconds.
+	// The email only changes once the otp is confirmed.
+	RequestEmailChange(ctx context.Context, userID, newEmail, password string) (int64, int)
+	ConfirmEmailChange(ctx context.Context, userID, otp string) (string, int)
+
+	// RequestDeletion schedules the hard delete after the grace period and returns when it happens
+	RequestDeletion(ctx context.Context, userID, password string) (time.Time, int)
+	CancelDeletion(ctx context.Context, userID string) int
+	// PurgeDueAccounts hard deletes accounts whose grace period ended and returns how many were deleted
+	PurgeDueAccounts(ctx context.Context) int
+}
+
+type ProfileService struct {
+	userRepo        repo.IUserRepository
+	mailHelper      helper.IMailHelper
+	reminderService IReminderService
+}
+
+func NewProfileService(
+	userRepository repo.IUserRepository,
+	mailHelper helper.IMailHelper,
+	reminderService IReminderService,
+) IProfileService {
+	return &ProfileService{
+		userRepo:        userRepository,
+		mailHelper:      mailHelper,
+		reminderService: reminderService,
+	}
+}
+
+func (s *ProfileService) GetProfile(ctx context.Context, userID string) (*models.UserProfile, int) {
+	user, code := s.getUser(ctx, userID)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+	return newUserProfile(user), response.CodeSuccess
+}
+
+// UpdateProfile only writes the fields of models.UpdateProfileRequest, anything else in the body is ignored
+func (s *ProfileService) UpdateProfile(ctx context.Context, userID string, payload *models.UpdateProfileRequest) (*models.UserProfile, int) {
+	updates := map[string]interface{}{}
+
+	setOptionalString := func(column string, value *string) {
+		if value == nil {
+			return
+		}
+		if trimmed := strings.TrimSpace(*value); trimmed != "" {
+			updates[column] = trimmed
+		} else {
+			updates[column] = nil
+		}
+	}
+	setOptionalString("display_name", payload.DisplayName)
+	setOptionalString("university", payload.University)
+	setOptionalString("major", payload.Major)
+
+	if payload.GraduationYear != nil {
+		if *payload.GraduationYear != 0 {
+			updates["graduation_year"] = *payload.GraduationYear
+		} else {
+			updates["graduation_year"] = nil
+		}
+	}
+
+	var location *time.Location
+	if payload.Timezone != nil {
+		timezone := strings.TrimSpace(*payload.Timezone)
+		if timezone == "" {
+			timezone = utils.DefaultTimezone
+		}
+		loaded, err := utils.LoadLocation(timezone)
+		if err != nil {
+			global.Log.Warn(err.Error(), zap.String("userID", userID), zap.String("timezone", timezone))
+			return nil, response.CodeInvalidTimezone
+		}
+		updates["timezone"] = timezone
+		location = loaded
+	}
+
+	if len(updates) > 0 {
+		if err := s.userRepo.UpdateUser(ctx, userID, updates); err != nil {
+			global.Log.Error("Error updating user profile", zap.Error(err), zap.String("userID", userID))
+			return nil, response.CodeFailedUpdateUser
+		}
+		global.Log.Info("Success updating user profile", zap.String("userID", userID), zap.Int("fields", len(updates)))
+	}
+
+	// Reminders keep their wall clock due time in the new timezone,
+	// a failure is logged by the reminder service and does not undo the profile update
+	if location != nil {
+		s.reminderService.RescheduleReminders(ctx, userID, location)
+	}
+
+	return s.GetProfile(ctx, userID)
+}
+
+func (s *ProfileService) RequestEmailChange(ctx context.Context, userID, newEmail, password string) (int64, int) {
+	newEmail = strings.ToLower(strings.TrimSpace(newEmail))
+
+	user, code := s.getUser(ctx, userID)
+	if code != response.CodeSuccess {
+		return 0, code
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		global.Log.Warn(errMessage.ErrInvalidCredentials.Error(), zap.String("userID", userID))
+		return 0, response.CodeInvalidCredentials
+	}
+	if strings.EqualFold(user.Email, newEmail) {
+		global.Log.Warn(errMessage.ErrInvalidEmail.Error(), zap.String("userID", userID), zap.String("reason", "unchanged"))
+		return 0, response.CodeInvalidEmail
+	}
+
+	if _, err := s.userRepo.GetUserByEmail(ctx, newEmail); err == nil {
+		global.Log.Warn(errMessage.ErrUserAlreadyExists.Error(), zap.String("userID", userID), zap.String("email", newEmail))
+		return 0, response.CodeUserAlreadyExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		global.Log.Error("Error getting user by email", zap.Error(err), zap.String("email", newEmail))
+		return 0, response.CodeFailedGetUser
+	}
+
+	cache := utils.NewRedisCache()
+
+	// The new address gets the same cooldown and daily cap as verification emails
+	cooldownKey := fmt.Sprintf(consts.REDIS_KEY_URS_OTP_COOLDOWN_PREFIX, newEmail)
+	cooldown, err := cache.TTL(ctx, cooldownKey)
+	if err != nil {
+		global.Log.Error("Failed to get otp cooldown from redis", zap.Error(err))
+		return 0, response.CodeFailedGetUser
+	}
+	if cooldown > 0 {
+		global.Log.Warn(errMessage.ErrOTPResendCooldown.Error(), zap.String("email", newEmail), zap.Duration("cooldown", cooldown))
+		return int64(cooldown.Seconds()), response.CodeOTPResendCooldown
+	}
+
+	dailyKey := fmt.Sprintf(consts.REDIS_KEY_URS_OTP_DAILY_PREFIX, newEmail)
+	sent, err := cache.Get(ctx, dailyKey)
+	if err != nil && !errors.Is(err, redis.Nil) {
+		global.Log.Error("Failed to get otp daily count from redis", zap.Error(err))
+		return 0, response.CodeFailedGetUser
+	}
+	if n, _ := strconv.Atoi(sent); n >= consts.OTP_DAILY_SEND_LIMIT {
+		global.Log.Warn(errMessage.ErrOTPResendLimit.Error(), zap.String("email", newEmail), zap.Int("sent", n))
+		return 0, response.CodeOTPResendLimitExceeded
+	}
+
+	otp := strconv.Itoa(utils.GenerateSixDigitOtp())
+	pending, err := json.Marshal(models.PendingEmailChange{Email: newEmail, Otp: otp})
+	if err != nil {
+		global.Log.Error("Error encoding pending email change", zap.Error(err))
+		return 0, response.CodeFailedUpdateUser
+	}
+
+	// A new request replaces the previous one and starts with a clean attempt counter
+	pendingKey := fmt.Sprintf(consts.REDIS_KEY_URS_EMAIL_CHANGE_PREFIX, userID)
+	if err := cache.SetEx(ctx, pendingKey, string(pending), consts.REDIS_EMAIL_CHANGE_EXPIRATION); err != nil {
+		global.Log.Error("Failed to store pending email change in redis", zap.Error(err))
+		return 0, response.CodeFailedUpdateUser
+	}
+	if err := cache.Del(ctx, fmt.Sprintf(consts.REDIS_KEY_URS_EMAIL_CHANGE_ATTEMPTS_PREFIX, userID)); err != nil {
+		global.Log.Error("Failed to reset email change attempts", zap.Error(err))
+	}
+
+	if err := cache.SetEx(ctx, cooldownKey, 1, consts.REDIS_OTP_RESEND_COOLDOWN); err != nil {
+		global.Log.Error("Failed to store otp cooldown in redis", zap.Error(err))
+	}
+	count, err := cache.Incr(ctx, dailyKey)
+	if err != nil {
+		global.Log.Error("Failed to increase otp daily count", zap.Error(err))
+	} else if count == 1 {
+		if err := cache.Expire(ctx, dailyKey, consts.REDIS_OTP_DAILY_WINDOW); err != nil {
+			global.Log.Error("Failed to set otp daily count expiration", zap.Error(err))
+		}
+	}
+
+	_, err = s.mailHelper.SendMail(
+		ctx,
+		newEmail,
+		fmt.Sprintf("ScholarAI Confirm Your New Email %s", otp),
+		fmt.Sprintf("<p>Use this code to confirm your new email address: %s</p>"+
+			"<p>The code expires in %s.</p>", otp, consts.REDIS_EMAIL_CHANGE_EXPIRATION),
+	)
+	if err != nil {
+		global.Log.Error("Failed to send email change otp", zap.String("email", newEmail), zap.Error(err))
+		return 0, response.CodeMailSendFailed
+	}
+
+	global.Log.Info("Email change requested", zap.String("userID", userID))
+	return int64(consts.REDIS_OTP_RESEND_COOLDOWN.Seconds()), response.CodeSuccess
+}
+
+func (s *ProfileService) ConfirmEmailChange(ctx context.Context, userID, otp string) (string, int) {
+	if otp == "" {
+		global.Log.Warn(errMessage.ErrInvalidOTP.Error(), zap.String("userID", userID))
+		return "", response.CodeInvalidOTP
+	}
+
+	cache := utils.NewRedisCache()
+	pendingKey := fmt.Sprintf(consts.REDIS_KEY_URS_EMAIL_CHANGE_PREFIX, userID)
+	attemptsKey := fmt.Sprintf(consts.REDIS_KEY_URS_EMAIL_CHANGE_ATTEMPTS_PREFIX, userID)
+
+	// The attempt is counted before the otp is compared, so parallel guesses cannot all get past the limit
+	attempts, code := s.reserveEmailChangeAttempt(ctx, cache, userID, attemptsKey)
+	if code != response.CodeSuccess {
+		return "", code
+	}
+
+	data, err := cache.Get(ctx, pendingKey)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			global.Log.Warn(errMessage.ErrOTPExpired.Error(), zap.String("userID", userID))
+			return "", response.CodeOTPExpired
+		}
+
+		global.Log.Error("Failed to get pending email change from redis", zap.Error(err))
+		return "", response.CodeFailedGetUser
+	}
+	var pending models.PendingEmailChange
+	if err := json.Unmarshal([]byte(data), &pending); err != nil {
+		global.Log.Error("Error decoding pending email change", zap.Error(err))
+		return "", response.CodeFailedGetUser
+	}
+
+	if subtle.ConstantTimeCompare([]byte(pending.Otp), []byte(otp)) != 1 {
+		return "", s.rejectEmailChangeOtp(ctx, cache, userID, pendingKey, attempts)
+	}
+
+	user, code := s.getUser(ctx, userID)
+	if code != response.CodeSuccess {
+		return "", code
+	}
+
+	// The unique index still guards against the address being taken since the request
+	err = s.userRepo.UpdateUser(ctx, userID, map[string]interface{}{
+		"email":             pending.Email,
+		"is_email_verified": 1,
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			global.Log.Warn(errMessage.ErrUserAlreadyExists.Error(), zap.String("userID", userID), zap.String("email", pending.Email))
+			return "", response.CodeUserAlreadyExists
+		}
+
+		global.Log.Error("Error changing user email", zap.Error(err), zap.String("userID", userID))
+		return "", response.CodeFailedUpdateUser
+	}
+
+	if err := cache.Del(ctx, pendingKey, attemptsKey, fmt.Sprintf(consts.REDIS_KEY_AUTH_USER_PREFIX, userID)); err != nil {
+		global.Log.Warn("Failed to delete pending email change from redis", zap.Error(err), zap.String("userID", userID))
+	}
+
+	// Tell the old address, in case the change was not made by its owner
+	go s.sendMail(user.Email, "ScholarAI Email Address Changed",
+		fmt.Sprintf("<p>The email address of your account was changed to %s.</p>"+
+			"<p>If you did not make this change, please contact support.</p>", pending.Email))
+
+	global.Log.Info("Success changing user email", zap.String("userID", userID))
+	return pending.Email, response.CodeSuccess
+}
+
+func (s *ProfileService) RequestDeletion(ctx context.Context, userID, password string) (time.Time, int) {
+	user, code := s.getUser(ctx, userID)
+	if code != response.CodeSuccess {
+		return time.Time{}, code
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		global.Log.Warn(errMessage.ErrInvalidCredentials.Error(), zap.String("userID", userID))
+		return time.Time{}, response.CodeInvalidCredentials
+	}
+	if user.DeletionScheduledAt.Valid {
+		global.Log.Warn(errMessage.ErrDeletionScheduled.Error(), zap.String("userID", userID))
+		return time.Time{}, response.CodeDeletionScheduled
+	}
+
+	deleteAt := time.Now().Add(consts.ACCOUNT_DELETION_GRACE_PERIOD)
+	if err := s.userRepo.UpdateUser(ctx, userID, map[string]interface{}{"deletion_scheduled_at": deleteAt}); err != nil {
+		global.Log.Error("Error scheduling account deletion", zap.Error(err), zap.String("userID", userID))
+		return time.Time{}, response.CodeFailedUpdateUser
+	}
+
+	go s.sendMail(user.Email, "ScholarAI Account Deletion Scheduled",
+		fmt.Sprintf("<p>Your account and all of its data will be permanently deleted on %s.</p>"+
+			"<p>Sign in and cancel the deletion before then to keep your account.</p>", deleteAt.UTC().Format(time.RFC1123)))
+
+	global.Log.Info("Account deletion scheduled", zap.String("userID", userID), zap.Time("deleteAt", deleteAt))
+	return deleteAt, response.CodeSuccess
+}
+
+func (s *ProfileService) CancelDeletion(ctx context.Context, userID string) int {
+	user, code := s.getUser(ctx, userID)
+	if code != response.CodeSuccess {
+		return code
+	}
+	if !user.DeletionScheduledAt.Valid {
+		global.Log.Warn(errMessage.ErrDeletionMissing.Error(), zap.String("userID", userID))
+		return response.CodeDeletionNotScheduled
+	}
+
+	if err := s.userRepo.UpdateUser(ctx, userID, map[string]interface{}{"deletion_scheduled_at": nil}); err != nil {
+		global.Log.Error("Error cancelling account deletion", zap.Error(err), zap.String("userID", userID))
+		return response.CodeFailedUpdateUser
+	}
+
+	global.Log.Info("Account deletion cancelled", zap.String("userID", userID))
+	return response.CodeSuccess
+}
+
+func (s *ProfileService) PurgeDueAccounts(ctx context.Context) int {
+	userIDs, err := s.userRepo.GetUsersDueForDeletion(ctx, time.Now(), consts.ACCOUNT_PURGE_BATCH_SIZE)
+	if err != nil {
+		global.Log.Error("Error getting accounts due for deletion", zap.Error(err))
+		return 0
+	}
+
+	deleted := 0
+	for _, userID := range userIDs {
+		if err := s.userRepo.DeleteUser(ctx, userID); err != nil {
+			global.Log.Error("Error deleting account", zap.Error(err), zap.String("userID", userID))
+			continue
+		}
+		if err := utils.NewRedisCache().Del(ctx, fmt.Sprintf(consts.REDIS_KEY_AUTH_USER_PREFIX, userID)); err != nil {
+			global.Log.Warn("Failed to invalidate cached auth user", zap.Error(err), zap.String("userID", userID))
+		}
+		deleted++
+		global.Log.Info("Account deleted", zap.String("userID", userID))
+	}
+	return deleted
+}
+
+// reserveEmailChangeAttempt counts an attempt at the otp and refuses it once the request is locked
+func (s *ProfileService) reserveEmailChangeAttempt(ctx context.Context, cache utils.IRedisCache, userID, attemptsKey string) (int64, int) {
+	attempts, err := cache.Incr(ctx, attemptsKey)
+	if err != nil {
+		global.Log.Error("Failed to increase email change attempts", zap.Error(err))
+		return 0, response.CodeFailedGetUser
+	}
+	if attempts == 1 {
+		if err := cache.Expire(ctx, attemptsKey, consts.REDIS_EMAIL_CHANGE_EXPIRATION); err != nil {
+			global.Log.Error("Failed to set email change attempts expiration", zap.Error(err))
+		}
+	}
+
+	if attempts > int64(consts.OTP_MAX_ATTEMPTS) {
+		global.Log.Warn(errMessage.ErrOTPLocked.Error(), zap.String("userID", userID), zap.Int64("attempts", attempts))
+		return attempts, response.CodeOTPLocked
+	}
+	return attempts, response.CodeSuccess
+}
+
+// rejectEmailChangeOtp answers a wrong otp and drops the request when it took the last attempt
+func (s *ProfileService) rejectEmailChangeOtp(ctx context.Context, cache utils.IRedisCache, userID, pendingKey string, attempts int64) int {
+	if attempts >= int64(consts.OTP_MAX_ATTEMPTS) {
+		if err := cache.Del(ctx, pendingKey); err != nil {
+			global.Log.Error("Failed to delete locked email change", zap.Error(err))
+		}
+		global.Log.Warn(errMessage.ErrOTPLocked.Error(), zap.String("userID", userID), zap.Int64("attempts", attempts))
+		return response.CodeOTPLocked
+	}
+
+	global.Log.Warn(errMessage.ErrInvalidOTP.Error(), zap.String("userID", userID), zap.Int64("attempts", attempts))
+	return response.CodeInvalidOTP
+}
+
+func (s *ProfileService) sendMail(email, subject, body string) {
+	if _, err := s.mailHelper.SendMail(context.Background(), email, subject, body); err != nil {
+		global.Log.Error("Failed to send account notification email", zap.String("email", email), zap.Error(err))
+	}
+}
+
+func (s *ProfileService) getUser(ctx context.Context, userID string) (*models.User, int) {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Log.Warn(errMessage.ErrUserNotFound.Error(), zap.String("userID", userID))
+			return nil, response.CodeUserNotFound
+		}
+
+		global.Log.Error("Error getting user by ID", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedGetUser
+	}
+	return user, response.CodeSuccess
+}
+
+func newUserProfile(user *models.User) *models.UserProfile {
+	profile := &models.UserProfile{
+		UserID:          user.UserID,
+		Username:        user.Username,
+		Email:           user.Email,
+		PhoneNumber:     user.PhoneNumber.String,
+		IsEmailVerified: user.IsEmailVerified == 1,
+		IsPhoneVerified: user.IsPhoneVerified == 1,
+		IsTotpEnabled:   user.IsTotpEnabled == 1,
+		Role:            user.Role,
+		DisplayName:     user.DisplayName.String,
+		University:      user.University.String,
+		Major:           user.Major.String,
+		Timezone:        user.Timezone,
+		CreatedAt:       user.CreatedAt,
+	}
+	if user.GraduationYear.Valid {
+		profile.GraduationYear = &user.GraduationYear.Int16
+	}
+	if user.DeletionScheduledAt.Valid {
+		profile.DeletionScheduledAt = &user.DeletionScheduledAt.Time
+	}
+	if profile.Timezone == "" {
+		profile.Timezone = utils.DefaultTimezone
+	}
+	return profile
+}
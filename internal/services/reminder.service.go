@@ -0,0 +1,729 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/consts"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	repo "github.com/nas03/scholar-ai/backend/internal/repositories"
+	"github.com/nas03/scholar-ai/backend/internal/utils"
+	errMessage "github.com/nas03/scholar-ai/backend/pkg/errors"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type IReminderService interface {
+	CreateReminder(ctx context.Context, userID string, payload *models.ReminderRequest) (*models.Reminder, int)
+	GetReminder(ctx context.Context, userID string, id int) (*models.Reminder, int)
+	GetReminders(ctx context.Context, userID string, filter models.ReminderFilter) ([]*models.Reminder, int)
+
+	// GetOccurrences lists the occurrences due in a date range, expanding recurring reminders
+	GetOccurrences(ctx context.Context, userID string, query models.ReminderRangeQuery) ([]*models.ReminderOccurrence, int)
+
+	// UpdateReminder and DeleteReminder apply to the occurrences of a recurring reminder picked by query,
+	// the whole series by default
+	UpdateReminder(ctx context.Context, userID string, id int, query models.ReminderOccurrenceQuery, payload *models.ReminderRequest) (*models.Reminder, int)
+	DeleteReminder(ctx context.Context, userID string, id int, query models.ReminderOccurrenceQuery) int
+
+	// CompleteReminder completes a reminder, or the occurrence on occurrenceDate of a recurring one
+	CompleteReminder(ctx context.Context, userID string, id int, occurrenceDate string) (*models.Reminder, int)
+
+	// RescheduleReminders recomputes the due instant of open reminders after the user changed timezone,
+	// keeping their wall clock due date and time
+	RescheduleReminders(ctx context.Context, userID string, location *time.Location) int
+	// MarkOverdueReminders moves past-due pending reminders to overdue and returns how many were moved
+	MarkOverdueReminders(ctx context.Context) int
+}
+
+type ReminderService struct {
+	reminderRepo repo.IReminderRepository
+	courseRepo   repo.ICourseRepository
+	userRepo     repo.IUserRepository
+}
+
+func NewReminderService(
+	reminderRepository repo.IReminderRepository,
+	courseRepository repo.ICourseRepository,
+	userRepository repo.IUserRepository,
+) IReminderService {
+	return &ReminderService{
+		reminderRepo: reminderRepository,
+		courseRepo:   courseRepository,
+		userRepo:     userRepository,
+	}
+}
+
+func (s *ReminderService) CreateReminder(ctx context.Context, userID string, payload *models.ReminderRequest) (*models.Reminder, int) {
+	due, code := s.validateReminder(ctx, userID, payload)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+
+	reminder := newReminder(userID, payload, due)
+	if err := s.reminderRepo.CreateReminder(ctx, reminder); err != nil {
+		return nil, s.writeErrorCode(err, userID, "Error creating reminder")
+	}
+
+	global.Log.Info("Reminder created", zap.String("userID", userID), zap.Int("reminderID", reminder.ID))
+	return reminder, response.CodeSuccess
+}
+
+func (s *ReminderService) GetReminder(ctx context.Context, userID string, id int) (*models.Reminder, int) {
+	reminder, err := s.reminderRepo.GetReminderByID(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Log.Warn(errMessage.ErrReminderNotFound.Error(), zap.String("userID", userID), zap.Int("reminderID", id))
+			return nil, response.CodeReminderNotFound
+		}
+
+		global.Log.Error("Error getting reminder by ID", zap.Error(err), zap.String("userID", userID), zap.Int("reminderID", id))
+		return nil, response.CodeFailedGetReminder
+	}
+	return reminder, response.CodeSuccess
+}
+
+func (s *ReminderService) GetReminders(ctx context.Context, userID string, filter models.ReminderFilter) ([]*models.Reminder, int) {
+	reminders, err := s.reminderRepo.GetReminders(ctx, userID, filter, time.Now())
+	if err != nil {
+		global.Log.Error("Error getting reminders", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedGetReminder
+	}
+	return reminders, response.CodeSuccess
+}
+
+func (s *ReminderService) GetOccurrences(ctx context.Context, userID string, query models.ReminderRangeQuery) ([]*models.ReminderOccurrence, int) {
+	from, err := utils.ParseDate(query.From)
+	if err != nil {
+		return nil, response.CodeInvalidInput
+	}
+	last, err := utils.ParseDate(query.To)
+	if err != nil || last.Before(from) || last.Sub(from) >= time.Duration(consts.REMINDER_MAX_RANGE_DAYS)*24*time.Hour {
+		return nil, response.CodeInvalidInput
+	}
+
+	location, code := getUserLocation(ctx, s.userRepo, userID)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+	fromAt, _ := utils.ParseDateTimeIn(query.From, "00:00", location)
+	toAt, _ := utils.ParseDateTimeIn(last.AddDate(0, 0, 1).Format(utils.DateLayout), "00:00", location)
+
+	reminders, err := s.reminderRepo.GetRemindersInRange(ctx, userID, query.CourseID, fromAt, toAt)
+	if err != nil {
+		global.Log.Error("Error getting reminders in range", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedGetReminder
+	}
+
+	var seriesIDs []int
+	for _, reminder := range reminders {
+		if reminder.RRule != nil {
+			seriesIDs = append(seriesIDs, reminder.ID)
+		}
+	}
+	completions, err := s.reminderRepo.GetCompletions(ctx, seriesIDs, from, last)
+	if err != nil {
+		global.Log.Error("Error getting reminder completions", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedGetReminder
+	}
+	completedAt := make(map[string]time.Time, len(completions))
+	for _, completion := range completions {
+		completedAt[occurrenceKey(completion.ReminderID, completion.OccurrenceDate)] = completion.CompletedAt
+	}
+
+	occurrences := make([]*models.ReminderOccurrence, 0, len(reminders))
+	for _, reminder := range reminders {
+		if reminder.RRule == nil {
+			occurrences = append(occurrences, &models.ReminderOccurrence{
+				ReminderID:     reminder.ID,
+				OccurrenceDate: reminder.DueDate,
+				DueAt:          reminder.DueAt,
+				Status:         reminder.Status,
+				CompletedAt:    reminder.CompletedAt,
+				Reminder:       reminder,
+			})
+			continue
+		}
+
+		for _, date := range seriesDates(reminder, location, last) {
+			if date.Before(from) {
+				continue
+			}
+
+			occurrence := &models.ReminderOccurrence{
+				ReminderID:     reminder.ID,
+				OccurrenceDate: date,
+				DueAt:          occurrenceAt(reminder, date, location),
+				Recurring:      true,
+				Reminder:       reminder,
+			}
+			if at, ok := completedAt[occurrenceKey(reminder.ID, date)]; ok {
+				occurrence.Status = consts.ReminderStatus.COMPLETED
+				occurrence.CompletedAt = &at
+			} else {
+				occurrence.Status = openReminderStatus(occurrence.DueAt)
+			}
+			occurrences = append(occurrences, occurrence)
+		}
+	}
+
+	if query.Status != nil {
+		filtered := occurrences[:0]
+		for _, occurrence := range occurrences {
+			if occurrence.Status == *query.Status {
+				filtered = append(filtered, occurrence)
+			}
+		}
+		occurrences = filtered
+	}
+
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		if !occurrences[i].DueAt.Equal(occurrences[j].DueAt) {
+			return occurrences[i].DueAt.Before(occurrences[j].DueAt)
+		}
+		return occurrences[i].ReminderID < occurrences[j].ReminderID
+	})
+	return occurrences, response.CodeSuccess
+}
+
+func (s *ReminderService) UpdateReminder(ctx context.Context, userID string, id int, query models.ReminderOccurrenceQuery, payload *models.ReminderRequest) (*models.Reminder, int) {
+	reminder, code := s.GetReminder(ctx, userID, id)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+
+	due, code := s.validateReminder(ctx, userID, payload)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+
+	if reminder.RRule == nil || query.Scope == "" || query.Scope == consts.ReminderEditScope.ALL {
+		return s.updateWholeReminder(ctx, userID, reminder, payload, due)
+	}
+
+	date, first, code := s.occurrenceDate(reminder, query.OccurrenceDate, due.location)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+
+	if query.Scope == consts.ReminderEditScope.THIS {
+		return s.detachOccurrence(ctx, userID, reminder, date, payload, due)
+	}
+	if first {
+		return s.updateWholeReminder(ctx, userID, reminder, payload, due)
+	}
+	return s.splitSeries(ctx, userID, reminder, date, payload, due)
+}
+
+// updateWholeReminder updates a one-off reminder or every occurrence of a series
+func (s *ReminderService) updateWholeReminder(ctx context.Context, userID string, reminder *models.Reminder, payload *models.ReminderRequest, due *reminderSchedule) (*models.Reminder, int) {
+	updates := map[string]interface{}{
+		"title":         strings.TrimSpace(payload.Title),
+		"description":   payload.Description,
+		"due_date":      due.date,
+		"due_time":      due.clock,
+		"due_at":        due.at,
+		"course_id":     payload.CourseID,
+		"type":          payload.Type,
+		"rrule":         due.rrule,
+		"series_end_at": due.endAt,
+	}
+	// Moving the due time decides again whether an open reminder is overdue, a series is always pending
+	switch {
+	case due.rrule != nil:
+		updates["status"] = consts.ReminderStatus.PENDING
+		updates["completed_at"] = nil
+	case reminder.Status != consts.ReminderStatus.COMPLETED:
+		updates["status"] = openReminderStatus(due.at)
+	}
+
+	if _, err := s.reminderRepo.UpdateReminderWithExDates(ctx, userID, reminder.ID, updates, due.exDates); err != nil {
+		return nil, s.writeErrorCode(err, userID, "Error updating reminder")
+	}
+
+	global.Log.Info("Reminder updated", zap.String("userID", userID), zap.Int("reminderID", reminder.ID))
+	return s.GetReminder(ctx, userID, reminder.ID)
+}
+
+// detachOccurrence turns one occurrence of a series into a reminder of its own, keeping its completion
+func (s *ReminderService) detachOccurrence(ctx context.Context, userID string, series *models.Reminder, date time.Time, payload *models.ReminderRequest, due *reminderSchedule) (*models.Reminder, int) {
+	// A single occurrence cannot recur itself
+	if due.rrule != nil {
+		return nil, response.CodeInvalidInput
+	}
+
+	completions, err := s.reminderRepo.GetCompletions(ctx, []int{series.ID}, date, date)
+	if err != nil {
+		global.Log.Error("Error getting reminder completions", zap.Error(err), zap.String("userID", userID), zap.Int("reminderID", series.ID))
+		return nil, response.CodeFailedGetReminder
+	}
+
+	occurrence := newReminder(userID, payload, due)
+	occurrence.SeriesID = &series.ID
+	occurrence.OccurrenceDate = &date
+	if len(completions) > 0 {
+		occurrence.Status = consts.ReminderStatus.COMPLETED
+		occurrence.CompletedAt = &completions[0].CompletedAt
+	}
+
+	if err := s.reminderRepo.DetachOccurrence(ctx, series.ID, occurrence); err != nil {
+		return nil, s.writeErrorCode(err, userID, "Error detaching reminder occurrence")
+	}
+
+	global.Log.Info("Reminder occurrence detached", zap.String("userID", userID), zap.Int("reminderID", series.ID),
+		zap.String("occurrenceDate", date.Format(utils.DateLayout)), zap.Int("occurrenceID", occurrence.ID))
+	return s.GetReminder(ctx, userID, occurrence.ID)
+}
+
+// splitSeries ends a series before date and continues it as a new reminder built from the payload
+func (s *ReminderService) splitSeries(ctx context.Context, userID string, series *models.Reminder, date time.Time, payload *models.ReminderRequest, due *reminderSchedule) (*models.Reminder, int) {
+	rule, endAt, kept, err := endSeriesBefore(series, date, due.location)
+	if err != nil {
+		global.Log.Error("Error parsing stored recurrence", zap.Error(err), zap.Int("reminderID", series.ID))
+		return nil, response.CodeFailedUpdateReminder
+	}
+
+	if !due.continueAfter(kept) {
+		return nil, response.CodeInvalidInput
+	}
+
+	next := newReminder(userID, payload, due)
+	// Dates the series skipped from date onwards stay skipped in its continuation
+	if next.RRule != nil {
+		skipped := make(map[string]bool, len(next.ExDates))
+		for _, exDate := range next.ExDates {
+			skipped[exDate.Date.Format(utils.DateLayout)] = true
+		}
+		for _, exDate := range series.ExDates {
+			if !exDate.Date.Before(date) && !skipped[exDate.Date.Format(utils.DateLayout)] {
+				next.ExDates = append(next.ExDates, models.ReminderExDate{Date: exDate.Date})
+			}
+		}
+	}
+
+	if err := s.reminderRepo.SplitSeries(ctx, series.ID, date, rule.String(), endAt, next); err != nil {
+		return nil, s.writeErrorCode(err, userID, "Error splitting reminder series")
+	}
+
+	global.Log.Info("Reminder series split", zap.String("userID", userID), zap.Int("reminderID", series.ID),
+		zap.String("occurrenceDate", date.Format(utils.DateLayout)), zap.Int("nextID", next.ID))
+	return s.GetReminder(ctx, userID, next.ID)
+}
+
+func (s *ReminderService) DeleteReminder(ctx context.Context, userID string, id int, query models.ReminderOccurrenceQuery) int {
+	if query.Scope != "" && query.Scope != consts.ReminderEditScope.ALL {
+		reminder, code := s.GetReminder(ctx, userID, id)
+		if code != response.CodeSuccess {
+			return code
+		}
+		if reminder.RRule != nil {
+			return s.deleteOccurrences(ctx, userID, reminder, query)
+		}
+	}
+
+	rowsAffected, err := s.reminderRepo.DeleteReminder(ctx, userID, id)
+	if err != nil {
+		global.Log.Error("Error deleting reminder", zap.Error(err), zap.String("userID", userID), zap.Int("reminderID", id))
+		return response.CodeFailedUpdateReminder
+	}
+	if rowsAffected == 0 {
+		global.Log.Warn(errMessage.ErrReminderNotFound.Error(), zap.String("userID", userID), zap.Int("reminderID", id))
+		return response.CodeReminderNotFound
+	}
+
+	global.Log.Info("Reminder deleted", zap.String("userID", userID), zap.Int("reminderID", id))
+	return response.CodeSuccess
+}
+
+// deleteOccurrences skips one occurrence of a series or ends the series before it
+func (s *ReminderService) deleteOccurrences(ctx context.Context, userID string, series *models.Reminder, query models.ReminderOccurrenceQuery) int {
+	location, code := getUserLocation(ctx, s.userRepo, userID)
+	if code != response.CodeSuccess {
+		return code
+	}
+
+	date, first, code := s.occurrenceDate(series, query.OccurrenceDate, location)
+	if code != response.CodeSuccess {
+		return code
+	}
+
+	if query.Scope == consts.ReminderEditScope.THIS {
+		if err := s.reminderRepo.SkipOccurrence(ctx, series.ID, date); err != nil {
+			global.Log.Error("Error skipping reminder occurrence", zap.Error(err), zap.String("userID", userID), zap.Int("reminderID", series.ID))
+			return response.CodeFailedUpdateReminder
+		}
+
+		global.Log.Info("Reminder occurrence deleted", zap.String("userID", userID), zap.Int("reminderID", series.ID),
+			zap.String("occurrenceDate", date.Format(utils.DateLayout)))
+		return response.CodeSuccess
+	}
+
+	// Nothing is left of a series deleted from its first occurrence on
+	if first {
+		return s.DeleteReminder(ctx, userID, series.ID, models.ReminderOccurrenceQuery{})
+	}
+
+	rule, endAt, _, err := endSeriesBefore(series, date, location)
+	if err != nil {
+		global.Log.Error("Error parsing stored recurrence", zap.Error(err), zap.Int("reminderID", series.ID))
+		return response.CodeFailedUpdateReminder
+	}
+
+	if err := s.reminderRepo.TruncateSeries(ctx, series.ID, date, rule.String(), endAt); err != nil {
+		global.Log.Error("Error truncating reminder series", zap.Error(err), zap.String("userID", userID), zap.Int("reminderID", series.ID))
+		return response.CodeFailedUpdateReminder
+	}
+
+	global.Log.Info("Reminder series truncated", zap.String("userID", userID), zap.Int("reminderID", series.ID),
+		zap.String("occurrenceDate", date.Format(utils.DateLayout)))
+	return response.CodeSuccess
+}
+
+// CompleteReminder is idempotent, completing a completed reminder or occurrence keeps its completion time
+func (s *ReminderService) CompleteReminder(ctx context.Context, userID string, id int, occurrenceDate string) (*models.Reminder, int) {
+	reminder, code := s.GetReminder(ctx, userID, id)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+	if reminder.RRule != nil {
+		return s.completeOccurrence(ctx, userID, reminder, occurrenceDate)
+	}
+	if reminder.Status == consts.ReminderStatus.COMPLETED {
+		return reminder, response.CodeSuccess
+	}
+
+	updates := map[string]interface{}{
+		"status":       consts.ReminderStatus.COMPLETED,
+		"completed_at": time.Now(),
+	}
+	if _, err := s.reminderRepo.UpdateReminder(ctx, userID, id, updates); err != nil {
+		global.Log.Error("Error completing reminder", zap.Error(err), zap.String("userID", userID), zap.Int("reminderID", id))
+		return nil, response.CodeFailedUpdateReminder
+	}
+
+	global.Log.Info("Reminder completed", zap.String("userID", userID), zap.Int("reminderID", id))
+	return s.GetReminder(ctx, userID, id)
+}
+
+// completeOccurrence records the completion of one occurrence, the series itself stays pending
+func (s *ReminderService) completeOccurrence(ctx context.Context, userID string, series *models.Reminder, occurrenceDate string) (*models.Reminder, int) {
+	location, code := getUserLocation(ctx, s.userRepo, userID)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+
+	date, _, code := s.occurrenceDate(series, occurrenceDate, location)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+
+	completion := &models.ReminderCompletion{
+		ReminderID:     series.ID,
+		OccurrenceDate: date,
+		CompletedAt:    time.Now(),
+	}
+	if err := s.reminderRepo.CreateCompletion(ctx, completion); err != nil {
+		global.Log.Error("Error completing reminder occurrence", zap.Error(err), zap.String("userID", userID), zap.Int("reminderID", series.ID))
+		return nil, response.CodeFailedUpdateReminder
+	}
+
+	global.Log.Info("Reminder occurrence completed", zap.String("userID", userID), zap.Int("reminderID", series.ID),
+		zap.String("occurrenceDate", date.Format(utils.DateLayout)))
+	return series, response.CodeSuccess
+}
+
+func (s *ReminderService) RescheduleReminders(ctx context.Context, userID string, location *time.Location) int {
+	reminders, err := s.reminderRepo.GetOpenReminders(ctx, userID)
+	if err != nil {
+		global.Log.Error("Error getting open reminders", zap.Error(err), zap.String("userID", userID))
+		return response.CodeFailedGetReminder
+	}
+
+	for _, reminder := range reminders {
+		dueAt, err := dueAtIn(reminder, reminder.DueDate, location)
+		if err != nil {
+			global.Log.Error("Error parsing reminder due time", zap.Error(err), zap.Int("reminderID", reminder.ID))
+			continue
+		}
+
+		updates := map[string]interface{}{
+			"due_at": dueAt,
+			"status": openReminderStatus(dueAt),
+		}
+		// The end of a series is an instant too
+		if reminder.RRule != nil {
+			rule, err := utils.ParseRRule(*reminder.RRule)
+			if err != nil {
+				global.Log.Error("Error parsing stored recurrence rule", zap.Error(err), zap.Int("reminderID", reminder.ID))
+				continue
+			}
+			updates["status"] = consts.ReminderStatus.PENDING
+			updates["series_end_at"] = seriesEndAt(rule, reminder.DueDate, reminder.DueTime, location)
+		}
+		if _, err := s.reminderRepo.UpdateReminder(ctx, userID, reminder.ID, updates); err != nil {
+			global.Log.Error("Error rescheduling reminder", zap.Error(err), zap.String("userID", userID), zap.Int("reminderID", reminder.ID))
+			return response.CodeFailedUpdateReminder
+		}
+	}
+
+	global.Log.Info("Reminders rescheduled", zap.String("userID", userID), zap.String("timezone", location.String()), zap.Int("count", len(reminders)))
+	return response.CodeSuccess
+}
+
+func (s *ReminderService) MarkOverdueReminders(ctx context.Context) int {
+	moved, err := s.reminderRepo.MarkOverdueReminders(ctx, time.Now())
+	if err != nil {
+		global.Log.Error("Error marking overdue reminders", zap.Error(err))
+		return 0
+	}
+	return int(moved)
+}
+
+// reminderSchedule is when a reminder is due in its stored forms
+type reminderSchedule struct {
+	date     time.Time // calendar date as midnight UTC
+	clock    string    // "15:04:05"
+	at       time.Time
+	rrule    *string // canonical recurrence rule
+	rule     *utils.RRule
+	endAt    *time.Time // when the last occurrence of a series is due, nil while it has no end
+	exDates  []models.ReminderExDate
+	location *time.Location // the user's timezone
+}
+
+// recur makes the schedule a series following rule
+func (d *reminderSchedule) recur(rule *utils.RRule) {
+	canonical := rule.String()
+	d.rrule = &canonical
+	d.rule = rule
+	d.endAt = seriesEndAt(rule, d.date, d.clock, d.location)
+}
+
+// continueAfter makes a series schedule continue one that kept occurrences before it. A COUNT counts
+// from the first occurrence of the whole series, the continuation only has what is left of it.
+// It reports false when nothing is left.
+func (d *reminderSchedule) continueAfter(kept int) bool {
+	if d.rule == nil || d.rule.Count == 0 {
+		return true
+	}
+
+	rest := *d.rule
+	rest.Count -= kept
+	if rest.Count < 1 {
+		return false
+	}
+	d.recur(&rest)
+	return true
+}
+
+// validateReminder checks the course belongs to the user and reads the due date and time in the user's timezone
+func (s *ReminderService) validateReminder(ctx context.Context, userID string, payload *models.ReminderRequest) (*reminderSchedule, int) {
+	if strings.TrimSpace(payload.Title) == "" {
+		return nil, response.CodeInvalidInput
+	}
+
+	if payload.CourseID != nil {
+		count, err := s.courseRepo.CountCourses(ctx, userID, []int{*payload.CourseID})
+		if err != nil {
+			global.Log.Error("Error counting courses", zap.Error(err), zap.String("userID", userID))
+			return nil, response.CodeFailedGetCourse
+		}
+		if count == 0 {
+			global.Log.Warn(errMessage.ErrCourseNotFound.Error(), zap.String("userID", userID), zap.Int("courseID", *payload.CourseID))
+			return nil, response.CodeCourseNotFound
+		}
+	}
+
+	location, code := getUserLocation(ctx, s.userRepo, userID)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+
+	date, err := utils.ParseDate(payload.DueDate)
+	if err != nil {
+		return nil, response.CodeInvalidInput
+	}
+	at, err := utils.ParseDateTimeIn(payload.DueDate, payload.DueTime, location)
+	if err != nil {
+		return nil, response.CodeInvalidInput
+	}
+
+	schedule := &reminderSchedule{date: date, clock: payload.DueTime + ":00", at: at, location: location}
+	if payload.RRule == nil || strings.TrimSpace(*payload.RRule) == "" {
+		// Only a series has dates to skip
+		if len(payload.ExDates) > 0 {
+			return nil, response.CodeInvalidInput
+		}
+		return schedule, response.CodeSuccess
+	}
+
+	rule, err := utils.ParseRRule(*payload.RRule)
+	if err != nil {
+		global.Log.Warn(errMessage.ErrInvalidRecurrenceRule.Error(), zap.String("userID", userID), zap.String("rrule", *payload.RRule))
+		return nil, response.CodeInvalidRecurrence
+	}
+	schedule.recur(rule)
+
+	seen := make(map[string]bool, len(payload.ExDates))
+	for _, value := range payload.ExDates {
+		exDate, err := utils.ParseDate(value)
+		if err != nil {
+			return nil, response.CodeInvalidInput
+		}
+		if !seen[value] {
+			seen[value] = true
+			schedule.exDates = append(schedule.exDates, models.ReminderExDate{Date: exDate})
+		}
+	}
+	return schedule, response.CodeSuccess
+}
+
+// occurrenceDate parses the date of an occurrence of a series and reports whether it is the first one
+func (s *ReminderService) occurrenceDate(series *models.Reminder, value string, location *time.Location) (time.Time, bool, int) {
+	date, err := utils.ParseDate(value)
+	if err != nil {
+		return time.Time{}, false, response.CodeInvalidInput
+	}
+
+	dates := seriesDates(series, location, date)
+	if len(dates) == 0 || !dates[len(dates)-1].Equal(date) {
+		global.Log.Warn(errMessage.ErrOccurrenceNotFound.Error(), zap.Int("reminderID", series.ID), zap.String("occurrenceDate", value))
+		return time.Time{}, false, response.CodeOccurrenceNotFound
+	}
+	return date, dates[0].Equal(date), response.CodeSuccess
+}
+
+// writeErrorCode maps constraint violations of a reminder insert or update to response codes
+func (s *ReminderService) writeErrorCode(err error, userID, message string) int {
+	// The course can be deleted between the ownership check and the write
+	if errors.Is(err, gorm.ErrForeignKeyViolated) {
+		global.Log.Warn(errMessage.ErrCourseNotFound.Error(), zap.String("userID", userID))
+		return response.CodeCourseNotFound
+	}
+
+	global.Log.Error(message, zap.Error(err), zap.String("userID", userID))
+	return response.CodeFailedUpdateReminder
+}
+
+// newReminder builds a reminder from a validated payload
+func newReminder(userID string, payload *models.ReminderRequest, due *reminderSchedule) *models.Reminder {
+	reminder := &models.Reminder{
+		Title:       strings.TrimSpace(payload.Title),
+		Description: payload.Description,
+		DueDate:     due.date,
+		DueTime:     due.clock,
+		DueAt:       due.at,
+		UserID:      userID,
+		CourseID:    payload.CourseID,
+		Type:        payload.Type,
+		Status:      openReminderStatus(due.at),
+		RRule:       due.rrule,
+		SeriesEndAt: due.endAt,
+		ExDates:     due.exDates,
+	}
+	// A series is pending for good, its occurrences have a status of their own
+	if reminder.RRule != nil {
+		reminder.Status = consts.ReminderStatus.PENDING
+	}
+	return reminder
+}
+
+// seriesEndAt is when the last occurrence of a series following rule from start at the wall clock
+// time dueTime is due, nil while it has no end. A series that seems endless is only expanded more.
+func seriesEndAt(rule *utils.RRule, start time.Time, dueTime string, location *time.Location) *time.Time {
+	clock, err := utils.WallClock(dueTime)
+	if err != nil {
+		return nil
+	}
+	last, ok := rule.LastDate(start, clock, location)
+	if !ok {
+		return nil
+	}
+	at, err := utils.ParseDateTimeIn(last.Format(utils.DateLayout), clock, location)
+	if err != nil {
+		return nil
+	}
+	return &at
+}
+
+// endSeriesBefore returns the rule of a stored series cut to end before date, when its last occurrence
+// is then due and how many occurrences, skipped dates included, it keeps
+func endSeriesBefore(series *models.Reminder, date time.Time, location *time.Location) (*utils.RRule, *time.Time, int, error) {
+	rule, err := utils.ParseRRule(*series.RRule)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	clock, err := utils.WallClock(series.DueTime)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	rule.EndBefore(date)
+	kept := len(rule.Dates(series.DueDate, clock, location, date))
+	return rule, seriesEndAt(rule, series.DueDate, series.DueTime, location), kept, nil
+}
+
+// seriesDates returns the dates of a recurring reminder up to last, without its exception dates
+func seriesDates(series *models.Reminder, location *time.Location, last time.Time) []time.Time {
+	rule, err := utils.ParseRRule(*series.RRule)
+	if err != nil {
+		global.Log.Error("Error parsing stored recurrence rule", zap.Error(err), zap.Int("reminderID", series.ID))
+		return nil
+	}
+
+	clock, err := utils.WallClock(series.DueTime)
+	if err != nil {
+		global.Log.Error("Error parsing reminder due time", zap.Error(err), zap.Int("reminderID", series.ID))
+		return nil
+	}
+
+	skipped := make(map[string]bool, len(series.ExDates))
+	for _, exDate := range series.ExDates {
+		skipped[exDate.Date.Format(utils.DateLayout)] = true
+	}
+
+	var dates []time.Time
+	for _, date := range rule.Dates(series.DueDate, clock, location, last) {
+		if !skipped[date.Format(utils.DateLayout)] {
+			dates = append(dates, date)
+		}
+	}
+	return dates
+}
+
+// occurrenceAt is the instant a series is due on date
+func occurrenceAt(series *models.Reminder, date time.Time, location *time.Location) time.Time {
+	at, err := dueAtIn(series, date, location)
+	if err != nil {
+		return date
+	}
+	return at
+}
+
+// dueAtIn is the instant a reminder is due on date in location, at its wall clock due time
+func dueAtIn(reminder *models.Reminder, date time.Time, location *time.Location) (time.Time, error) {
+	clock, err := utils.WallClock(reminder.DueTime)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return utils.ParseDateTimeIn(date.Format(utils.DateLayout), clock, location)
+}
+
+// occurrenceKey identifies an occurrence of a series
+func occurrenceKey(reminderID int, date time.Time) string {
+	return strconv.Itoa(reminderID) + "/" + date.Format(utils.DateLayout)
+}
+
+// openReminderStatus is the status of a reminder that is not completed
+func openReminderStatus(dueAt time.Time) int8 {
+	if dueAt.Before(time.Now()) {
+		return consts.ReminderStatus.OVERDUE
+	}
+	return consts.ReminderStatus.PENDING
+}
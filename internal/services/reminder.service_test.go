@@ -0,0 +1,123 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	"github.com/nas03/scholar-ai/backend/internal/utils"
+)
+
+func TestEndSeriesBefore(t *testing.T) {
+	saigon, err := time.LoadLocation("Asia/Ho_Chi_Minh")
+	if err != nil {
+		t.Skipf("timezone data: %v", err)
+	}
+	rrule := "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10"
+	series := &models.Reminder{
+		ID:      1,
+		DueDate: time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC),
+		DueTime: "09:30:00",
+		RRule:   &rrule,
+	}
+
+	rule, endAt, kept, err := endSeriesBefore(series, time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC), saigon)
+	if err != nil {
+		t.Fatalf("endSeriesBefore() error = %v", err)
+	}
+	if got, want := rule.String(), "FREQ=WEEKLY;BYDAY=MO,WE;UNTIL=20261013"; got != want {
+		t.Errorf("rule = %q, want %q", got, want)
+	}
+	// 10-05, 10-07 and 10-12 stay in the series
+	if kept != 3 {
+		t.Errorf("kept = %d, want 3", kept)
+	}
+	if want := time.Date(2026, 10, 12, 9, 30, 0, 0, saigon); endAt == nil || !endAt.Equal(want) {
+		t.Errorf("endAt = %v, want %v", endAt, want)
+	}
+}
+
+func TestEndSeriesBeforeRejectsBadDueTime(t *testing.T) {
+	rrule := "FREQ=DAILY"
+	series := &models.Reminder{ID: 1, DueDate: time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC), DueTime: "9", RRule: &rrule}
+
+	if _, _, _, err := endSeriesBefore(series, time.Date(2026, 10, 8, 0, 0, 0, 0, time.UTC), time.UTC); err == nil {
+		t.Error("endSeriesBefore() succeeded with a malformed due time")
+	}
+}
+
+func TestSeriesEndAt(t *testing.T) {
+	start := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		rule    string
+		dueTime string
+		want    *time.Time
+	}{
+		{rule: "FREQ=WEEKLY", dueTime: "09:30:00", want: nil},
+		{rule: "FREQ=WEEKLY;COUNT=3", dueTime: "09:30:00", want: ptrTime(time.Date(2026, 10, 19, 9, 30, 0, 0, time.UTC))},
+		{rule: "FREQ=DAILY;UNTIL=20261010", dueTime: "18:00", want: ptrTime(time.Date(2026, 10, 10, 18, 0, 0, 0, time.UTC))},
+		{rule: "FREQ=DAILY;COUNT=2", dueTime: "", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			rule, err := utils.ParseRRule(tt.rule)
+			if err != nil {
+				t.Fatalf("ParseRRule(%q) error = %v", tt.rule, err)
+			}
+			got := seriesEndAt(rule, start, tt.dueTime, time.UTC)
+			if (got == nil) != (tt.want == nil) || (got != nil && !got.Equal(*tt.want)) {
+				t.Errorf("seriesEndAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReminderScheduleContinueAfter(t *testing.T) {
+	schedule := func(rrule string) *reminderSchedule {
+		rule, err := utils.ParseRRule(rrule)
+		if err != nil {
+			t.Fatalf("ParseRRule(%q) error = %v", rrule, err)
+		}
+		due := &reminderSchedule{date: time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC), clock: "09:30:00", location: time.UTC}
+		due.recur(rule)
+		return due
+	}
+
+	tests := []struct {
+		rule      string
+		kept      int
+		ok        bool
+		wantRRule string
+		wantEnd   *time.Time
+	}{
+		// 10-14, 10-19, 10-21, 10-26, 10-28, 11-02, 11-04
+		{rule: "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10", kept: 3, ok: true, wantRRule: "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=7", wantEnd: ptrTime(time.Date(2026, 11, 4, 9, 30, 0, 0, time.UTC))},
+		{rule: "FREQ=WEEKLY;COUNT=4", kept: 3, ok: true, wantRRule: "FREQ=WEEKLY;COUNT=1", wantEnd: ptrTime(time.Date(2026, 10, 14, 9, 30, 0, 0, time.UTC))},
+		{rule: "FREQ=WEEKLY;COUNT=3", kept: 3, ok: false},
+		{rule: "FREQ=WEEKLY;UNTIL=20261104", kept: 3, ok: true, wantRRule: "FREQ=WEEKLY;UNTIL=20261104", wantEnd: ptrTime(time.Date(2026, 11, 4, 9, 30, 0, 0, time.UTC))},
+		{rule: "FREQ=WEEKLY", kept: 3, ok: true, wantRRule: "FREQ=WEEKLY"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			due := schedule(tt.rule)
+			if ok := due.continueAfter(tt.kept); ok != tt.ok {
+				t.Fatalf("continueAfter(%d) = %v, want %v", tt.kept, ok, tt.ok)
+			}
+			if !tt.ok {
+				return
+			}
+			if *due.rrule != tt.wantRRule {
+				t.Errorf("rrule = %q, want %q", *due.rrule, tt.wantRRule)
+			}
+			if (due.endAt == nil) != (tt.wantEnd == nil) || (due.endAt != nil && !due.endAt.Equal(*tt.wantEnd)) {
+				t.Errorf("endAt = %v, want %v", due.endAt, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
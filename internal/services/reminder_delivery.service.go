@@ -0,0 +1,355 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/consts"
+	"github.com/nas03/scholar-ai/backend/internal/helper"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	repo "github.com/nas03/scholar-ai/backend/internal/repositories"
+	"github.com/nas03/scholar-ai/backend/internal/utils"
+	"go.uber.org/zap"
+)
+
+type IReminderDeliveryService interface {
+	// ScheduleDeliveries plans a delivery per channel for reminders that reached a notification offset
+	// and returns how many were planned
+	ScheduleDeliveries(ctx context.Context) int
+	// DeliverDueNotifications sends due deliveries and returns how many were sent
+	DeliverDueNotifications(ctx context.Context) int
+}
+
+type ReminderDeliveryService struct {
+	deliveryRepo repo.IReminderDeliveryRepository
+	userRepo     repo.IUserRepository
+	channels     map[string]helper.IReminderChannel
+	cache        utils.IRedisCache
+}
+
+func NewReminderDeliveryService(
+	deliveryRepository repo.IReminderDeliveryRepository,
+	userRepository repo.IUserRepository,
+	channels map[string]helper.IReminderChannel,
+) IReminderDeliveryService {
+	return &ReminderDeliveryService{
+		deliveryRepo: deliveryRepository,
+		userRepo:     userRepository,
+		channels:     channels,
+		cache:        utils.NewRedisCache(),
+	}
+}
+
+// ScheduleDeliveries only plans the smallest offset a reminder has reached, so a reminder
+// created a day before it is due is notified once rather than for every larger offset too
+func (s *ReminderDeliveryService) ScheduleDeliveries(ctx context.Context) int {
+	now := time.Now()
+	planned := 0
+
+	var previous time.Duration
+	for _, offset := range reminderOffsets() {
+		offsetSeconds := int64(offset.Seconds())
+		reminders, err := s.deliveryRepo.GetRemindersToNotify(ctx, now.Add(previous), now.Add(offset), offsetSeconds)
+		previous = offset
+		if err != nil {
+			global.Log.Error("Error getting reminders to notify", zap.Error(err), zap.Duration("offset", offset))
+			continue
+		}
+
+		deliveries := make([]*models.ReminderDelivery, 0, len(reminders)*len(s.channels))
+		for _, reminder := range reminders {
+			for channel := range s.channels {
+				deliveries = append(deliveries, &models.ReminderDelivery{
+					ReminderID:    reminder.ID,
+					DueAt:         reminder.DueAt,
+					OffsetSeconds: offsetSeconds,
+					Channel:       channel,
+					Status:        consts.ReminderDeliveryStatus.PENDING,
+					NextAttemptAt: now,
+				})
+			}
+		}
+
+		inserted, err := s.deliveryRepo.CreateDeliveries(ctx, deliveries)
+		if err != nil {
+			global.Log.Error("Error creating reminder deliveries", zap.Error(err), zap.Duration("offset", offset))
+			continue
+		}
+		planned += int(inserted)
+	}
+
+	return planned + s.scheduleRecurringDeliveries(ctx, now)
+}
+
+// scheduleRecurringDeliveries plans the deliveries of series occurrences coming due within the largest offset.
+// Planning an occurrence again is a no-op thanks to the unique index.
+func (s *ReminderDeliveryService) scheduleRecurringDeliveries(ctx context.Context, now time.Time) int {
+	offsets := reminderOffsets()
+	if len(offsets) == 0 {
+		return 0
+	}
+	horizon := now.Add(offsets[len(offsets)-1])
+
+	series, err := s.deliveryRepo.GetRecurringReminders(ctx, now, horizon, now.AddDate(0, 0, -2))
+	if err != nil {
+		global.Log.Error("Error getting recurring reminders", zap.Error(err))
+		return 0
+	}
+
+	locations := make(map[string]*time.Location)
+	var deliveries []*models.ReminderDelivery
+	for _, reminder := range series {
+		location, ok := locations[reminder.UserID]
+		if !ok {
+			location = s.userLocation(ctx, reminder.UserID)
+			locations[reminder.UserID] = location
+		}
+
+		completed := make(map[string]bool, len(reminder.Completions))
+		for _, completion := range reminder.Completions {
+			completed[completion.OccurrenceDate.Format(utils.DateLayout)] = true
+		}
+
+		year, month, day := horizon.In(location).Date()
+		for _, date := range seriesDates(reminder, location, time.Date(year, month, day, 0, 0, 0, 0, time.UTC)) {
+			at := occurrenceAt(reminder, date, location)
+			if !at.After(now) || at.After(horizon) || completed[date.Format(utils.DateLayout)] {
+				continue
+			}
+
+			// The smallest offset the occurrence has reached, like for one-off reminders
+			var offset time.Duration
+			for _, candidate := range offsets {
+				if !at.After(now.Add(candidate)) {
+					offset = candidate
+					break
+				}
+			}
+
+			for channel := range s.channels {
+				deliveries = append(deliveries, &models.ReminderDelivery{
+					ReminderID:    reminder.ID,
+					DueAt:         at,
+					OffsetSeconds: int64(offset.Seconds()),
+					Channel:       channel,
+					Status:        consts.ReminderDeliveryStatus.PENDING,
+					NextAttemptAt: now,
+				})
+			}
+		}
+	}
+
+	inserted, err := s.deliveryRepo.CreateDeliveries(ctx, deliveries)
+	if err != nil {
+		global.Log.Error("Error creating recurring reminder deliveries", zap.Error(err))
+		return 0
+	}
+	return int(inserted)
+}
+
+// DeliverDueNotifications holds a Redis lock and claims the delivery in the database before sending,
+// so each notification is sent at most once even with several instances running
+func (s *ReminderDeliveryService) DeliverDueNotifications(ctx context.Context) int {
+	now := time.Now()
+
+	if stale, err := s.deliveryRepo.FailStaleDeliveries(ctx, now.Add(-consts.REMINDER_DELIVERY_STALE_AFTER)); err != nil {
+		global.Log.Error("Error failing stale reminder deliveries", zap.Error(err))
+	} else if stale > 0 {
+		global.Log.Warn("Gave up stale reminder deliveries", zap.Int64("count", stale))
+	}
+
+	deliveries, err := s.deliveryRepo.GetDueDeliveries(ctx, now, now.AddDate(0, 0, -2), consts.REMINDER_DELIVERY_BATCH_SIZE)
+	if err != nil {
+		global.Log.Error("Error getting due reminder deliveries", zap.Error(err))
+		return 0
+	}
+
+	sent := 0
+	for _, delivery := range deliveries {
+		if s.deliver(ctx, delivery) {
+			sent++
+		}
+	}
+	return sent
+}
+
+// deliver sends one delivery and reports whether it went out
+func (s *ReminderDeliveryService) deliver(ctx context.Context, delivery *models.ReminderDelivery) bool {
+	lockKey := fmt.Sprintf(consts.REDIS_KEY_REMINDER_DELIVERY_LOCK_PREFIX, delivery.ID)
+	token := uuid.NewString()
+
+	locked, err := s.cache.SetNX(ctx, lockKey, token, consts.REMINDER_DELIVERY_LOCK_TTL)
+	if err != nil {
+		global.Log.Error("Error locking reminder delivery", zap.Error(err), zap.Int("deliveryID", delivery.ID))
+		return false
+	}
+	if !locked {
+		return false
+	}
+	defer func() {
+		if err := s.cache.DelIfEqual(ctx, lockKey, token); err != nil {
+			global.Log.Error("Error unlocking reminder delivery", zap.Error(err), zap.Int("deliveryID", delivery.ID))
+		}
+	}()
+
+	claimed, err := s.deliveryRepo.ClaimDelivery(ctx, delivery.ID)
+	if err != nil {
+		global.Log.Error("Error claiming reminder delivery", zap.Error(err), zap.Int("deliveryID", delivery.ID))
+		return false
+	}
+	if !claimed {
+		return false
+	}
+
+	reminder := delivery.Reminder
+	channel, enabled := s.channels[delivery.Channel]
+	if reminder == nil || !enabled || reminder.Status != consts.ReminderStatus.PENDING {
+		s.cancelDelivery(ctx, delivery.ID)
+		return false
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, reminder.UserID)
+	if err != nil {
+		err = fmt.Errorf("failed to load user: %w", err)
+	} else {
+		location := locationOf(user)
+		// The reminder was moved, or the occurrence skipped or completed, since planning.
+		// A moved reminder gets a delivery of its own.
+		if !isPlannedOccurrence(delivery, location) {
+			s.cancelDelivery(ctx, delivery.ID)
+			return false
+		}
+
+		sendCtx, cancel := context.WithTimeout(ctx, consts.REMINDER_DELIVERY_SEND_TIMEOUT)
+		err = channel.Send(sendCtx, buildNotification(delivery, user, location))
+		cancel()
+	}
+
+	attempt := &models.ReminderDeliveryAttempt{
+		DeliveryID: delivery.ID,
+		Attempt:    delivery.Attempts + 1,
+		Status:     consts.ReminderDeliveryStatus.SENT,
+	}
+	updates := map[string]interface{}{
+		"attempts": attempt.Attempt,
+	}
+
+	if err == nil {
+		updates["status"] = consts.ReminderDeliveryStatus.SENT
+		updates["sent_at"] = time.Now()
+		updates["last_error"] = nil
+	} else {
+		message := err.Error()
+		attempt.Status = consts.ReminderDeliveryStatus.FAILED
+		attempt.Error = &message
+		updates["last_error"] = message
+
+		if attempt.Attempt >= reminderMaxAttempts() {
+			updates["status"] = consts.ReminderDeliveryStatus.FAILED
+		} else {
+			// Back off exponentially, the delivery is picked up again once due
+			updates["status"] = consts.ReminderDeliveryStatus.PENDING
+			updates["next_attempt_at"] = time.Now().Add(consts.REMINDER_DELIVERY_RETRY_BASE << (attempt.Attempt - 1))
+		}
+		global.Log.Warn("Reminder notification failed", zap.Error(err), zap.Int("deliveryID", delivery.ID),
+			zap.String("channel", delivery.Channel), zap.Int("attempt", attempt.Attempt))
+	}
+
+	if err := s.deliveryRepo.FinishDeliveryAttempt(ctx, attempt, updates); err != nil {
+		global.Log.Error("Error recording reminder delivery attempt", zap.Error(err), zap.Int("deliveryID", delivery.ID))
+	}
+	return attempt.Status == consts.ReminderDeliveryStatus.SENT
+}
+
+func (s *ReminderDeliveryService) cancelDelivery(ctx context.Context, id int) {
+	if err := s.deliveryRepo.UpdateDelivery(ctx, id, map[string]interface{}{"status": consts.ReminderDeliveryStatus.CANCELLED}); err != nil {
+		global.Log.Error("Error cancelling reminder delivery", zap.Error(err), zap.Int("deliveryID", id))
+	}
+}
+
+// userLocation loads the timezone of a reminder owner, UTC when it cannot be loaded
+func (s *ReminderDeliveryService) userLocation(ctx context.Context, userID string) *time.Location {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		global.Log.Error("Error getting user by ID", zap.Error(err), zap.String("userID", userID))
+		return time.UTC
+	}
+	return locationOf(user)
+}
+
+// locationOf returns the timezone of the user, UTC for a stale zone name
+func locationOf(user *models.User) *time.Location {
+	location, err := utils.LoadLocation(user.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// isPlannedOccurrence reports whether the reminder is still due when the delivery was planned for
+func isPlannedOccurrence(delivery *models.ReminderDelivery, location *time.Location) bool {
+	reminder := delivery.Reminder
+	if reminder.RRule == nil {
+		return reminder.DueAt.Equal(delivery.DueAt)
+	}
+
+	year, month, day := delivery.DueAt.In(location).Date()
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	for _, completion := range reminder.Completions {
+		if completion.OccurrenceDate.Equal(date) {
+			return false
+		}
+	}
+
+	dates := seriesDates(reminder, location, date)
+	return len(dates) > 0 && dates[len(dates)-1].Equal(date) && occurrenceAt(reminder, date, location).Equal(delivery.DueAt)
+}
+
+// buildNotification tells the user about the reminder of a delivery
+func buildNotification(delivery *models.ReminderDelivery, user *models.User, location *time.Location) *helper.ReminderNotification {
+	reminder := delivery.Reminder
+	notification := &helper.ReminderNotification{
+		ReminderID: reminder.ID,
+		UserID:     reminder.UserID,
+		Email:      user.Email,
+		Title:      reminder.Title,
+		DueAt:      delivery.DueAt,
+		Location:   location,
+		Offset:     time.Duration(delivery.OffsetSeconds) * time.Second,
+	}
+	if reminder.Description != nil {
+		notification.Description = *reminder.Description
+	}
+	if reminder.Course != nil {
+		notification.CourseName = reminder.Course.CourseName
+	}
+	return notification
+}
+
+// reminderOffsets returns the configured notification offsets, smallest first
+func reminderOffsets() []time.Duration {
+	offsets := global.Config.Reminder.Offsets
+	if len(offsets) == 0 {
+		offsets = consts.DEFAULT_REMINDER_OFFSETS
+	}
+
+	sorted := make([]time.Duration, 0, len(offsets))
+	for _, offset := range offsets {
+		if offset > 0 {
+			sorted = append(sorted, offset)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
+
+func reminderMaxAttempts() int {
+	if global.Config.Reminder.MaxAttempts > 0 {
+		return global.Config.Reminder.MaxAttempts
+	}
+	return consts.DEFAULT_REMINDER_MAX_ATTEMPTS
+}
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	repo "github.com/nas03/scholar-ai/backend/internal/repositories"
+	"github.com/nas03/scholar-ai/backend/internal/utils"
+	errMessage "github.com/nas03/scholar-ai/backend/pkg/errors"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type ISemesterService interface {
+	CreateSemester(ctx context.Context, userID string, payload *models.SemesterRequest) (*models.Semester, int)
+	GetSemester(ctx context.Context, userID string, id int) (*models.Semester, int)
+	GetSemesters(ctx context.Context, userID string) ([]*models.Semester, int)
+	UpdateSemester(ctx context.Context, userID string, id int, payload *models.SemesterRequest) (*models.Semester, int)
+	DeleteSemester(ctx context.Context, userID string, id int) int
+
+	// GetCurrentSemester returns the semester running on date ("YYYY-MM-DD"),
+	// today in the user's timezone when date is empty
+	GetCurrentSemester(ctx context.Context, userID, date string) (*models.Semester, int)
+}
+
+type SemesterService struct {
+	semesterRepo repo.ISemesterRepository
+	userRepo     repo.IUserRepository
+}
+
+func NewSemesterService(semesterRepository repo.ISemesterRepository, userRepository repo.IUserRepository) ISemesterService {
+	return &SemesterService{
+		semesterRepo: semesterRepository,
+		userRepo:     userRepository,
+	}
+}
+
+func (s *SemesterService) CreateSemester(ctx context.Context, userID string, payload *models.SemesterRequest) (*models.Semester, int) {
+	startDate, endDate, code := s.validateSemester(ctx, userID, 0, payload)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+
+	semester := &models.Semester{
+		Name:      strings.TrimSpace(payload.Name),
+		StartDate: startDate,
+		EndDate:   endDate,
+		UserID:    userID,
+	}
+	if err := s.semesterRepo.CreateSemester(ctx, semester); err != nil {
+		global.Log.Error("Error creating semester", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedUpdateSemester
+	}
+
+	global.Log.Info("Semester created", zap.String("userID", userID), zap.Int("semesterID", semester.ID))
+	return semester, response.CodeSuccess
+}
+
+func (s *SemesterService) GetSemester(ctx context.Context, userID string, id int) (*models.Semester, int) {
+	semester, err := s.semesterRepo.GetSemesterByID(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Log.Warn(errMessage.ErrSemesterNotFound.Error(), zap.String("userID", userID), zap.Int("semesterID", id))
+			return nil, response.CodeSemesterNotFound
+		}
+
+		global.Log.Error("Error getting semester by ID", zap.Error(err), zap.String("userID", userID), zap.Int("semesterID", id))
+		return nil, response.CodeFailedGetSemester
+	}
+	return semester, response.CodeSuccess
+}
+
+func (s *SemesterService) GetSemesters(ctx context.Context, userID string) ([]*models.Semester, int) {
+	semesters, err := s.semesterRepo.GetSemesters(ctx, userID)
+	if err != nil {
+		global.Log.Error("Error getting semesters", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedGetSemester
+	}
+	return semesters, response.CodeSuccess
+}
+
+func (s *SemesterService) UpdateSemester(ctx context.Context, userID string, id int, payload *models.SemesterRequest) (*models.Semester, int) {
+	if _, code := s.GetSemester(ctx, userID, id); code != response.CodeSuccess {
+		return nil, code
+	}
+
+	startDate, endDate, code := s.validateSemester(ctx, userID, id, payload)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+
+	updates := map[string]interface{}{
+		"name":       strings.TrimSpace(payload.Name),
+		"start_date": startDate,
+		"end_date":   endDate,
+	}
+	if _, err := s.semesterRepo.UpdateSemester(ctx, userID, id, updates); err != nil {
+		global.Log.Error("Error updating semester", zap.Error(err), zap.String("userID", userID), zap.Int("semesterID", id))
+		return nil, response.CodeFailedUpdateSemester
+	}
+
+	global.Log.Info("Semester updated", zap.String("userID", userID), zap.Int("semesterID", id))
+	return s.GetSemester(ctx, userID, id)
+}
+
+func (s *SemesterService) DeleteSemester(ctx context.Context, userID string, id int) int {
+	rowsAffected, err := s.semesterRepo.DeleteSemester(ctx, userID, id)
+	if err != nil {
+		// courses reference semesters with ON DELETE RESTRICT
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			global.Log.Warn(errMessage.ErrSemesterHasCourses.Error(), zap.String("userID", userID), zap.Int("semesterID", id))
+			return response.CodeSemesterHasCourses
+		}
+
+		global.Log.Error("Error deleting semester", zap.Error(err), zap.String("userID", userID), zap.Int("semesterID", id))
+		return response.CodeFailedUpdateSemester
+	}
+	if rowsAffected == 0 {
+		global.Log.Warn(errMessage.ErrSemesterNotFound.Error(), zap.String("userID", userID), zap.Int("semesterID", id))
+		return response.CodeSemesterNotFound
+	}
+
+	global.Log.Info("Semester deleted", zap.String("userID", userID), zap.Int("semesterID", id))
+	return response.CodeSuccess
+}
+
+func (s *SemesterService) GetCurrentSemester(ctx context.Context, userID, date string) (*models.Semester, int) {
+	var day time.Time
+	if date != "" {
+		parsed, err := utils.ParseDate(date)
+		if err != nil {
+			return nil, response.CodeInvalidInput
+		}
+		day = parsed
+	} else {
+		location, code := getUserLocation(ctx, s.userRepo, userID)
+		if code != response.CodeSuccess {
+			return nil, code
+		}
+		day = utils.TodayIn(location)
+	}
+
+	semester, err := s.semesterRepo.GetSemesterByDate(ctx, userID, day)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Log.Warn(errMessage.ErrSemesterNotFound.Error(), zap.String("userID", userID), zap.Time("date", day))
+			return nil, response.CodeSemesterNotFound
+		}
+
+		global.Log.Error("Error getting semester by date", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedGetSemester
+	}
+	return semester, response.CodeSuccess
+}
+
+// validateSemester parses the date range and makes sure it neither runs backwards
+// nor overlaps another semester of the user, excludeID being the semester under update
+func (s *SemesterService) validateSemester(ctx context.Context, userID string, excludeID int, payload *models.SemesterRequest) (time.Time, time.Time, int) {
+	if strings.TrimSpace(payload.Name) == "" {
+		return time.Time{}, time.Time{}, response.CodeInvalidInput
+	}
+
+	startDate, err := utils.ParseDate(payload.StartDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, response.CodeInvalidInput
+	}
+	endDate, err := utils.ParseDate(payload.EndDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, response.CodeInvalidInput
+	}
+	if endDate.Before(startDate) {
+		global.Log.Warn(errMessage.ErrInvalidSemesterDate.Error(), zap.String("userID", userID))
+		return time.Time{}, time.Time{}, response.CodeInvalidSemesterDates
+	}
+
+	overlapping, err := s.semesterRepo.CountOverlappingSemesters(ctx, userID, startDate, endDate, excludeID)
+	if err != nil {
+		global.Log.Error("Error checking overlapping semesters", zap.Error(err), zap.String("userID", userID))
+		return time.Time{}, time.Time{}, response.CodeFailedGetSemester
+	}
+	if overlapping > 0 {
+		global.Log.Warn(errMessage.ErrSemesterOverlap.Error(), zap.String("userID", userID), zap.Int64("overlapping", overlapping))
+		return time.Time{}, time.Time{}, response.CodeSemesterOverlap
+	}
+
+	return startDate, endDate, response.CodeSuccess
+}
@@ -0,0 +1,410 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/consts"
+	"github.com/nas03/scholar-ai/backend/internal/helper"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	repo "github.com/nas03/scholar-ai/backend/internal/repositories"
+	"github.com/nas03/scholar-ai/backend/internal/utils"
+	errMessage "github.com/nas03/scholar-ai/backend/pkg/errors"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// ssoTokenBytes is the entropy of the state, nonce and PKCE verifier
+const ssoTokenBytes = 32
+
+// ssoUsernameAttempts is how many random suffixes are tried for a taken username
+const ssoUsernameAttempts = 5
+
+type ISsoService interface {
+	GetProviders() []string
+	// Authorize starts the authorization code flow. userID is set when a logged-in user links the provider.
+	Authorize(ctx context.Context, provider, userID string) (string, int)
+	// Callback signs in with the provider account, creating or linking the user on first sign-in
+	Callback(ctx context.Context, provider, code, state, userAgent, ipAddress string) (*models.LoginResult, int)
+	Link(ctx context.Context, userID, provider, code, state string) (*models.UserIdentity, int)
+	GetIdentities(ctx context.Context, userID string) ([]*models.UserIdentity, int)
+	Unlink(ctx context.Context, userID, provider string) int
+}
+
+type SsoService struct {
+	userRepo     repo.IUserRepository
+	identityRepo repo.IIdentityRepository
+	authService  IAuthService
+	providers    map[string]helper.IOidcProvider
+}
+
+func NewSsoService(
+	userRepository repo.IUserRepository,
+	identityRepository repo.IIdentityRepository,
+	authService IAuthService,
+	providers map[string]helper.IOidcProvider,
+) ISsoService {
+	return &SsoService{
+		userRepo:     userRepository,
+		identityRepo: identityRepository,
+		authService:  authService,
+		providers:    providers,
+	}
+}
+
+func (s *SsoService) GetProviders() []string {
+	names := make([]string, 0, len(s.providers))
+	for name := range s.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s *SsoService) Authorize(ctx context.Context, provider, userID string) (string, int) {
+	oidcProvider, ok := s.providers[provider]
+	if !ok {
+		global.Log.Warn(errMessage.ErrSsoProviderNotFound.Error(), zap.String("provider", provider))
+		return "", response.CodeSsoProviderNotFound
+	}
+
+	tokens := make([]string, 3)
+	for i := range tokens {
+		token, err := utils.GenerateOpaqueToken(ssoTokenBytes)
+		if err != nil {
+			global.Log.Error("Error generating sso state", zap.Error(err))
+			return "", response.CodeLoginInternalError
+		}
+		tokens[i] = token
+	}
+	state, nonce, codeVerifier := tokens[0], tokens[1], tokens[2]
+
+	data, err := json.Marshal(models.SsoState{
+		Provider:     provider,
+		CodeVerifier: codeVerifier,
+		Nonce:        nonce,
+		UserID:       userID,
+	})
+	if err != nil {
+		global.Log.Error("Error encoding sso state", zap.Error(err))
+		return "", response.CodeLoginInternalError
+	}
+
+	key := fmt.Sprintf(consts.REDIS_KEY_AUTH_SSO_STATE_PREFIX, utils.HashToken(state))
+	if err := utils.NewRedisCache().SetEx(ctx, key, string(data), consts.REDIS_SSO_STATE_EXPIRATION); err != nil {
+		global.Log.Error("Failed to store sso state", zap.Error(err), zap.String("provider", provider))
+		return "", response.CodeLoginInternalError
+	}
+
+	authURL, err := oidcProvider.AuthCodeURL(ctx, state, nonce, codeVerifier)
+	if err != nil {
+		global.Log.Error("Error building sso authorization url", zap.Error(err), zap.String("provider", provider))
+		return "", response.CodeSsoFailed
+	}
+	return authURL, response.CodeSuccess
+}
+
+func (s *SsoService) Callback(ctx context.Context, provider, code, state, userAgent, ipAddress string) (*models.LoginResult, int) {
+	claims, resCode := s.exchange(ctx, provider, code, state, "")
+	if resCode != response.CodeSuccess {
+		return nil, resCode
+	}
+
+	identity, err := s.identityRepo.GetIdentityBySubject(ctx, provider, claims.Subject)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		global.Log.Error("Error getting sso identity", zap.Error(err), zap.String("provider", provider))
+		return nil, response.CodeLoginInternalError
+	}
+
+	var user *models.User
+	if identity != nil {
+		user, err = s.userRepo.GetUserByID(ctx, identity.UserID)
+		if err != nil {
+			global.Log.Error("Error getting user of sso identity", zap.Error(err), zap.String("userID", identity.UserID))
+			return nil, response.CodeLoginInternalError
+		}
+	} else {
+		user, resCode = s.onboardUser(ctx, provider, claims)
+		if resCode != response.CodeSuccess {
+			return nil, resCode
+		}
+	}
+
+	global.Log.Info("Signed in with sso provider", zap.String("userID", user.UserID), zap.String("provider", provider))
+	return s.authService.LoginWithUser(ctx, user, userAgent, ipAddress)
+}
+
+func (s *SsoService) Link(ctx context.Context, userID, provider, code, state string) (*models.UserIdentity, int) {
+	claims, resCode := s.exchange(ctx, provider, code, state, userID)
+	if resCode != response.CodeSuccess {
+		return nil, resCode
+	}
+
+	identity := &models.UserIdentity{
+		IdentityID: uuid.NewString(),
+		UserID:     userID,
+		Provider:   provider,
+		Subject:    claims.Subject,
+		Email:      claims.Email,
+	}
+	if resCode := s.identityError(s.identityRepo.CreateIdentity(ctx, identity), identity); resCode != response.CodeSuccess {
+		return nil, resCode
+	}
+
+	global.Log.Info("Linked sso provider", zap.String("userID", userID), zap.String("provider", provider))
+	return identity, response.CodeSuccess
+}
+
+func (s *SsoService) GetIdentities(ctx context.Context, userID string) ([]*models.UserIdentity, int) {
+	identities, err := s.identityRepo.GetUserIdentities(ctx, userID)
+	if err != nil {
+		global.Log.Error("Error getting sso identities", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedGetUser
+	}
+	return identities, response.CodeSuccess
+}
+
+// Unlink removes a provider. The account stays reachable through the password reset flow,
+// even for users created by sso who never chose a password.
+func (s *SsoService) Unlink(ctx context.Context, userID, provider string) int {
+	deleted, err := s.identityRepo.DeleteIdentity(ctx, userID, provider)
+	if err != nil {
+		global.Log.Error("Error unlinking sso provider", zap.Error(err), zap.String("userID", userID))
+		return response.CodeFailedUpdateUser
+	}
+	if deleted == 0 {
+		global.Log.Warn(errMessage.ErrIdentityNotFound.Error(), zap.String("userID", userID), zap.String("provider", provider))
+		return response.CodeIdentityNotFound
+	}
+
+	global.Log.Info("Unlinked sso provider", zap.String("userID", userID), zap.String("provider", provider))
+	return response.CodeSuccess
+}
+
+// exchange consumes the state, checks it belongs to the same provider and user, and redeems the code
+func (s *SsoService) exchange(ctx context.Context, provider, code, state, userID string) (*helper.OidcClaims, int) {
+	oidcProvider, ok := s.providers[provider]
+	if !ok {
+		global.Log.Warn(errMessage.ErrSsoProviderNotFound.Error(), zap.String("provider", provider))
+		return nil, response.CodeSsoProviderNotFound
+	}
+
+	// The state is single-use so a leaked callback url cannot be replayed
+	key := fmt.Sprintf(consts.REDIS_KEY_AUTH_SSO_STATE_PREFIX, utils.HashToken(state))
+	data, err := utils.NewRedisCache().GetDel(ctx, key)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			global.Log.Warn(errMessage.ErrInvalidSsoState.Error(), zap.String("provider", provider))
+			return nil, response.CodeInvalidSsoState
+		}
+
+		global.Log.Error("Failed to get sso state", zap.Error(err))
+		return nil, response.CodeLoginInternalError
+	}
+
+	var ssoState models.SsoState
+	if err := json.Unmarshal([]byte(data), &ssoState); err != nil {
+		global.Log.Error("Error decoding sso state", zap.Error(err))
+		return nil, response.CodeInvalidSsoState
+	}
+	if ssoState.Provider != provider || ssoState.UserID != userID {
+		global.Log.Warn(errMessage.ErrInvalidSsoState.Error(), zap.String("provider", provider), zap.String("userID", userID))
+		return nil, response.CodeInvalidSsoState
+	}
+
+	claims, err := oidcProvider.Exchange(ctx, code, ssoState.CodeVerifier, ssoState.Nonce)
+	if err != nil {
+		global.Log.Warn("Error exchanging sso authorization code", zap.Error(err), zap.String("provider", provider))
+		return nil, response.CodeSsoFailed
+	}
+	if claims.Subject == "" {
+		global.Log.Warn("Sso id token has no subject", zap.String("provider", provider))
+		return nil, response.CodeSsoFailed
+	}
+	return claims, response.CodeSuccess
+}
+
+// onboardUser links the provider account to the user with the same verified email,
+// or creates a new user from the provider claims
+func (s *SsoService) onboardUser(ctx context.Context, provider string, claims *helper.OidcClaims) (*models.User, int) {
+	if !claims.EmailVerified {
+		global.Log.Warn(errMessage.ErrSsoEmailNotVerified.Error(), zap.String("provider", provider))
+		return nil, response.CodeSsoEmailNotVerified
+	}
+	email := strings.ToLower(strings.TrimSpace(claims.Email))
+
+	identity := &models.UserIdentity{
+		IdentityID: uuid.NewString(),
+		Provider:   provider,
+		Subject:    claims.Subject,
+		Email:      email,
+	}
+
+	user, err := s.userRepo.GetUserByEmail(ctx, email)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		global.Log.Error("Error getting user by email", zap.Error(err), zap.String("email", email))
+		return nil, response.CodeLoginInternalError
+	}
+	if user != nil {
+		return s.linkExistingUser(ctx, user, identity)
+	}
+
+	return s.createUser(ctx, claims, identity)
+}
+
+func (s *SsoService) linkExistingUser(ctx context.Context, user *models.User, identity *models.UserIdentity) (*models.User, int) {
+	identity.UserID = user.UserID
+
+	err := s.userRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		// Whoever registered an unverified account never proved the email, so the password
+		// they chose is replaced before the provider owner takes the account over
+		if user.IsEmailVerified != 1 {
+			password, err := randomPasswordHash()
+			if err != nil {
+				return err
+			}
+			updates := map[string]interface{}{
+				"password":          password,
+				"is_email_verified": 1,
+				"account_status":    consts.UserAccountStatus.ACTIVE,
+			}
+			if err := tx.Model(&models.User{}).Where("user_id = ?", user.UserID).Updates(updates).Error; err != nil {
+				return err
+			}
+			user.IsEmailVerified = 1
+			user.AccountStatus = consts.UserAccountStatus.ACTIVE
+		}
+		return tx.Create(identity).Error
+	})
+	if resCode := s.identityError(err, identity); resCode != response.CodeSuccess {
+		return nil, resCode
+	}
+
+	global.Log.Info("Linked sso provider by verified email", zap.String("userID", user.UserID), zap.String("provider", identity.Provider))
+	return user, response.CodeSuccess
+}
+
+func (s *SsoService) createUser(ctx context.Context, claims *helper.OidcClaims, identity *models.UserIdentity) (*models.User, int) {
+	userUUID, err := uuid.NewRandom()
+	if err != nil {
+		global.Log.Error("Error creating UUID", zap.Error(err))
+		return nil, response.CodeRegisterInternalError
+	}
+
+	username, err := s.generateUsername(ctx, claims)
+	if err != nil {
+		global.Log.Error("Error generating username", zap.Error(err), zap.String("email", identity.Email))
+		return nil, response.CodeRegisterInternalError
+	}
+
+	// Sso users sign in through the provider, the password reset flow lets them choose one later
+	password, err := randomPasswordHash()
+	if err != nil {
+		global.Log.Error("Error generating hashedPassword", zap.Error(err))
+		return nil, response.CodeRegisterInternalError
+	}
+
+	user := &models.User{
+		UserID:          userUUID.String(),
+		Username:        username,
+		Email:           identity.Email,
+		Password:        password,
+		AccountStatus:   consts.UserAccountStatus.ACTIVE,
+		IsEmailVerified: 1,
+	}
+	identity.UserID = user.UserID
+
+	err = s.userRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		return tx.Create(identity).Error
+	})
+	if err != nil {
+		// Another callback for the same email or username won the race
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			global.Log.Warn(errMessage.ErrUserAlreadyExists.Error(), zap.String("email", user.Email), zap.String("username", username))
+			return nil, response.CodeUserAlreadyExists
+		}
+
+		global.Log.Error("Error creating new user from sso", zap.Error(err))
+		return nil, response.CodeRegisterInternalError
+	}
+
+	global.Log.Info("Success creating new user from sso", zap.String("userID", user.UserID), zap.String("provider", identity.Provider))
+	return user, response.CodeSuccess
+}
+
+// identityError maps the error of storing an identity to a response code
+func (s *SsoService) identityError(err error, identity *models.UserIdentity) int {
+	if err == nil {
+		return response.CodeSuccess
+	}
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		global.Log.Warn(errMessage.ErrIdentityLinked.Error(), zap.String("userID", identity.UserID), zap.String("provider", identity.Provider))
+		return response.CodeIdentityLinked
+	}
+
+	global.Log.Error("Error storing sso identity", zap.Error(err), zap.String("userID", identity.UserID), zap.String("provider", identity.Provider))
+	return response.CodeLoginInternalError
+}
+
+// generateUsername derives a free username from the provider claims, adding a random suffix when taken
+func (s *SsoService) generateUsername(ctx context.Context, claims *helper.OidcClaims) (string, error) {
+	base := sanitizeUsername(claims.PreferredUsername)
+	if base == "" {
+		base = sanitizeUsername(strings.Split(claims.Email, "@")[0])
+	}
+	if base == "" {
+		base = "user"
+	}
+
+	candidate := base
+	for range ssoUsernameAttempts {
+		_, err := s.userRepo.GetUserByUsername(ctx, candidate)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return candidate, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		candidate = fmt.Sprintf("%s%04d", base, utils.GenerateSixDigitOtp()%10000)
+	}
+	return "", errMessage.ErrUserAlreadyExists
+}
+
+// sanitizeUsername keeps lowercase letters, digits, dots and underscores
+func sanitizeUsername(value string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(value) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '.' || r == '_' {
+			b.WriteRune(r)
+		}
+		if b.Len() >= consts.SSO_USERNAME_MAX_LENGTH {
+			break
+		}
+	}
+	return strings.Trim(b.String(), "._")
+}
+
+// randomPasswordHash returns the hash of a password nobody knows
+func randomPasswordHash() (string, error) {
+	password, err := utils.GenerateOpaqueToken(ssoTokenBytes)
+	if err != nil {
+		return "", err
+	}
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hashedPassword), nil
+}
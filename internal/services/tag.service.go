@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/consts"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	repo "github.com/nas03/scholar-ai/backend/internal/repositories"
+	errMessage "github.com/nas03/scholar-ai/backend/pkg/errors"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type ITagService interface {
+	CreateTag(ctx context.Context, userID string, payload *models.TagRequest) (*models.Tag, int)
+	GetTag(ctx context.Context, userID string, id int) (*models.Tag, int)
+	GetTags(ctx context.Context, userID string) ([]*models.Tag, int)
+	UpdateTag(ctx context.Context, userID string, id int, payload *models.TagRequest) (*models.Tag, int)
+	DeleteTag(ctx context.Context, userID string, id int) int
+
+	// AttachTags and DetachTags apply every tag of the payload to every course of it
+	AttachTags(ctx context.Context, userID string, payload *models.TagAssignmentRequest) int
+	DetachTags(ctx context.Context, userID string, payload *models.TagAssignmentRequest) int
+}
+
+type TagService struct {
+	tagRepo    repo.ITagRepository
+	courseRepo repo.ICourseRepository
+}
+
+func NewTagService(tagRepository repo.ITagRepository, courseRepository repo.ICourseRepository) ITagService {
+	return &TagService{
+		tagRepo:    tagRepository,
+		courseRepo: courseRepository,
+	}
+}
+
+func (s *TagService) CreateTag(ctx context.Context, userID string, payload *models.TagRequest) (*models.Tag, int) {
+	name := strings.TrimSpace(payload.Name)
+	if name == "" {
+		return nil, response.CodeInvalidInput
+	}
+
+	tag := &models.Tag{
+		Name:   name,
+		Color:  tagColor(payload.Color),
+		UserID: userID,
+	}
+	if err := s.tagRepo.CreateTag(ctx, tag); err != nil {
+		return nil, s.writeErrorCode(err, userID, "Error creating tag")
+	}
+
+	global.Log.Info("Tag created", zap.String("userID", userID), zap.Int("tagID", tag.ID))
+	return tag, response.CodeSuccess
+}
+
+func (s *TagService) GetTag(ctx context.Context, userID string, id int) (*models.Tag, int) {
+	tag, err := s.tagRepo.GetTagByID(ctx, userID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Log.Warn(errMessage.ErrTagNotFound.Error(), zap.String("userID", userID), zap.Int("tagID", id))
+			return nil, response.CodeTagNotFound
+		}
+
+		global.Log.Error("Error getting tag by ID", zap.Error(err), zap.String("userID", userID), zap.Int("tagID", id))
+		return nil, response.CodeFailedGetTag
+	}
+	return tag, response.CodeSuccess
+}
+
+func (s *TagService) GetTags(ctx context.Context, userID string) ([]*models.Tag, int) {
+	tags, err := s.tagRepo.GetTags(ctx, userID)
+	if err != nil {
+		global.Log.Error("Error getting tags", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedGetTag
+	}
+	return tags, response.CodeSuccess
+}
+
+func (s *TagService) UpdateTag(ctx context.Context, userID string, id int, payload *models.TagRequest) (*models.Tag, int) {
+	name := strings.TrimSpace(payload.Name)
+	if name == "" {
+		return nil, response.CodeInvalidInput
+	}
+
+	updates := map[string]interface{}{
+		"name":  name,
+		"color": tagColor(payload.Color),
+	}
+	if _, err := s.tagRepo.UpdateTag(ctx, userID, id, updates); err != nil {
+		return nil, s.writeErrorCode(err, userID, "Error updating tag")
+	}
+
+	// MySQL reports unchanged rows as unaffected, the lookup tells a missing tag apart
+	return s.GetTag(ctx, userID, id)
+}
+
+func (s *TagService) DeleteTag(ctx context.Context, userID string, id int) int {
+	rowsAffected, err := s.tagRepo.DeleteTag(ctx, userID, id)
+	if err != nil {
+		global.Log.Error("Error deleting tag", zap.Error(err), zap.String("userID", userID), zap.Int("tagID", id))
+		return response.CodeFailedUpdateTag
+	}
+	if rowsAffected == 0 {
+		global.Log.Warn(errMessage.ErrTagNotFound.Error(), zap.String("userID", userID), zap.Int("tagID", id))
+		return response.CodeTagNotFound
+	}
+
+	global.Log.Info("Tag deleted", zap.String("userID", userID), zap.Int("tagID", id))
+	return response.CodeSuccess
+}
+
+func (s *TagService) AttachTags(ctx context.Context, userID string, payload *models.TagAssignmentRequest) int {
+	courseIDs, tagIDs, code := s.checkAssignment(ctx, userID, payload)
+	if code != response.CodeSuccess {
+		return code
+	}
+
+	if err := s.tagRepo.AttachTags(ctx, courseIDs, tagIDs); err != nil {
+		global.Log.Error("Error attaching tags", zap.Error(err), zap.String("userID", userID))
+		return response.CodeFailedUpdateTag
+	}
+
+	global.Log.Info("Tags attached", zap.String("userID", userID), zap.Ints("courseIDs", courseIDs), zap.Ints("tagIDs", tagIDs))
+	return response.CodeSuccess
+}
+
+func (s *TagService) DetachTags(ctx context.Context, userID string, payload *models.TagAssignmentRequest) int {
+	courseIDs, tagIDs, code := s.checkAssignment(ctx, userID, payload)
+	if code != response.CodeSuccess {
+		return code
+	}
+
+	rowsAffected, err := s.tagRepo.DetachTags(ctx, courseIDs, tagIDs)
+	if err != nil {
+		global.Log.Error("Error detaching tags", zap.Error(err), zap.String("userID", userID))
+		return response.CodeFailedUpdateTag
+	}
+
+	global.Log.Info("Tags detached", zap.String("userID", userID), zap.Int64("links", rowsAffected))
+	return response.CodeSuccess
+}
+
+// checkAssignment deduplicates the ids and makes sure every course and tag belongs to the user
+func (s *TagService) checkAssignment(ctx context.Context, userID string, payload *models.TagAssignmentRequest) ([]int, []int, int) {
+	courseIDs := uniqueIDs(payload.CourseIDs)
+	tagIDs := uniqueIDs(payload.TagIDs)
+
+	courseCount, err := s.courseRepo.CountCourses(ctx, userID, courseIDs)
+	if err != nil {
+		global.Log.Error("Error counting courses", zap.Error(err), zap.String("userID", userID))
+		return nil, nil, response.CodeFailedGetCourse
+	}
+	if courseCount != int64(len(courseIDs)) {
+		global.Log.Warn(errMessage.ErrCourseNotFound.Error(), zap.String("userID", userID), zap.Ints("courseIDs", courseIDs))
+		return nil, nil, response.CodeCourseNotFound
+	}
+
+	tagCount, err := s.tagRepo.CountTags(ctx, userID, tagIDs)
+	if err != nil {
+		global.Log.Error("Error counting tags", zap.Error(err), zap.String("userID", userID))
+		return nil, nil, response.CodeFailedGetTag
+	}
+	if tagCount != int64(len(tagIDs)) {
+		global.Log.Warn(errMessage.ErrTagNotFound.Error(), zap.String("userID", userID), zap.Ints("tagIDs", tagIDs))
+		return nil, nil, response.CodeTagNotFound
+	}
+
+	return courseIDs, tagIDs, response.CodeSuccess
+}
+
+// writeErrorCode maps constraint violations of a tag insert or update to response codes
+func (s *TagService) writeErrorCode(err error, userID, message string) int {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		global.Log.Warn(errMessage.ErrTagExists.Error(), zap.String("userID", userID))
+		return response.CodeTagAlreadyExists
+	}
+
+	global.Log.Error(message, zap.Error(err), zap.String("userID", userID))
+	return response.CodeFailedUpdateTag
+}
+
+// tagColor normalizes a hex color, falling back to the default grey
+func tagColor(color string) string {
+	if color == "" {
+		return consts.TAG_DEFAULT_COLOR
+	}
+	return strings.ToUpper(color)
+}
@@ -0,0 +1,278 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/png"
+	"strings"
+
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/consts"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	repo "github.com/nas03/scholar-ai/backend/internal/repositories"
+	"github.com/nas03/scholar-ai/backend/internal/utils"
+	errMessage "github.com/nas03/scholar-ai/backend/pkg/errors"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+	"github.com/pquerna/otp/totp"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// totpQRCodeSize is the width and height of the enrolment QR code in pixels
+const totpQRCodeSize = 256
+
+type ITwoFactorService interface {
+	Enroll(ctx context.Context, userID string) (*models.TotpEnrollment, int)
+	Confirm(ctx context.Context, userID, code string) ([]string, int)
+	Disable(ctx context.Context, userID, password string) int
+	// VerifyCode checks a totp code, or consumes a recovery code when code is empty
+	VerifyCode(ctx context.Context, user *models.User, code, recoveryCode string) int
+}
+
+type TwoFactorService struct {
+	userRepo repo.IUserRepository
+}
+
+func NewTwoFactorService(userRepository repo.IUserRepository) ITwoFactorService {
+	return &TwoFactorService{
+		userRepo: userRepository,
+	}
+}
+
+// Enroll generates a new secret that stays pending until confirmed with a first code
+func (s *TwoFactorService) Enroll(ctx context.Context, userID string) (*models.TotpEnrollment, int) {
+	user, code := s.getUser(ctx, userID)
+	if code != response.CodeSuccess {
+		return nil, code
+	}
+	if user.IsTotpEnabled == 1 {
+		global.Log.Warn(errMessage.ErrTwoFactorEnabled.Error(), zap.String("userID", userID))
+		return nil, response.CodeTwoFactorEnabled
+	}
+
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      consts.TOTP_ISSUER,
+		AccountName: user.Email,
+	})
+	if err != nil {
+		global.Log.Error("Error generating totp secret", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedUpdateUser
+	}
+
+	img, err := key.Image(totpQRCodeSize, totpQRCodeSize)
+	if err != nil {
+		global.Log.Error("Error generating totp qr code", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedUpdateUser
+	}
+	var qrCode bytes.Buffer
+	if err := png.Encode(&qrCode, img); err != nil {
+		global.Log.Error("Error encoding totp qr code", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedUpdateUser
+	}
+
+	secret := sql.NullString{String: key.Secret(), Valid: true}
+	if err := s.userRepo.UpdateUserTotp(ctx, userID, secret, 0, sql.NullString{}); err != nil {
+		global.Log.Error("Error storing totp secret", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedUpdateUser
+	}
+
+	global.Log.Info("Two-factor enrolment started", zap.String("userID", userID))
+	return &models.TotpEnrollment{
+		Secret:     key.Secret(),
+		OtpauthURL: key.URL(),
+		QRCodePNG:  base64.StdEncoding.EncodeToString(qrCode.Bytes()),
+	}, response.CodeSuccess
+}
+
+// Confirm enables 2fa once the user proves the authenticator works and returns the recovery codes.
+// The plain recovery codes are only ever shown here.
+func (s *TwoFactorService) Confirm(ctx context.Context, userID, code string) ([]string, int) {
+	user, resCode := s.getUser(ctx, userID)
+	if resCode != response.CodeSuccess {
+		return nil, resCode
+	}
+	if user.IsTotpEnabled == 1 {
+		global.Log.Warn(errMessage.ErrTwoFactorEnabled.Error(), zap.String("userID", userID))
+		return nil, response.CodeTwoFactorEnabled
+	}
+	if !user.TotpSecret.Valid {
+		global.Log.Warn(errMessage.ErrTwoFactorNotEnabled.Error(), zap.String("userID", userID))
+		return nil, response.CodeTwoFactorNotEnrolled
+	}
+
+	if resCode := s.verifyTotp(ctx, user, code); resCode != response.CodeSuccess {
+		return nil, resCode
+	}
+
+	codes, hashes, err := generateRecoveryCodes(consts.TOTP_RECOVERY_CODES)
+	if err != nil {
+		global.Log.Error("Error generating recovery codes", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedUpdateUser
+	}
+
+	if err := s.userRepo.UpdateUserTotp(ctx, userID, user.TotpSecret, 1, hashes); err != nil {
+		global.Log.Error("Error enabling two-factor", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedUpdateUser
+	}
+
+	global.Log.Info("Two-factor enabled", zap.String("userID", userID))
+	return codes, response.CodeSuccess
+}
+
+// Disable turns 2fa off after the user re-enters their password
+func (s *TwoFactorService) Disable(ctx context.Context, userID, password string) int {
+	user, code := s.getUser(ctx, userID)
+	if code != response.CodeSuccess {
+		return code
+	}
+	if user.IsTotpEnabled != 1 {
+		global.Log.Warn(errMessage.ErrTwoFactorNotEnabled.Error(), zap.String("userID", userID))
+		return response.CodeTwoFactorNotEnabled
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		global.Log.Warn(errMessage.ErrInvalidCredentials.Error(), zap.String("userID", userID))
+		return response.CodeInvalidCredentials
+	}
+
+	if err := s.userRepo.UpdateUserTotp(ctx, userID, sql.NullString{}, 0, sql.NullString{}); err != nil {
+		global.Log.Error("Error disabling two-factor", zap.Error(err), zap.String("userID", userID))
+		return response.CodeFailedUpdateUser
+	}
+
+	global.Log.Info("Two-factor disabled", zap.String("userID", userID))
+	return response.CodeSuccess
+}
+
+func (s *TwoFactorService) VerifyCode(ctx context.Context, user *models.User, code, recoveryCode string) int {
+	if user.IsTotpEnabled != 1 || !user.TotpSecret.Valid {
+		global.Log.Warn(errMessage.ErrTwoFactorNotEnabled.Error(), zap.String("userID", user.UserID))
+		return response.CodeTwoFactorNotEnabled
+	}
+
+	if code != "" {
+		return s.verifyTotp(ctx, user, code)
+	}
+	return s.consumeRecoveryCode(ctx, user, recoveryCode)
+}
+
+// verifyTotp validates a code against the user's secret and refuses codes already used
+func (s *TwoFactorService) verifyTotp(ctx context.Context, user *models.User, code string) int {
+	code = strings.TrimSpace(code)
+	if code == "" || !totp.Validate(code, user.TotpSecret.String) {
+		global.Log.Warn(errMessage.ErrInvalidTwoFactor.Error(), zap.String("userID", user.UserID))
+		return response.CodeInvalidTwoFactor
+	}
+
+	usedKey := fmt.Sprintf(consts.REDIS_KEY_URS_TOTP_USED_PREFIX, user.UserID, code)
+	fresh, err := utils.NewRedisCache().SetNX(ctx, usedKey, 1, consts.REDIS_TOTP_USED_CODE_EXPIRATION)
+	if err != nil {
+		global.Log.Error("Failed to store used totp code", zap.Error(err), zap.String("userID", user.UserID))
+		return response.CodeFailedUpdateUser
+	}
+	if !fresh {
+		global.Log.Warn(errMessage.ErrInvalidTwoFactor.Error(), zap.String("userID", user.UserID), zap.String("reason", "replayed"))
+		return response.CodeInvalidTwoFactor
+	}
+
+	return response.CodeSuccess
+}
+
+// consumeRecoveryCode removes a matching recovery code so it cannot be used twice
+func (s *TwoFactorService) consumeRecoveryCode(ctx context.Context, user *models.User, recoveryCode string) int {
+	recoveryCode = normalizeRecoveryCode(recoveryCode)
+	if recoveryCode == "" || !user.TotpRecovery.Valid {
+		global.Log.Warn(errMessage.ErrInvalidTwoFactor.Error(), zap.String("userID", user.UserID))
+		return response.CodeInvalidTwoFactor
+	}
+
+	var hashes []string
+	if err := json.Unmarshal([]byte(user.TotpRecovery.String), &hashes); err != nil {
+		global.Log.Error("Error decoding recovery codes", zap.Error(err), zap.String("userID", user.UserID))
+		return response.CodeFailedGetUser
+	}
+
+	hash := utils.HashToken(recoveryCode)
+	match := -1
+	for i, h := range hashes {
+		if subtle.ConstantTimeCompare([]byte(h), []byte(hash)) == 1 {
+			match = i
+		}
+	}
+	if match < 0 {
+		global.Log.Warn(errMessage.ErrInvalidTwoFactor.Error(), zap.String("userID", user.UserID))
+		return response.CodeInvalidTwoFactor
+	}
+
+	remaining, err := json.Marshal(append(hashes[:match], hashes[match+1:]...))
+	if err != nil {
+		global.Log.Error("Error encoding recovery codes", zap.Error(err), zap.String("userID", user.UserID))
+		return response.CodeFailedUpdateUser
+	}
+	// The codes are only written back if no concurrent request consumed one in between
+	rowsAffected, err := s.userRepo.SwapTotpRecovery(ctx, user.UserID, user.TotpRecovery.String, string(remaining))
+	if err != nil {
+		global.Log.Error("Error consuming recovery code", zap.Error(err), zap.String("userID", user.UserID))
+		return response.CodeFailedUpdateUser
+	}
+	if rowsAffected == 0 {
+		global.Log.Warn(errMessage.ErrInvalidTwoFactor.Error(), zap.String("userID", user.UserID), zap.String("reason", "concurrent use"))
+		return response.CodeInvalidTwoFactor
+	}
+
+	global.Log.Info("Recovery code used", zap.String("userID", user.UserID), zap.Int("remaining", len(hashes)-1))
+	return response.CodeSuccess
+}
+
+func (s *TwoFactorService) getUser(ctx context.Context, userID string) (*models.User, int) {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Log.Warn(errMessage.ErrUserNotFound.Error(), zap.String("userID", userID))
+			return nil, response.CodeUserNotFound
+		}
+
+		global.Log.Error("Error getting user by ID", zap.Error(err), zap.String("userID", userID))
+		return nil, response.CodeFailedGetUser
+	}
+	return user, response.CodeSuccess
+}
+
+// generateRecoveryCodes returns n codes formatted as "xxxxx-xxxxx" and their hashes as a JSON array
+func generateRecoveryCodes(n int) ([]string, sql.NullString, error) {
+	encoding := base32.StdEncoding.WithPadding(base32.NoPadding)
+
+	codes := make([]string, 0, n)
+	hashes := make([]string, 0, n)
+	for range n {
+		b := make([]byte, 7)
+		if _, err := rand.Read(b); err != nil {
+			return nil, sql.NullString{}, err
+		}
+		raw := strings.ToLower(encoding.EncodeToString(b))[:10]
+		code := raw[:5] + "-" + raw[5:]
+
+		codes = append(codes, code)
+		hashes = append(hashes, utils.HashToken(normalizeRecoveryCode(code)))
+	}
+
+	data, err := json.Marshal(hashes)
+	if err != nil {
+		return nil, sql.NullString{}, err
+	}
+	return codes, sql.NullString{String: string(data), Valid: true}, nil
+}
+
+// normalizeRecoveryCode makes recovery codes case and dash insensitive
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/nas03/scholar-ai/backend/global"
+	"github.com/nas03/scholar-ai/backend/internal/models"
+	repo "github.com/nas03/scholar-ai/backend/internal/repositories"
+	"github.com/nas03/scholar-ai/backend/internal/utils"
+	"github.com/nas03/scholar-ai/backend/pkg/response"
+	"go.uber.org/zap"
+)
+
+// fakeRecoveryRepository records the recovery codes swapped in, affecting rowsAffected rows
+type fakeRecoveryRepository struct {
+	repo.IUserRepository
+	rowsAffected int64
+	current      string
+	recovery     string
+	swapped      bool
+}
+
+func (r *fakeRecoveryRepository) SwapTotpRecovery(_ context.Context, _, current, recovery string) (int64, error) {
+	r.current, r.recovery, r.swapped = current, recovery, true
+	return r.rowsAffected, nil
+}
+
+func TestNormalizeRecoveryCode(t *testing.T) {
+	tests := map[string]string{
+		"abcde-fghij":    "abcdefghij",
+		"ABCDE-FGHIJ":    "abcdefghij",
+		" abcdefghij \n": "abcdefghij",
+		"ab-cd-ef-gh-ij": "abcdefghij",
+		"":               "",
+		"  -  ":          "",
+		"Ab12C-dE34f":    "ab12cde34f",
+	}
+	for code, want := range tests {
+		if got := normalizeRecoveryCode(code); got != want {
+			t.Errorf("normalizeRecoveryCode(%q) = %q, want %q", code, got, want)
+		}
+	}
+}
+
+func TestConsumeRecoveryCode(t *testing.T) {
+	global.Log = zap.NewNop()
+	codes, stored, err := generateRecoveryCodes(3)
+	if err != nil {
+		t.Fatalf("generateRecoveryCodes() error = %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		recovery     sql.NullString
+		code         string
+		rowsAffected int64
+		wantCode     int
+		wantSwapped  bool
+	}{
+		{name: "matching code", recovery: stored, code: codes[1], rowsAffected: 1, wantCode: response.CodeSuccess, wantSwapped: true},
+		{name: "code typed without dash in upper case", recovery: stored, code: strings.ToUpper(strings.ReplaceAll(codes[2], "-", "")), rowsAffected: 1, wantCode: response.CodeSuccess, wantSwapped: true},
+		{name: "unknown code", recovery: stored, code: "aaaaa-bbbbb", rowsAffected: 1, wantCode: response.CodeInvalidTwoFactor},
+		{name: "empty code", recovery: stored, code: " - ", rowsAffected: 1, wantCode: response.CodeInvalidTwoFactor},
+		{name: "no codes stored", code: codes[0], rowsAffected: 1, wantCode: response.CodeInvalidTwoFactor},
+		{name: "malformed codes stored", recovery: sql.NullString{String: "not json", Valid: true}, code: codes[0], rowsAffected: 1, wantCode: response.CodeFailedGetUser},
+		{name: "consumed concurrently", recovery: stored, code: codes[0], rowsAffected: 0, wantCode: response.CodeInvalidTwoFactor, wantSwapped: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository := &fakeRecoveryRepository{rowsAffected: tt.rowsAffected}
+			s := &TwoFactorService{userRepo: repository}
+			user := &models.User{UserID: "user-1", TotpRecovery: tt.recovery}
+
+			if code := s.consumeRecoveryCode(context.Background(), user, tt.code); code != tt.wantCode {
+				t.Fatalf("consumeRecoveryCode() = %d, want %d", code, tt.wantCode)
+			}
+			if repository.swapped != tt.wantSwapped {
+				t.Fatalf("swapped = %v, want %v", repository.swapped, tt.wantSwapped)
+			}
+			if !tt.wantSwapped {
+				return
+			}
+
+			// The swap is conditioned on the codes read, and drops exactly the used one
+			if repository.current != stored.String {
+				t.Errorf("swap current = %s, want %s", repository.current, stored.String)
+			}
+			var remaining []string
+			if err := json.Unmarshal([]byte(repository.recovery), &remaining); err != nil {
+				t.Fatalf("swapped recovery codes %q: %v", repository.recovery, err)
+			}
+			used := utils.HashToken(normalizeRecoveryCode(tt.code))
+			if len(remaining) != len(codes)-1 || strings.Contains(repository.recovery, used) {
+				t.Errorf("remaining = %v, want the other %d codes without %s", remaining, len(codes)-1, used)
+			}
+		})
+	}
+}
@@ -2,8 +2,10 @@ package services
 
 import (
 	"context"
+	"crypto/subtle"
 	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/google/uuid"
 	"github.com/nas03/scholar-ai/backend/global"
@@ -14,6 +16,7 @@ import (
 	"github.com/nas03/scholar-ai/backend/internal/utils"
 	errMessage "github.com/nas03/scholar-ai/backend/pkg/errors"
 	"github.com/nas03/scholar-ai/backend/pkg/response"
+	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
@@ -28,6 +31,7 @@ type IUserService interface {
 	UpdateUserVerification(ctx context.Context, userID string, isEmailVerified, isPhoneVerified bool) int
 	// UpdateUserInfo(email, phoneNumber string) int
 	VerifyUserEmail(ctx context.Context, otp, email string) int
+	ResendVerificationEmail(ctx context.Context, email string) (int64, int)
 }
 
 type UserService struct {
@@ -50,9 +54,9 @@ func (s *UserService) CreateUser(ctx context.Context, username, password, email
 		global.Log.Warn(errMessage.ErrInvalidEmail.Error(), zap.String("email", email))
 		return response.CodeInvalidEmail
 	}
-	if password == "" {
-		global.Log.Warn(errMessage.ErrEmptyPassword.Error(), zap.String("password", password))
-		return response.CodeEmptyPassword
+	if err := utils.ValidatePassword(password); err != nil {
+		global.Log.Warn(err.Error(), zap.String("username", username))
+		return passwordPolicyCode(err)
 	}
 
 	// Generate user's uuid
@@ -89,24 +93,12 @@ func (s *UserService) CreateUser(ctx context.Context, username, password, email
 	}
 
 	// Send OTP verify user's email
-	otp := utils.GenerateSixDigitOtp()
-	redisKey := fmt.Sprintf(consts.REDIS_KEY_URS_OTP_PREFIX, email)
-	if err := utils.NewRedisCache().SetEx(ctx, redisKey, otp, consts.REDIS_OTP_EXPIRATION); err != nil {
-		global.Log.Error("Failed to store otp in redis", zap.Error(err))
+	if code := s.sendVerificationOtp(ctx, email); code != response.CodeSuccess {
+		if code == response.CodeMailSendFailed {
+			return code
+		}
 		return response.CodeRegisterInternalError
 	}
-
-	// TODO: Should save mailID, email, email type to DB
-	_, err = helper.NewMailHelper().SendMail(
-		ctx,
-		email,
-		fmt.Sprintf("ScholarAI Verification Code %d", otp),
-		fmt.Sprintf("<p>%d</p>", otp),
-	)
-	if err != nil {
-		global.Log.Error("Failed to send verification email", zap.String("email", email), zap.Error(err))
-		return response.CodeMailSendFailed
-	}
 	global.Log.Info("Success creating new user", zap.String("userID", userUUID.String()))
 	return response.CodeSuccess
 }
@@ -157,6 +149,11 @@ func (s *UserService) UpdateUserAccountStatus(ctx context.Context, userID string
 		return response.CodeFailedUpdateUser
 	}
 
+	// Make the auth middleware pick up the new status right away
+	if err := utils.NewRedisCache().Del(ctx, fmt.Sprintf(consts.REDIS_KEY_AUTH_USER_PREFIX, userID)); err != nil {
+		global.Log.Warn("Failed to invalidate cached auth user", zap.Error(err), zap.String("userID", userID))
+	}
+
 	global.Log.Info("Success updating user account status", zap.String("userID", userID), zap.Int8("status", status))
 	return response.CodeSuccess
 }
@@ -164,12 +161,18 @@ func (s *UserService) UpdateUserAccountStatus(ctx context.Context, userID string
 // UpdateUserPassword updates user password with proper error handling
 func (s *UserService) UpdateUserPassword(ctx context.Context, userID, password string) int {
 	// Validate password at service level
-	if password == "" {
-		global.Log.Warn(errMessage.ErrEmptyPassword.Error(), zap.String("userID", userID))
-		return response.CodeEmptyPassword
+	if err := utils.ValidatePassword(password); err != nil {
+		global.Log.Warn(err.Error(), zap.String("userID", userID))
+		return passwordPolicyCode(err)
 	}
 
-	err := s.userRepo.UpdateUserPassword(ctx, userID, password)
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		global.Log.Error("Error generating hashedPassword", zap.Error(err))
+		return response.CodeFailedUpdateUser
+	}
+
+	err = s.userRepo.UpdateUserPassword(ctx, userID, string(hashedPassword))
 	if err != nil {
 		global.Log.Error("Error updating user password", zap.Error(err), zap.String("userID", userID))
 		return response.CodeFailedUpdateUser
@@ -191,6 +194,7 @@ func (s *UserService) UpdateUserVerification(ctx context.Context, userID string,
 	return response.CodeSuccess
 }
 
+// VerifyUserEmail checks the OTP sent on registration and activates the account
 func (s *UserService) VerifyUserEmail(ctx context.Context, otp, email string) int {
 	// Validate input parameters
 	if otp == "" {
@@ -202,7 +206,7 @@ func (s *UserService) VerifyUserEmail(ctx context.Context, otp, email string) in
 		return response.CodeInvalidEmail
 	}
 
-	_, err := s.userRepo.GetUserByEmail(ctx, email)
+	user, err := s.userRepo.GetUserByEmail(ctx, email)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			global.Log.Warn(errMessage.ErrUserNotFound.Error(), zap.String("email", email))
@@ -212,14 +216,207 @@ func (s *UserService) VerifyUserEmail(ctx context.Context, otp, email string) in
 		global.Log.Error("Error getting user by email", zap.Error(err), zap.String("email", email))
 		return response.CodeFailedGetUser
 	}
+	if user.IsEmailVerified == 1 {
+		global.Log.Warn(errMessage.ErrEmailVerified.Error(), zap.String("email", email))
+		return response.CodeEmailAlreadyVerified
+	}
+
+	cache := utils.NewRedisCache()
+	otpKey := fmt.Sprintf(consts.REDIS_KEY_URS_OTP_PREFIX, email)
+	attemptsKey := fmt.Sprintf(consts.REDIS_KEY_URS_OTP_ATTEMPTS_PREFIX, email)
 
-	// TODO: Implement OTP verification logic
-	// This would typically involve:
-	// 1. Getting user by email
-	// 2. Checking if OTP matches and is not expired
-	// 3. Updating user verification status
-	// 4. Clearing the OTP from storage
+	// The attempt is counted before the otp is compared, so parallel guesses cannot all get past the limit
+	attempts, code := s.reserveOtpAttempt(ctx, cache, email, attemptsKey)
+	if code != response.CodeSuccess {
+		return code
+	}
 
-	global.Log.Info("Email verification successful", zap.String("email", email))
+	storedOtp, err := cache.Get(ctx, otpKey)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			global.Log.Warn(errMessage.ErrOTPExpired.Error(), zap.String("email", email))
+			return response.CodeOTPExpired
+		}
+
+		global.Log.Error("Failed to get otp from redis", zap.Error(err))
+		return response.CodeFailedGetUser
+	}
+
+	if subtle.ConstantTimeCompare([]byte(storedOtp), []byte(otp)) != 1 {
+		return s.rejectOtp(ctx, cache, email, otpKey, attempts)
+	}
+
+	// Activate the account in a single transaction
+	err = s.userRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		return tx.Model(&models.User{}).
+			Where("user_id = ?", user.UserID).
+			Updates(map[string]interface{}{
+				"is_email_verified": 1,
+				"account_status":    consts.UserAccountStatus.ACTIVE,
+			}).Error
+	})
+	if err != nil {
+		global.Log.Error("Error activating user", zap.Error(err), zap.String("userID", user.UserID))
+		return response.CodeFailedUpdateUser
+	}
+
+	// The otp is single-use, a failure here only leaves a dead key behind
+	if err := cache.Del(ctx, otpKey, attemptsKey); err != nil {
+		global.Log.Warn("Failed to delete otp from redis", zap.Error(err), zap.String("email", email))
+	}
+
+	global.Log.Info("Email verification successful", zap.String("email", email), zap.String("userID", user.UserID))
 	return response.CodeSuccess
 }
+
+// reserveOtpAttempt counts an attempt at the otp and refuses it once the code is locked
+func (s *UserService) reserveOtpAttempt(ctx context.Context, cache utils.IRedisCache, email, attemptsKey string) (int64, int) {
+	attempts, err := cache.Incr(ctx, attemptsKey)
+	if err != nil {
+		global.Log.Error("Failed to increase otp attempts", zap.Error(err))
+		return 0, response.CodeFailedGetUser
+	}
+	if attempts == 1 {
+		if err := cache.Expire(ctx, attemptsKey, consts.REDIS_OTP_EXPIRATION); err != nil {
+			global.Log.Error("Failed to set otp attempts expiration", zap.Error(err))
+		}
+	}
+
+	if attempts > int64(consts.OTP_MAX_ATTEMPTS) {
+		global.Log.Warn(errMessage.ErrOTPLocked.Error(), zap.String("email", email), zap.Int64("attempts", attempts))
+		return attempts, response.CodeOTPLocked
+	}
+	return attempts, response.CodeSuccess
+}
+
+// rejectOtp answers a wrong otp and locks the code when it took the last attempt
+func (s *UserService) rejectOtp(ctx context.Context, cache utils.IRedisCache, email, otpKey string, attempts int64) int {
+	if attempts >= int64(consts.OTP_MAX_ATTEMPTS) {
+		if err := cache.Del(ctx, otpKey); err != nil {
+			global.Log.Error("Failed to delete locked otp", zap.Error(err))
+		}
+		global.Log.Warn(errMessage.ErrOTPLocked.Error(), zap.String("email", email), zap.Int64("attempts", attempts))
+		return response.CodeOTPLocked
+	}
+
+	global.Log.Warn(errMessage.ErrInvalidOTP.Error(), zap.String("email", email), zap.Int64("attempts", attempts))
+	return response.CodeInvalidOTP
+}
+
+// ResendVerificationEmail sends a fresh OTP, returning the remaining cooldown in seconds
+func (s *UserService) ResendVerificationEmail(ctx context.Context, email string) (int64, int) {
+	if email == "" {
+		global.Log.Warn(errMessage.ErrInvalidEmail.Error(), zap.String("email", email))
+		return 0, response.CodeInvalidEmail
+	}
+
+	user, err := s.userRepo.GetUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Log.Warn(errMessage.ErrUserNotFound.Error(), zap.String("email", email))
+			return 0, response.CodeUserNotFound
+		}
+
+		global.Log.Error("Error getting user by email", zap.Error(err), zap.String("email", email))
+		return 0, response.CodeFailedGetUser
+	}
+	if user.IsEmailVerified == 1 {
+		global.Log.Warn(errMessage.ErrEmailVerified.Error(), zap.String("email", email))
+		return 0, response.CodeEmailAlreadyVerified
+	}
+
+	cache := utils.NewRedisCache()
+
+	// Enforce per-email cooldown
+	cooldown, err := cache.TTL(ctx, fmt.Sprintf(consts.REDIS_KEY_URS_OTP_COOLDOWN_PREFIX, email))
+	if err != nil {
+		global.Log.Error("Failed to get otp cooldown from redis", zap.Error(err))
+		return 0, response.CodeFailedGetUser
+	}
+	if cooldown > 0 {
+		global.Log.Warn(errMessage.ErrOTPResendCooldown.Error(), zap.String("email", email), zap.Duration("cooldown", cooldown))
+		return int64(cooldown.Seconds()), response.CodeOTPResendCooldown
+	}
+
+	// Enforce per-day cap
+	sent, err := cache.Get(ctx, fmt.Sprintf(consts.REDIS_KEY_URS_OTP_DAILY_PREFIX, email))
+	if err != nil && !errors.Is(err, redis.Nil) {
+		global.Log.Error("Failed to get otp daily count from redis", zap.Error(err))
+		return 0, response.CodeFailedGetUser
+	}
+	if n, _ := strconv.Atoi(sent); n >= consts.OTP_DAILY_SEND_LIMIT {
+		global.Log.Warn(errMessage.ErrOTPResendLimit.Error(), zap.String("email", email), zap.Int("sent", n))
+		return 0, response.CodeOTPResendLimitExceeded
+	}
+
+	if code := s.sendVerificationOtp(ctx, email); code != response.CodeSuccess {
+		return 0, code
+	}
+
+	global.Log.Info("Success resending verification email", zap.String("email", email))
+	return int64(consts.REDIS_OTP_RESEND_COOLDOWN.Seconds()), response.CodeSuccess
+}
+
+// sendVerificationOtp stores a new OTP, resets failed attempts and mails the code
+func (s *UserService) sendVerificationOtp(ctx context.Context, email string) int {
+	cache := utils.NewRedisCache()
+	otp := utils.GenerateSixDigitOtp()
+
+	redisKey := fmt.Sprintf(consts.REDIS_KEY_URS_OTP_PREFIX, email)
+	if err := cache.SetEx(ctx, redisKey, otp, consts.REDIS_OTP_EXPIRATION); err != nil {
+		global.Log.Error("Failed to store otp in redis", zap.Error(err))
+		return response.CodeFailedUpdateUser
+	}
+
+	// A new code starts with a clean attempt counter
+	if err := cache.Del(ctx, fmt.Sprintf(consts.REDIS_KEY_URS_OTP_ATTEMPTS_PREFIX, email)); err != nil {
+		global.Log.Error("Failed to reset otp attempts", zap.Error(err))
+	}
+
+	cooldownKey := fmt.Sprintf(consts.REDIS_KEY_URS_OTP_COOLDOWN_PREFIX, email)
+	if err := cache.SetEx(ctx, cooldownKey, 1, consts.REDIS_OTP_RESEND_COOLDOWN); err != nil {
+		global.Log.Error("Failed to store otp cooldown in redis", zap.Error(err))
+	}
+
+	dailyKey := fmt.Sprintf(consts.REDIS_KEY_URS_OTP_DAILY_PREFIX, email)
+	sent, err := cache.Incr(ctx, dailyKey)
+	if err != nil {
+		global.Log.Error("Failed to increase otp daily count", zap.Error(err))
+	} else if sent == 1 {
+		if err := cache.Expire(ctx, dailyKey, consts.REDIS_OTP_DAILY_WINDOW); err != nil {
+			global.Log.Error("Failed to set otp daily count expiration", zap.Error(err))
+		}
+	}
+
+	// TODO: Should save mailID, email, email type to DB
+	_, err = helper.NewMailHelper().SendMail(
+		ctx,
+		email,
+		fmt.Sprintf("ScholarAI Verification Code %d", otp),
+		fmt.Sprintf("<p>%d</p>", otp),
+	)
+	if err != nil {
+		global.Log.Error("Failed to send verification email", zap.String("email", email), zap.Error(err))
+		return response.CodeMailSendFailed
+	}
+
+	return response.CodeSuccess
+}
+
+// passwordPolicyCode maps a utils.ValidatePassword error to its response code
+func passwordPolicyCode(err error) int {
+	switch {
+	case errors.Is(err, errMessage.ErrEmptyPassword):
+		return response.CodeEmptyPassword
+	case errors.Is(err, errMessage.ErrPasswordTooShort):
+		return response.CodePasswordTooShort
+	case errors.Is(err, errMessage.ErrPasswordTooLong):
+		return response.CodePasswordTooLong
+	case errors.Is(err, errMessage.ErrPasswordTooWeak):
+		return response.CodePasswordTooWeak
+	case errors.Is(err, errMessage.ErrPasswordTooCommon):
+		return response.CodePasswordTooCommon
+	default:
+		return response.CodeInvalidInput
+	}
+}
@@ -0,0 +1,215 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Kinds of a line in an edit script
+const (
+	diffKeep   = ' '
+	diffDelete = '-'
+	diffInsert = '+'
+)
+
+// diffNoNewline follows a line the text ends in without a newline
+const diffNoNewline = `\ No newline at end of file`
+
+// diffMaxEdits bounds the Myers search, larger changes come out as a whole rewrite of the changed block
+const diffMaxEdits = 1000
+
+type diffLine struct {
+	kind byte
+	text string
+}
+
+// UnifiedDiff returns the line-based unified diff turning from into to, with context unchanged lines
+// around every change. It is empty when both texts are the same up to CRLF line endings, a last line
+// without a newline is marked the way diff -u does.
+func UnifiedDiff(fromName, toName, from, to string, context int) string {
+	lines := diffLines(splitLines(from), splitLines(to))
+
+	// fromLine and toLine count the lines of each side before every edit
+	fromLine := make([]int, len(lines)+1)
+	toLine := make([]int, len(lines)+1)
+	for i, line := range lines {
+		fromLine[i+1], toLine[i+1] = fromLine[i], toLine[i]
+		if line.kind != diffInsert {
+			fromLine[i+1]++
+		}
+		if line.kind != diffDelete {
+			toLine[i+1]++
+		}
+	}
+
+	var out strings.Builder
+	for i := 0; i < len(lines); {
+		if lines[i].kind == diffKeep {
+			i++
+			continue
+		}
+
+		// Extend the hunk over changes separated by at most twice the context
+		start := max(i-context, 0)
+		end := i
+		for j := i; j < len(lines); {
+			if lines[j].kind != diffKeep {
+				j++
+				end = j
+				continue
+			}
+			k := j
+			for k < len(lines) && lines[k].kind == diffKeep {
+				k++
+			}
+			if k == len(lines) || k-j > 2*context {
+				break
+			}
+			j = k
+		}
+		end = min(end+context, len(lines))
+
+		if out.Len() == 0 {
+			fmt.Fprintf(&out, "--- %s\n+++ %s\n", fromName, toName)
+		}
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n",
+			diffRange(fromLine[start], fromLine[end]-fromLine[start]),
+			diffRange(toLine[start], toLine[end]-toLine[start]))
+		for _, line := range lines[start:end] {
+			out.WriteByte(line.kind)
+			out.WriteString(line.text)
+			if !strings.HasSuffix(line.text, "\n") {
+				out.WriteString("\n" + diffNoNewline + "\n")
+			}
+		}
+		i = end
+	}
+	return out.String()
+}
+
+// diffRange formats the lines of a hunk the way diff -u does, an empty range points at the line before it
+func diffRange(before, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", before)
+	case 1:
+		return fmt.Sprintf("%d", before+1)
+	default:
+		return fmt.Sprintf("%d,%d", before+1, count)
+	}
+}
+
+// splitLines splits a text into lines keeping their newline, so a last line without one differs
+// from the same line with one
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines returns the edit script turning a into b, keeping their common prefix and suffix
+func diffLines(a, b []string) []diffLine {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	lines := make([]diffLine, 0, len(a)+len(b))
+	for _, text := range a[:prefix] {
+		lines = append(lines, diffLine{diffKeep, text})
+	}
+	lines = append(lines, diffMiddle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, text := range a[len(a)-suffix:] {
+		lines = append(lines, diffLine{diffKeep, text})
+	}
+	return lines
+}
+
+// diffMiddle runs the Myers shortest edit search, falling back to deleting a and inserting b
+// when more than diffMaxEdits edits are needed
+func diffMiddle(a, b []string) []diffLine {
+	n, m := len(a), len(b)
+	limit := min(n+m, diffMaxEdits)
+
+	// v[offset+k] is the furthest x reached on diagonal k, trace keeps v[offset-d-1 : offset+d+2] before step d
+	offset := limit + 1
+	v := make([]int, 2*limit+3)
+	var trace [][]int
+	for d := 0; d <= limit; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d-1:offset+d+2]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+
+			if x >= n && y >= m {
+				return diffBacktrack(a, b, trace)
+			}
+		}
+	}
+
+	lines := make([]diffLine, 0, n+m)
+	for _, text := range a {
+		lines = append(lines, diffLine{diffDelete, text})
+	}
+	for _, text := range b {
+		lines = append(lines, diffLine{diffInsert, text})
+	}
+	return lines
+}
+
+// diffBacktrack walks the Myers trace back from the end of a and b into the edit script
+func diffBacktrack(a, b []string, trace [][]int) []diffLine {
+	var reversed []diffLine
+	x, y := len(a), len(b)
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		at := func(k int) int { return v[k+d+1] }
+
+		k := x - y
+		prevK := k - 1
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			reversed = append(reversed, diffLine{diffKeep, a[x-1]})
+			x--
+			y--
+		}
+		if d > 0 {
+			if x == prevX {
+				reversed = append(reversed, diffLine{diffInsert, b[y-1]})
+			} else {
+				reversed = append(reversed, diffLine{diffDelete, a[x-1]})
+			}
+		}
+		x, y = prevX, prevY
+	}
+
+	lines := make([]diffLine, len(reversed))
+	for i, line := range reversed {
+		lines[len(reversed)-1-i] = line
+	}
+	return lines
+}
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		context  int
+		want     string
+	}{
+		{name: "empty", from: "", to: "", context: 3, want: ""},
+		{name: "same lines", from: "a\r\nb\r\n", to: "a\nb\n", context: 3, want: ""},
+		{name: "same without a trailing newline", from: "a\nb", to: "a\nb", context: 3, want: ""},
+		{
+			name: "pure insert", from: "", to: "a\nb\n", context: 3,
+			want: "--- from\n+++ to\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			name: "pure delete", from: "a\nb\n", to: "", context: 3,
+			want: "--- from\n+++ to\n@@ -1,2 +0,0 @@\n-a\n-b\n",
+		},
+		{
+			name: "change with context", from: "a\nb\nc\nd\n", to: "a\nx\nc\nd\n", context: 1,
+			want: "--- from\n+++ to\n@@ -1,3 +1,3 @@\n a\n-b\n+x\n c\n",
+		},
+		{
+			name: "separate hunks", from: "a\nb\nc\nd\ne\nf\n", to: "x\nb\nc\nd\ne\ny\n", context: 1,
+			want: "--- from\n+++ to\n@@ -1,2 +1,2 @@\n-a\n+x\n b\n@@ -5,2 +5,2 @@\n e\n-f\n+y\n",
+		},
+		{
+			name: "trailing newline added", from: "a\nb", to: "a\nb\n", context: 3,
+			want: "--- from\n+++ to\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+b\n",
+		},
+		{
+			name: "line added after a last line without newline", from: "a", to: "a\nb", context: 3,
+			want: "--- from\n+++ to\n@@ -1 +1,2 @@\n-a\n\\ No newline at end of file\n+a\n+b\n\\ No newline at end of file\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UnifiedDiff("from", "to", tt.from, tt.to, tt.context); got != tt.want {
+				t.Errorf("UnifiedDiff() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnifiedDiffRewritesPastMaxEdits(t *testing.T) {
+	// Every other line changes, so the shortest edit script needs more than diffMaxEdits edits
+	var from, to strings.Builder
+	pairs := diffMaxEdits/2 + 100
+	for i := 0; i < pairs; i++ {
+		fmt.Fprintf(&from, "keep %d\nold %d\n", i, i)
+		fmt.Fprintf(&to, "keep %d\nnew %d\n", i, i)
+	}
+
+	got := UnifiedDiff("from", "to", from.String(), to.String(), 3)
+	if want := fmt.Sprintf("@@ -1,%d +1,%d @@\n", 2*pairs, 2*pairs); !strings.Contains(got, want) {
+		t.Fatalf("UnifiedDiff() lacks the hunk header %q", strings.TrimSpace(want))
+	}
+	// Past the common first line the block is deleted and inserted whole
+	if strings.Contains(got, "\n keep 1\n") || !strings.Contains(got, "\n-keep 1\n") || !strings.Contains(got, "\n+keep 1\n") {
+		t.Errorf("UnifiedDiff() kept lines inside the rewritten block")
+	}
+	if inserted := strings.Index(got, "\n+new 0\n"); inserted < strings.LastIndex(got, "\n-") {
+		t.Errorf("UnifiedDiff() interleaves deletions and insertions in the rewritten block")
+	}
+}
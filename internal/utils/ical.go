@@ -0,0 +1,386 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	errMessage "github.com/nas03/scholar-ai/backend/pkg/errors"
+)
+
+// iCalendar value layouts
+const (
+	ICalDateTimeLayout    = "20060102T150405"  // local time, paired with a TZID parameter
+	ICalUTCDateTimeLayout = "20060102T150405Z" // UTC time
+	ICalDateLayout        = "20060102"         // all-day values
+	icalMaxLineOctets     = 75
+)
+
+// icalTextEscaper escapes TEXT values (RFC 5545 3.3.11), icalTextUnescaper reverts it
+var (
+	icalTextEscaper   = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	icalTextUnescaper = strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n")
+)
+
+// ICalWriter builds an iCalendar (RFC 5545) document with CRLF line endings and folded long lines
+type ICalWriter struct {
+	builder strings.Builder
+}
+
+// Line writes a property whose value is already formatted, name may carry parameters ("DTSTART;TZID=UTC")
+func (w *ICalWriter) Line(name, value string) {
+	line := name + ":" + value
+	// Continuation lines start with the space that marks them
+	limit := icalMaxLineOctets
+	for len(line) > limit {
+		// Fold before the limit without splitting a UTF-8 sequence
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.builder.WriteString(line[:cut])
+		w.builder.WriteString("\r\n ")
+		line = line[cut:]
+		limit = icalMaxLineOctets - 1
+	}
+	w.builder.WriteString(line)
+	w.builder.WriteString("\r\n")
+}
+
+// Text writes a property with a TEXT value
+func (w *ICalWriter) Text(name, value string) {
+	w.Line(name, icalTextEscaper.Replace(value))
+}
+
+// Time writes a date-time property, in location with a TZID parameter or in UTC
+func (w *ICalWriter) Time(name string, t time.Time, location *time.Location) {
+	if location == nil || location == time.UTC {
+		w.Line(name, t.UTC().Format(ICalUTCDateTimeLayout))
+		return
+	}
+	w.Line(name+";TZID="+location.String(), t.In(location).Format(ICalDateTimeLayout))
+}
+
+// Timezone writes the VTIMEZONE of location, with the daylight saving rules it follows in year.
+// UTC needs none, times in UTC are written as such.
+func (w *ICalWriter) Timezone(location *time.Location, year int) {
+	if location == nil || location == time.UTC {
+		return
+	}
+
+	w.Line("BEGIN", "VTIMEZONE")
+	w.Line("TZID", location.String())
+
+	transitions := zoneTransitions(location, year)
+	if len(transitions) == 0 {
+		name, offset := time.Date(year, time.January, 1, 0, 0, 0, 0, location).Zone()
+		w.Line("BEGIN", "STANDARD")
+		w.Line("DTSTART", "19700101T000000")
+		w.Line("TZOFFSETFROM", icalOffset(offset))
+		w.Line("TZOFFSETTO", icalOffset(offset))
+		w.Line("TZNAME", name)
+		w.Line("END", "STANDARD")
+	}
+
+	for _, transition := range transitions {
+		component := "STANDARD"
+		if transition.isDST {
+			component = "DAYLIGHT"
+		}
+
+		// The rule recurs on the nth, or the last, weekday of the month at the local time before the change
+		local := transition.at.Add(time.Duration(transition.offsetFrom) * time.Second).UTC()
+		week := (local.Day()-1)/7 + 1
+		if local.Day()+7 > local.AddDate(0, 1, -local.Day()).Day() {
+			week = -1
+		}
+
+		w.Line("BEGIN", component)
+		w.Line("DTSTART", local.Format(ICalDateTimeLayout))
+		w.Line("RRULE", fmt.Sprintf("FREQ=YEARLY;BYMONTH=%d;BYDAY=%d%s", int(local.Month()), week, strings.ToUpper(local.Weekday().String()[:2])))
+		w.Line("TZOFFSETFROM", icalOffset(transition.offsetFrom))
+		w.Line("TZOFFSETTO", icalOffset(transition.offsetTo))
+		w.Line("TZNAME", transition.name)
+		w.Line("END", component)
+	}
+	w.Line("END", "VTIMEZONE")
+}
+
+func (w *ICalWriter) String() string {
+	return w.builder.String()
+}
+
+// zoneTransition is a change of UTC offset of a timezone
+type zoneTransition struct {
+	at         time.Time
+	offsetFrom int
+	offsetTo   int
+	name       string // zone abbreviation after the change
+	isDST      bool
+}
+
+// zoneTransitions finds the offset changes of location in year, scanning day by day and then narrowing down
+func zoneTransitions(location *time.Location, year int) []zoneTransition {
+	var transitions []zoneTransition
+
+	day := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	_, previous := day.In(location).Zone()
+	for ; day.Year() == year; day = day.AddDate(0, 0, 1) {
+		next := day.AddDate(0, 0, 1)
+		_, offset := next.In(location).Zone()
+		if offset == previous {
+			continue
+		}
+
+		low, high := day, next
+		for high.Sub(low) > time.Second {
+			middle := low.Add(high.Sub(low) / 2)
+			if _, o := middle.In(location).Zone(); o == previous {
+				low = middle
+			} else {
+				high = middle
+			}
+		}
+
+		// Zones change on whole minutes
+		at := high.Truncate(time.Minute)
+		name, _ := high.In(location).Zone()
+		transitions = append(transitions, zoneTransition{
+			at:         at,
+			offsetFrom: previous,
+			offsetTo:   offset,
+			name:       name,
+			isDST:      high.In(location).IsDST(),
+		})
+		previous = offset
+	}
+	return transitions
+}
+
+// icalOffset formats a UTC offset in seconds as "+0700"
+func icalOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, seconds/3600, seconds%3600/60)
+}
+
+// ICalComponent is a parsed iCalendar component such as VCALENDAR or VEVENT
+type ICalComponent struct {
+	Name       string
+	Properties []ICalProperty
+	Components []*ICalComponent
+}
+
+// ICalProperty is a content line, names are upper case and values are left escaped
+type ICalProperty struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// ParseICal parses an iCalendar document and returns its VCALENDAR component
+func ParseICal(data string) (*ICalComponent, error) {
+	data = strings.TrimPrefix(data, "\ufeff")
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	// Unfold continuation lines
+	data = strings.ReplaceAll(data, "\n ", "")
+	data = strings.ReplaceAll(data, "\n\t", "")
+
+	var root *ICalComponent
+	var stack []*ICalComponent
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if line == "" {
+			continue
+		}
+
+		property, ok := parseICalLine(line)
+		if !ok {
+			return nil, errMessage.ErrInvalidICalendar
+		}
+
+		switch property.Name {
+		case "BEGIN":
+			component := &ICalComponent{Name: strings.ToUpper(property.Value)}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Components = append(parent.Components, component)
+			} else if root == nil {
+				root = component
+			} else {
+				return nil, errMessage.ErrInvalidICalendar
+			}
+			stack = append(stack, component)
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].Name != strings.ToUpper(property.Value) {
+				return nil, errMessage.ErrInvalidICalendar
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			if len(stack) == 0 {
+				return nil, errMessage.ErrInvalidICalendar
+			}
+			current := stack[len(stack)-1]
+			current.Properties = append(current.Properties, property)
+		}
+	}
+
+	if root == nil || root.Name != "VCALENDAR" || len(stack) != 0 {
+		return nil, errMessage.ErrInvalidICalendar
+	}
+	return root, nil
+}
+
+// parseICalLine splits an unfolded content line into name, parameters and value,
+// quoted parameter values may contain ";", ":" and ","
+func parseICalLine(line string) (ICalProperty, bool) {
+	var property ICalProperty
+	var fields []string
+	quoted := false
+	start := 0
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case c == '"':
+			quoted = !quoted
+		case !quoted && c == ';':
+			fields = append(fields, line[start:i])
+			start = i + 1
+		case !quoted && c == ':':
+			fields = append(fields, line[start:i])
+			property.Value = line[i+1:]
+
+			property.Name = strings.ToUpper(fields[0])
+			if property.Name == "" {
+				return property, false
+			}
+			for _, field := range fields[1:] {
+				name, value, ok := strings.Cut(field, "=")
+				if !ok {
+					return property, false
+				}
+				if property.Params == nil {
+					property.Params = make(map[string]string)
+				}
+				property.Params[strings.ToUpper(name)] = strings.Trim(value, `"`)
+			}
+			return property, true
+		}
+	}
+	return property, false
+}
+
+// Property returns the first property named name, nil if there is none
+func (c *ICalComponent) Property(name string) *ICalProperty {
+	for i := range c.Properties {
+		if c.Properties[i].Name == name {
+			return &c.Properties[i]
+		}
+	}
+	return nil
+}
+
+// Text returns the unescaped TEXT value of the first property named name, empty if there is none
+func (c *ICalComponent) Text(name string) string {
+	if property := c.Property(name); property != nil {
+		return strings.TrimSpace(icalTextUnescaper.Replace(property.Value))
+	}
+	return ""
+}
+
+// Time reads a DATE or DATE-TIME value. UTC values and values with a known TZID keep their instant,
+// floating values and unknown zones are read in location. Dates are midnight UTC, with allDay set.
+func (p *ICalProperty) Time(location *time.Location) (t time.Time, allDay bool, err error) {
+	value := strings.TrimSpace(p.Value)
+	if p.Params["VALUE"] == "DATE" || len(value) == len(ICalDateLayout) {
+		t, err = time.Parse(ICalDateLayout, value)
+		if err != nil {
+			return t, true, errMessage.ErrInvalidICalendar
+		}
+		return t, true, nil
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err = time.Parse(ICalUTCDateTimeLayout, value)
+	} else {
+		if tzid, ok := p.Params["TZID"]; ok {
+			if zone, err := icalZone(tzid); err == nil {
+				location = zone
+			}
+		}
+		t, err = time.ParseInLocation(ICalDateTimeLayout, value, location)
+	}
+	if err != nil {
+		return t, false, errMessage.ErrInvalidICalendar
+	}
+	return t, false, nil
+}
+
+// UnknownZone returns the TZID of a local DATE-TIME value naming a zone that is not known,
+// Time reads such a value in the location it is given instead
+func (p *ICalProperty) UnknownZone() (string, bool) {
+	tzid, ok := p.Params["TZID"]
+	value := strings.TrimSpace(p.Value)
+	if !ok || p.Params["VALUE"] == "DATE" || len(value) == len(ICalDateLayout) || strings.HasSuffix(value, "Z") {
+		return "", false
+	}
+	if _, err := icalZone(tzid); err != nil {
+		return tzid, true
+	}
+	return "", false
+}
+
+// icalZone loads the zone of a TZID parameter, some producers prefix the zone name with a "/"
+func icalZone(tzid string) (*time.Location, error) {
+	return LoadLocation(strings.TrimPrefix(tzid, "/"))
+}
+
+// ParseICalDuration reads a DURATION value such as "PT1H30M" or "P1D"
+func ParseICalDuration(value string) (time.Duration, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	sign := time.Duration(1)
+	switch {
+	case strings.HasPrefix(value, "-"):
+		sign = -1
+		value = value[1:]
+	case strings.HasPrefix(value, "+"):
+		value = value[1:]
+	}
+	if !strings.HasPrefix(value, "P") || len(value) < 3 {
+		return 0, errMessage.ErrInvalidICalendar
+	}
+
+	units := map[byte]time.Duration{'W': 7 * 24 * time.Hour, 'D': 24 * time.Hour}
+	timeUnits := map[byte]time.Duration{'H': time.Hour, 'M': time.Minute, 'S': time.Second}
+
+	var total time.Duration
+	number := ""
+	for i := 1; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c >= '0' && c <= '9':
+			number += string(c)
+		case c == 'T':
+			if number != "" {
+				return 0, errMessage.ErrInvalidICalendar
+			}
+			units = timeUnits
+		default:
+			unit, ok := units[c]
+			n, err := strconv.Atoi(number)
+			if !ok || err != nil {
+				return 0, errMessage.ErrInvalidICalendar
+			}
+			total += time.Duration(n) * unit
+			number = ""
+		}
+	}
+	if number != "" {
+		return 0, errMessage.ErrInvalidICalendar
+	}
+	return sign * total, nil
+}
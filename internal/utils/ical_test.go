@@ -0,0 +1,231 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestICalWriterFoldsLongLines(t *testing.T) {
+	var w ICalWriter
+	summary := strings.Repeat("Lecture notes ", 10) + "ở Hà Nội"
+	w.Text("SUMMARY", summary)
+	out := w.String()
+
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+		if len(line) > icalMaxLineOctets {
+			t.Errorf("line of %d octets: %q", len(line), line)
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("line splits a UTF-8 sequence: %q", line)
+		}
+	}
+
+	calendar, err := ParseICal("BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\n" + out + "END:VEVENT\r\nEND:VCALENDAR\r\n")
+	if err != nil {
+		t.Fatalf("ParseICal() error = %v", err)
+	}
+	if got := calendar.Components[0].Text("SUMMARY"); got != strings.TrimSpace(summary) {
+		t.Errorf("unfolded SUMMARY = %q, want %q", got, summary)
+	}
+}
+
+func TestICalTextEscaping(t *testing.T) {
+	value := `a; b, c\d` + "\nnext line"
+
+	var w ICalWriter
+	w.Text("DESCRIPTION", value)
+	if want := `DESCRIPTION:a\; b\, c\\d\nnext line` + "\r\n"; w.String() != want {
+		t.Errorf("Text() wrote %q, want %q", w.String(), want)
+	}
+
+	calendar, err := ParseICal("BEGIN:VCALENDAR\nBEGIN:VEVENT\n" + w.String() + "END:VEVENT\nEND:VCALENDAR\n")
+	if err != nil {
+		t.Fatalf("ParseICal() error = %v", err)
+	}
+	if got := calendar.Components[0].Text("DESCRIPTION"); got != value {
+		t.Errorf("Text() = %q, want %q", got, value)
+	}
+}
+
+func TestParseICal(t *testing.T) {
+	data := "\ufeffBEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:event-1\r\n" +
+		"SUMMARY:CS101 Lect\r\n" +
+		" ure\r\n" +
+		"DTSTART;TZID=\"Asia/Ho_Chi_Minh\";X-NOTE=\"a;b:c\":20261019T090000\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VTODO\r\n" +
+		"summary:lower case name\r\n" +
+		"END:VTODO\r\n" +
+		"END:VCALENDAR\r\n"
+
+	calendar, err := ParseICal(data)
+	if err != nil {
+		t.Fatalf("ParseICal() error = %v", err)
+	}
+	if len(calendar.Components) != 2 || calendar.Components[0].Name != "VEVENT" || calendar.Components[1].Name != "VTODO" {
+		t.Fatalf("components = %+v", calendar.Components)
+	}
+
+	event := calendar.Components[0]
+	if got := event.Text("SUMMARY"); got != "CS101 Lecture" {
+		t.Errorf("SUMMARY = %q, want the folded line joined", got)
+	}
+	start := event.Property("DTSTART")
+	if start == nil || start.Params["TZID"] != "Asia/Ho_Chi_Minh" || start.Params["X-NOTE"] != "a;b:c" || start.Value != "20261019T090000" {
+		t.Errorf("DTSTART = %+v", start)
+	}
+	if got := calendar.Components[1].Text("SUMMARY"); got != "lower case name" {
+		t.Errorf("property names are not case-insensitive, SUMMARY = %q", got)
+	}
+}
+
+func TestParseICalRejectsMalformed(t *testing.T) {
+	tests := map[string]string{
+		"no calendar":        "BEGIN:VEVENT\nEND:VEVENT\n",
+		"unclosed component": "BEGIN:VCALENDAR\nBEGIN:VEVENT\nEND:VCALENDAR\n",
+		"line without colon": "BEGIN:VCALENDAR\nSUMMARY\nEND:VCALENDAR\n",
+		"property outside":   "SUMMARY:x\nBEGIN:VCALENDAR\nEND:VCALENDAR\n",
+		"two calendars":      "BEGIN:VCALENDAR\nEND:VCALENDAR\nBEGIN:VCALENDAR\nEND:VCALENDAR\n",
+		"empty":              "",
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseICal(data); err == nil {
+				t.Error("ParseICal() succeeded")
+			}
+		})
+	}
+}
+
+func TestICalPropertyTime(t *testing.T) {
+	saigon, err := time.LoadLocation("Asia/Ho_Chi_Minh")
+	if err != nil {
+		t.Skipf("timezone data: %v", err)
+	}
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("timezone data: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		property   ICalProperty
+		want       time.Time
+		allDay     bool
+		wantErr    bool
+		unknownTZ  string
+		hasUnknown bool
+	}{
+		{
+			name:     "UTC",
+			property: ICalProperty{Value: "20261019T020000Z"},
+			want:     time.Date(2026, 10, 19, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "known TZID",
+			property: ICalProperty{Params: map[string]string{"TZID": "Europe/Berlin"}, Value: "20261019T090000"},
+			want:     time.Date(2026, 10, 19, 9, 0, 0, 0, berlin),
+		},
+		{
+			name:     "TZID with a leading slash",
+			property: ICalProperty{Params: map[string]string{"TZID": "/Europe/Berlin"}, Value: "20261019T090000"},
+			want:     time.Date(2026, 10, 19, 9, 0, 0, 0, berlin),
+		},
+		{
+			name:       "unknown TZID is read in the given location",
+			property:   ICalProperty{Params: map[string]string{"TZID": "SE Asia Standard Time"}, Value: "20261019T090000"},
+			want:       time.Date(2026, 10, 19, 9, 0, 0, 0, saigon),
+			unknownTZ:  "SE Asia Standard Time",
+			hasUnknown: true,
+		},
+		{
+			name:     "floating time",
+			property: ICalProperty{Value: "20261019T090000"},
+			want:     time.Date(2026, 10, 19, 9, 0, 0, 0, saigon),
+		},
+		{
+			name:     "all-day date",
+			property: ICalProperty{Params: map[string]string{"VALUE": "DATE"}, Value: "20261019"},
+			want:     time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+			allDay:   true,
+		},
+		{
+			name:     "date without VALUE parameter",
+			property: ICalProperty{Params: map[string]string{"TZID": "Nowhere/Unknown"}, Value: "20261019"},
+			want:     time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+			allDay:   true,
+		},
+		{
+			name:     "malformed",
+			property: ICalProperty{Value: "2026-10-19T09:00"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, allDay, err := tt.property.Time(saigon)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Time() = %v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Time() error = %v", err)
+			}
+			if !got.Equal(tt.want) || allDay != tt.allDay {
+				t.Errorf("Time() = %v, %v, want %v, %v", got, allDay, tt.want, tt.allDay)
+			}
+
+			tzid, unknown := tt.property.UnknownZone()
+			if tzid != tt.unknownTZ || unknown != tt.hasUnknown {
+				t.Errorf("UnknownZone() = %q, %v, want %q, %v", tzid, unknown, tt.unknownTZ, tt.hasUnknown)
+			}
+		})
+	}
+}
+
+func TestParseICalDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "PT1H30M", want: 90 * time.Minute},
+		{value: "PT45S", want: 45 * time.Second},
+		{value: "P1D", want: 24 * time.Hour},
+		{value: "P2W", want: 14 * 24 * time.Hour},
+		{value: "P1DT2H", want: 26 * time.Hour},
+		{value: "-PT15M", want: -15 * time.Minute},
+		{value: "+PT15M", want: 15 * time.Minute},
+		{value: " pt1h ", want: time.Hour},
+		{value: "PT", wantErr: true},
+		{value: "P", wantErr: true},
+		{value: "1H", wantErr: true},
+		{value: "PT1H30", wantErr: true},
+		{value: "P1H", wantErr: true},
+		{value: "PTH", wantErr: true},
+		{value: "P1T2H", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseICalDuration(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseICalDuration(%q) = %v, want an error", tt.value, got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ParseICalDuration(%q) = %v, %v, want %v", tt.value, got, err, tt.want)
+			}
+		})
+	}
+}
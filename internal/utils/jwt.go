@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/nas03/scholar-ai/backend/global"
+)
+
+// AccessClaims is the payload of an access token
+type AccessClaims struct {
+	SessionID string `json:"sid"`
+	jwt.RegisteredClaims
+}
+
+// GenerateAccessToken signs a short-lived access token for the given user and session
+func GenerateAccessToken(userID, sessionID string, ttl time.Duration) (string, error) {
+	if global.Config.Jwt.Secret == "" {
+		return "", errors.New("jwt secret is not configured")
+	}
+
+	now := time.Now()
+	claims := AccessClaims{
+		SessionID: sessionID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userID,
+			Issuer:    global.Config.Jwt.Issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(global.Config.Jwt.Secret))
+}
+
+// ParseAccessToken validates signature, issuer and expiry of an access token
+func ParseAccessToken(tokenString string) (*AccessClaims, error) {
+	if global.Config.Jwt.Secret == "" {
+		return nil, errors.New("jwt secret is not configured")
+	}
+
+	options := []jwt.ParserOption{jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()})}
+	if global.Config.Jwt.Issuer != "" {
+		options = append(options, jwt.WithIssuer(global.Config.Jwt.Issuer))
+	}
+
+	claims := &AccessClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		return []byte(global.Config.Jwt.Secret), nil
+	}, options...)
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid || claims.Subject == "" {
+		return nil, fmt.Errorf("invalid access token")
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	_ "embed"
+	"strings"
+	"unicode"
+
+	"github.com/nas03/scholar-ai/backend/global"
+	errMessage "github.com/nas03/scholar-ai/backend/pkg/errors"
+)
+
+const (
+	defaultPasswordMinLength = 8
+	// bcrypt ignores everything after 72 bytes
+	bcryptMaxPasswordLength = 72
+)
+
+//go:embed common_passwords.txt
+var commonPasswordList string
+
+var commonPasswords = parseCommonPasswords(commonPasswordList)
+
+// ValidatePassword checks a new password against the configured password policy
+func ValidatePassword(password string) error {
+	policy := global.Config.Password
+
+	minLength := policy.MinLength
+	if minLength <= 0 {
+		minLength = defaultPasswordMinLength
+	}
+	maxLength := policy.MaxLength
+	if maxLength <= 0 || maxLength > bcryptMaxPasswordLength {
+		maxLength = bcryptMaxPasswordLength
+	}
+
+	if password == "" {
+		return errMessage.ErrEmptyPassword
+	}
+	if len([]rune(password)) < minLength {
+		return errMessage.ErrPasswordTooShort
+	}
+	if len(password) > maxLength {
+		return errMessage.ErrPasswordTooLong
+	}
+	if policy.RequireLetterAndDigit && !hasLetterAndDigit(password) {
+		return errMessage.ErrPasswordTooWeak
+	}
+	if !policy.AllowCommon {
+		if _, ok := commonPasswords[strings.ToLower(password)]; ok {
+			return errMessage.ErrPasswordTooCommon
+		}
+	}
+
+	return nil
+}
+
+func hasLetterAndDigit(password string) bool {
+	var hasLetter, hasDigit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+	return hasLetter && hasDigit
+}
+
+func parseCommonPasswords(list string) map[string]struct{} {
+	passwords := make(map[string]struct{})
+	for _, line := range strings.Split(list, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		passwords[strings.ToLower(line)] = struct{}{}
+	}
+	return passwords
+}
@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+
+	errMessage "github.com/nas03/scholar-ai/backend/pkg/errors"
+)
+
+// e164Pattern is a plus sign followed by up to 15 digits, the first one non-zero
+var e164Pattern = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+
+// phoneSeparators are dropped before validation so "+1 (415) 555-2671" is accepted
+var phoneSeparators = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "")
+
+// NormalizePhoneNumber returns the E.164 form of a phone number written with common separators
+func NormalizePhoneNumber(phoneNumber string) (string, error) {
+	normalized := phoneSeparators.Replace(strings.TrimSpace(phoneNumber))
+	if strings.HasPrefix(normalized, "00") {
+		normalized = "+" + normalized[2:]
+	}
+	if !e164Pattern.MatchString(normalized) {
+		return "", errMessage.ErrInvalidPhone
+	}
+	return normalized, nil
+}
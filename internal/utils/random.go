@@ -2,6 +2,9 @@ package utils
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"math/big"
 )
 
@@ -11,3 +14,18 @@ func GenerateSixDigitOtp() int {
 	n, _ := rand.Int(rand.Reader, max)
 	return int(n.Int64()) + 100000
 }
+
+// GenerateOpaqueToken returns a url-safe random token built from n random bytes
+func GenerateOpaqueToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken returns the sha256 hex digest of a token, used to store secrets at rest
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -10,8 +10,16 @@ import (
 
 type IRedisCache interface {
 	Get(ctx context.Context, key string) (string, error)
+	GetDel(ctx context.Context, key string) (string, error)
 	Set(ctx context.Context, key string, data any) error
 	SetEx(ctx context.Context, key string, data any, exp time.Duration) error
+	SetNX(ctx context.Context, key string, data any, exp time.Duration) (bool, error)
+	// DelIfEqual deletes key only while it still holds value, e.g. to release a lock it owns
+	DelIfEqual(ctx context.Context, key string, value string) error
+	Del(ctx context.Context, keys ...string) error
+	Incr(ctx context.Context, key string) (int64, error)
+	Expire(ctx context.Context, key string, exp time.Duration) error
+	TTL(ctx context.Context, key string) (time.Duration, error)
 }
 
 type RedisCache struct {
@@ -28,6 +36,10 @@ func (r *RedisCache) Get(ctx context.Context, key string) (string, error) {
 	return r.client.Get(ctx, key).Result()
 }
 
+func (r *RedisCache) GetDel(ctx context.Context, key string) (string, error) {
+	return r.client.GetDel(ctx, key).Result()
+}
+
 func (r *RedisCache) Set(ctx context.Context, key string, data any) error {
 	return r.client.Set(ctx, key, data, 0).Err()
 }
@@ -35,3 +47,35 @@ func (r *RedisCache) Set(ctx context.Context, key string, data any) error {
 func (r *RedisCache) SetEx(ctx context.Context, key string, data any, exp time.Duration) error {
 	return r.client.SetEx(ctx, key, data, exp).Err()
 }
+
+func (r *RedisCache) SetNX(ctx context.Context, key string, data any, exp time.Duration) (bool, error) {
+	return r.client.SetNX(ctx, key, data, exp).Result()
+}
+
+// delIfEqualScript compares and deletes atomically
+var delIfEqualScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+func (r *RedisCache) DelIfEqual(ctx context.Context, key string, value string) error {
+	return delIfEqualScript.Run(ctx, r.client, []string{key}, value).Err()
+}
+
+func (r *RedisCache) Del(ctx context.Context, keys ...string) error {
+	return r.client.Del(ctx, keys...).Err()
+}
+
+func (r *RedisCache) Incr(ctx context.Context, key string) (int64, error) {
+	return r.client.Incr(ctx, key).Result()
+}
+
+func (r *RedisCache) Expire(ctx context.Context, key string, exp time.Duration) error {
+	return r.client.Expire(ctx, key, exp).Err()
+}
+
+func (r *RedisCache) TTL(ctx context.Context, key string) (time.Duration, error) {
+	return r.client.TTL(ctx, key).Result()
+}
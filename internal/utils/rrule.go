@@ -0,0 +1,363 @@
+package utils
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	errMessage "github.com/nas03/scholar-ai/backend/pkg/errors"
+)
+
+// Recurrence frequencies supported by RRule
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+)
+
+// Bounds of a recurrence rule, they keep a series cheap to expand
+const (
+	rruleMaxInterval = 999
+	rruleMaxCount    = 1000
+)
+
+// rruleMaxDate bounds the expansion of a series that ends by COUNT, it fits a DATETIME column
+var rruleMaxDate = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+// rruleUntilLayout is the UTC form of UNTIL, a bare date uses rruleDateLayout
+const (
+	rruleUntilLayout = "20060102T150405Z"
+	rruleDateLayout  = "20060102"
+)
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// RRule is the subset of an iCalendar recurrence rule (RFC 5545) reminders support:
+// FREQ=DAILY, WEEKLY or MONTHLY with INTERVAL, BYDAY, COUNT and UNTIL. Weeks start on Monday.
+type RRule struct {
+	Freq      string
+	Interval  int
+	ByDay     []RRuleDay
+	Count     int        // 0 when the count is unbounded
+	Until     *time.Time // last allowed instant, or last date as midnight UTC when UntilDate
+	UntilDate bool
+}
+
+// RRuleDay is a BYDAY entry such as "MO" or, for MONTHLY rules, "2TU" and "-1FR"
+type RRuleDay struct {
+	Ordinal int // nth weekday of the month, negative counts from the end, 0 for every one
+	Weekday time.Weekday
+}
+
+// ParseRRule parses a recurrence rule, with or without the "RRULE:" prefix
+func ParseRRule(value string) (*RRule, error) {
+	value = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(value)), "RRULE:")
+	if value == "" {
+		return nil, errMessage.ErrInvalidRecurrenceRule
+	}
+
+	rule := &RRule{Interval: 1}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok || val == "" || seen[key] {
+			return nil, errMessage.ErrInvalidRecurrenceRule
+		}
+		seen[key] = true
+
+		var err error
+		switch key {
+		case "FREQ":
+			if val != FreqDaily && val != FreqWeekly && val != FreqMonthly {
+				return nil, errMessage.ErrInvalidRecurrenceRule
+			}
+			rule.Freq = val
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(val)
+			if err != nil || rule.Interval < 1 || rule.Interval > rruleMaxInterval {
+				return nil, errMessage.ErrInvalidRecurrenceRule
+			}
+		case "COUNT":
+			rule.Count, err = strconv.Atoi(val)
+			if err != nil || rule.Count < 1 || rule.Count > rruleMaxCount {
+				return nil, errMessage.ErrInvalidRecurrenceRule
+			}
+		case "UNTIL":
+			if err := rule.parseUntil(val); err != nil {
+				return nil, err
+			}
+		case "BYDAY":
+			if err := rule.parseByDay(val); err != nil {
+				return nil, err
+			}
+		case "WKST":
+			// Only the default week start is supported
+			if val != "MO" {
+				return nil, errMessage.ErrInvalidRecurrenceRule
+			}
+		default:
+			return nil, errMessage.ErrInvalidRecurrenceRule
+		}
+	}
+
+	if rule.Freq == "" || (rule.Count != 0 && rule.Until != nil) {
+		return nil, errMessage.ErrInvalidRecurrenceRule
+	}
+	for _, day := range rule.ByDay {
+		if day.Ordinal != 0 && rule.Freq != FreqMonthly {
+			return nil, errMessage.ErrInvalidRecurrenceRule
+		}
+	}
+	return rule, nil
+}
+
+func (r *RRule) parseUntil(value string) error {
+	if until, err := time.Parse(rruleUntilLayout, value); err == nil {
+		r.Until = &until
+		return nil
+	}
+	until, err := time.Parse(rruleDateLayout, value)
+	if err != nil {
+		return errMessage.ErrInvalidRecurrenceRule
+	}
+	r.Until = &until
+	r.UntilDate = true
+	return nil
+}
+
+func (r *RRule) parseByDay(value string) error {
+	for _, entry := range strings.Split(value, ",") {
+		if len(entry) < 2 {
+			return errMessage.ErrInvalidRecurrenceRule
+		}
+		weekday, ok := rruleWeekdays[entry[len(entry)-2:]]
+		if !ok {
+			return errMessage.ErrInvalidRecurrenceRule
+		}
+
+		day := RRuleDay{Weekday: weekday}
+		if prefix := entry[:len(entry)-2]; prefix != "" {
+			ordinal, err := strconv.Atoi(prefix)
+			if err != nil || ordinal == 0 || ordinal < -5 || ordinal > 5 {
+				return errMessage.ErrInvalidRecurrenceRule
+			}
+			day.Ordinal = ordinal
+		}
+		r.ByDay = append(r.ByDay, day)
+	}
+	return nil
+}
+
+// String returns the rule in its canonical form, e.g. "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10"
+func (r *RRule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			days[i] = strings.ToUpper(day.Weekday.String()[:2])
+			if day.Ordinal != 0 {
+				days[i] = strconv.Itoa(day.Ordinal) + days[i]
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.Count != 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		if r.UntilDate {
+			parts = append(parts, "UNTIL="+r.Until.Format(rruleDateLayout))
+		} else {
+			parts = append(parts, "UNTIL="+r.Until.UTC().Format(rruleUntilLayout))
+		}
+	}
+	return strings.Join(parts, ";")
+}
+
+// EndBefore makes the series stop before date, keeping the occurrences it already had
+func (r *RRule) EndBefore(date time.Time) {
+	until := date.AddDate(0, 0, -1)
+	r.Count = 0
+	r.Until = &until
+	r.UntilDate = true
+}
+
+// Dates returns the occurrence dates of a series starting on start up to and including last, in order.
+// Dates are midnight UTC, clock is the "15:04" wall clock time of every occurrence in location,
+// needed to compare against an UNTIL instant.
+func (r *RRule) Dates(start time.Time, clock string, location *time.Location, last time.Time) []time.Time {
+	var dates []time.Time
+
+	// visit returns false once the series is over
+	visit := func(date time.Time) bool {
+		if date.After(last) || r.pastUntil(date, clock, location) {
+			return false
+		}
+		if !date.Before(start) {
+			dates = append(dates, date)
+		}
+		return r.Count == 0 || len(dates) < r.Count
+	}
+
+	switch r.Freq {
+	case FreqDaily:
+		for date := start; ; date = date.AddDate(0, 0, r.Interval) {
+			if r.matchesWeekday(date) {
+				if !visit(date) {
+					return dates
+				}
+			} else if date.After(last) {
+				return dates
+			}
+		}
+	case FreqWeekly:
+		offsets := r.weekdayOffsets(start)
+		for week := start.AddDate(0, 0, -mondayOffset(start.Weekday())); !week.After(last); week = week.AddDate(0, 0, 7*r.Interval) {
+			for _, offset := range offsets {
+				if !visit(week.AddDate(0, 0, offset)) {
+					return dates
+				}
+			}
+		}
+	case FreqMonthly:
+		for month := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC); !month.After(last); month = month.AddDate(0, r.Interval, 0) {
+			for _, date := range r.monthDates(month, start.Day()) {
+				if !visit(date) {
+					return dates
+				}
+			}
+		}
+	}
+	return dates
+}
+
+// LastDate returns the date of the last occurrence of a series starting on start, skipped dates aside,
+// and false when the series has no end. A series with no occurrence at all ends on start.
+func (r *RRule) LastDate(start time.Time, clock string, location *time.Location) (time.Time, bool) {
+	if r.Count == 0 && r.Until == nil {
+		return time.Time{}, false
+	}
+
+	last := rruleMaxDate
+	if r.Until != nil {
+		// An UNTIL instant falls on its UTC date or the day after in any timezone
+		year, month, day := r.Until.Date()
+		last = time.Date(year, month, day+1, 0, 0, 0, 0, time.UTC)
+	}
+
+	dates := r.Dates(start, clock, location, last)
+	if len(dates) == 0 {
+		return start, true
+	}
+	return dates[len(dates)-1], true
+}
+
+// Occurs reports whether date is an occurrence of a series starting on start
+func (r *RRule) Occurs(start time.Time, clock string, location *time.Location, date time.Time) bool {
+	dates := r.Dates(start, clock, location, date)
+	return len(dates) > 0 && dates[len(dates)-1].Equal(date)
+}
+
+func (r *RRule) pastUntil(date time.Time, clock string, location *time.Location) bool {
+	if r.Until == nil {
+		return false
+	}
+	if r.UntilDate {
+		return date.After(*r.Until)
+	}
+
+	at, err := ParseDateTimeIn(date.Format(DateLayout), clock, location)
+	return err == nil && at.After(*r.Until)
+}
+
+func (r *RRule) matchesWeekday(date time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, day := range r.ByDay {
+		if day.Weekday == date.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// weekdayOffsets returns the days of a weekly rule as offsets from Monday, the start weekday by default
+func (r *RRule) weekdayOffsets(start time.Time) []int {
+	if len(r.ByDay) == 0 {
+		return []int{mondayOffset(start.Weekday())}
+	}
+
+	seen := make(map[int]bool)
+	var offsets []int
+	for _, day := range r.ByDay {
+		offset := mondayOffset(day.Weekday)
+		if !seen[offset] {
+			seen[offset] = true
+			offsets = append(offsets, offset)
+		}
+	}
+	sort.Ints(offsets)
+	return offsets
+}
+
+// monthDates returns the dates of a monthly rule in the month starting on month, in order.
+// Without BYDAY that is day of the month, skipped in months too short for it.
+func (r *RRule) monthDates(month time.Time, day int) []time.Time {
+	daysInMonth := month.AddDate(0, 1, -1).Day()
+	if len(r.ByDay) == 0 {
+		if day > daysInMonth {
+			return nil
+		}
+		return []time.Time{month.AddDate(0, 0, day-1)}
+	}
+
+	seen := make(map[int]bool)
+	for _, byDay := range r.ByDay {
+		first := 1 + (int(byDay.Weekday)-int(month.Weekday())+7)%7
+		switch {
+		case byDay.Ordinal == 0:
+			for d := first; d <= daysInMonth; d += 7 {
+				seen[d] = true
+			}
+		case byDay.Ordinal > 0:
+			if d := first + 7*(byDay.Ordinal-1); d <= daysInMonth {
+				seen[d] = true
+			}
+		default:
+			lastOfWeekday := first + 7*((daysInMonth-first)/7)
+			if d := lastOfWeekday + 7*(byDay.Ordinal+1); d >= 1 {
+				seen[d] = true
+			}
+		}
+	}
+
+	days := make([]int, 0, len(seen))
+	for d := range seen {
+		days = append(days, d)
+	}
+	sort.Ints(days)
+
+	dates := make([]time.Time, len(days))
+	for i, d := range days {
+		dates[i] = month.AddDate(0, 0, d-1)
+	}
+	return dates
+}
+
+// mondayOffset is the number of days from Monday to weekday
+func mondayOffset(weekday time.Weekday) int {
+	return (int(weekday) + 6) % 7
+}
@@ -0,0 +1,239 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func formatDates(dates []time.Time) string {
+	values := make([]string, len(dates))
+	for i, d := range dates {
+		values[i] = d.Format(DateLayout)
+	}
+	return strings.Join(values, " ")
+}
+
+func TestParseRRule(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string // canonical form, empty when the rule is rejected
+	}{
+		{value: "FREQ=WEEKLY", want: "FREQ=WEEKLY"},
+		{value: "RRULE:freq=weekly;byday=mo,we;count=10", want: "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10"},
+		{value: " FREQ=DAILY;INTERVAL=1 ", want: "FREQ=DAILY"},
+		{value: "FREQ=DAILY;INTERVAL=3;UNTIL=20261231", want: "FREQ=DAILY;INTERVAL=3;UNTIL=20261231"},
+		{value: "FREQ=MONTHLY;BYDAY=2TU,-1FR;UNTIL=20261231T235959Z", want: "FREQ=MONTHLY;BYDAY=2TU,-1FR;UNTIL=20261231T235959Z"},
+		{value: "FREQ=WEEKLY;WKST=MO", want: "FREQ=WEEKLY"},
+		{value: "", want: ""},
+		{value: "INTERVAL=2", want: ""},
+		{value: "FREQ=YEARLY", want: ""},
+		{value: "FREQ=WEEKLY;INTERVAL=0", want: ""},
+		{value: "FREQ=WEEKLY;INTERVAL=1000", want: ""},
+		{value: "FREQ=WEEKLY;COUNT=0", want: ""},
+		{value: "FREQ=WEEKLY;COUNT=1001", want: ""},
+		{value: "FREQ=WEEKLY;COUNT=2;UNTIL=20261231", want: ""},
+		{value: "FREQ=WEEKLY;FREQ=DAILY", want: ""},
+		{value: "FREQ=WEEKLY;BYDAY=XX", want: ""},
+		{value: "FREQ=WEEKLY;BYDAY=2MO", want: ""},
+		{value: "FREQ=MONTHLY;BYDAY=6MO", want: ""},
+		{value: "FREQ=MONTHLY;BYDAY=0MO", want: ""},
+		{value: "FREQ=WEEKLY;WKST=SU", want: ""},
+		{value: "FREQ=WEEKLY;UNTIL=2026-12-31", want: ""},
+		{value: "FREQ=WEEKLY;BYMONTH=1", want: ""},
+		{value: "FREQ=WEEKLY;COUNT", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			rule, err := ParseRRule(tt.value)
+			if tt.want == "" {
+				if err == nil {
+					t.Fatalf("ParseRRule(%q) = %s, want an error", tt.value, rule)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRRule(%q) error = %v", tt.value, err)
+			}
+			if got := rule.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRRuleDates(t *testing.T) {
+	// 2026-10-05 is a Monday
+	tests := []struct {
+		name  string
+		rule  string
+		start time.Time
+		last  time.Time
+		want  string
+	}{
+		{
+			name:  "daily",
+			rule:  "FREQ=DAILY;INTERVAL=2",
+			start: date(2026, 10, 5), last: date(2026, 10, 12),
+			want: "2026-10-05 2026-10-07 2026-10-09 2026-10-11",
+		},
+		{
+			name:  "daily on weekdays",
+			rule:  "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR;COUNT=6",
+			start: date(2026, 10, 8), last: date(2026, 12, 31),
+			want: "2026-10-08 2026-10-09 2026-10-12 2026-10-13 2026-10-14 2026-10-15",
+		},
+		{
+			name:  "weekly on the start weekday",
+			rule:  "FREQ=WEEKLY;COUNT=3",
+			start: date(2026, 10, 7), last: date(2026, 12, 31),
+			want: "2026-10-07 2026-10-14 2026-10-21",
+		},
+		{
+			name:  "weekly BYDAY skips days before start",
+			rule:  "FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=4",
+			start: date(2026, 10, 7), last: date(2026, 12, 31),
+			want: "2026-10-07 2026-10-09 2026-10-12 2026-10-14",
+		},
+		{
+			name:  "every other week",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU",
+			start: date(2026, 10, 6), last: date(2026, 11, 10),
+			want: "2026-10-06 2026-10-20 2026-11-03",
+		},
+		{
+			name:  "monthly skips short months",
+			rule:  "FREQ=MONTHLY;COUNT=4",
+			start: date(2027, 1, 31), last: date(2027, 12, 31),
+			want: "2027-01-31 2027-03-31 2027-05-31 2027-07-31",
+		},
+		{
+			name:  "monthly nth and last weekday",
+			rule:  "FREQ=MONTHLY;BYDAY=2TU,-1FR",
+			start: date(2026, 10, 1), last: date(2026, 11, 30),
+			want: "2026-10-13 2026-10-30 2026-11-10 2026-11-27",
+		},
+		{
+			name:  "until a date is inclusive",
+			rule:  "FREQ=WEEKLY;UNTIL=20261019",
+			start: date(2026, 10, 5), last: date(2026, 12, 31),
+			want: "2026-10-05 2026-10-12 2026-10-19",
+		},
+		{
+			name:  "last bounds the expansion",
+			rule:  "FREQ=DAILY",
+			start: date(2026, 10, 5), last: date(2026, 10, 7),
+			want: "2026-10-05 2026-10-06 2026-10-07",
+		},
+		{
+			name:  "last before start",
+			rule:  "FREQ=DAILY",
+			start: date(2026, 10, 5), last: date(2026, 10, 4),
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRRule(tt.rule)
+			if err != nil {
+				t.Fatalf("ParseRRule(%q) error = %v", tt.rule, err)
+			}
+			if got := formatDates(rule.Dates(tt.start, "09:00", time.UTC, tt.last)); got != tt.want {
+				t.Errorf("Dates() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRRuleUntilInstant(t *testing.T) {
+	saigon, err := time.LoadLocation("Asia/Ho_Chi_Minh")
+	if err != nil {
+		t.Skipf("timezone data: %v", err)
+	}
+
+	// 09:00 in Saigon is 02:00 UTC, so an UNTIL of 01:00 UTC on the 19th ends the series on the 12th
+	rule, err := ParseRRule("FREQ=WEEKLY;UNTIL=20261019T010000Z")
+	if err != nil {
+		t.Fatalf("ParseRRule() error = %v", err)
+	}
+	if got, want := formatDates(rule.Dates(date(2026, 10, 5), "09:00", saigon, date(2026, 12, 31))), "2026-10-05 2026-10-12"; got != want {
+		t.Errorf("Dates() = %s, want %s", got, want)
+	}
+
+	rule, _ = ParseRRule("FREQ=WEEKLY;UNTIL=20261019T020000Z")
+	if got, want := formatDates(rule.Dates(date(2026, 10, 5), "09:00", saigon, date(2026, 12, 31))), "2026-10-05 2026-10-12 2026-10-19"; got != want {
+		t.Errorf("Dates() = %s, want %s", got, want)
+	}
+}
+
+func TestRRuleOccurs(t *testing.T) {
+	rule, err := ParseRRule("FREQ=WEEKLY;BYDAY=MO,TH;COUNT=4")
+	if err != nil {
+		t.Fatalf("ParseRRule() error = %v", err)
+	}
+	start := date(2026, 10, 5)
+
+	for day, want := range map[time.Time]bool{
+		date(2026, 10, 5):  true,
+		date(2026, 10, 8):  true,
+		date(2026, 10, 15): true,
+		date(2026, 10, 6):  false,
+		date(2026, 10, 19): false, // past COUNT
+		date(2026, 9, 28):  false, // before start
+	} {
+		if got := rule.Occurs(start, "09:00", time.UTC, day); got != want {
+			t.Errorf("Occurs(%s) = %v, want %v", day.Format(DateLayout), got, want)
+		}
+	}
+}
+
+func TestRRuleEndBefore(t *testing.T) {
+	rule, err := ParseRRule("FREQ=WEEKLY;COUNT=10")
+	if err != nil {
+		t.Fatalf("ParseRRule() error = %v", err)
+	}
+	rule.EndBefore(date(2026, 10, 19))
+
+	if got, want := rule.String(), "FREQ=WEEKLY;UNTIL=20261018"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got, want := formatDates(rule.Dates(date(2026, 10, 5), "09:00", time.UTC, date(2026, 12, 31))), "2026-10-05 2026-10-12"; got != want {
+		t.Errorf("Dates() = %s, want %s", got, want)
+	}
+}
+
+func TestRRuleLastDate(t *testing.T) {
+	tests := []struct {
+		rule    string
+		start   time.Time
+		want    time.Time
+		bounded bool
+	}{
+		{rule: "FREQ=WEEKLY", start: date(2026, 10, 5), bounded: false},
+		{rule: "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=5", start: date(2026, 10, 5), want: date(2026, 10, 19), bounded: true},
+		{rule: "FREQ=DAILY;INTERVAL=3;UNTIL=20261020", start: date(2026, 10, 5), want: date(2026, 10, 20), bounded: true},
+		{rule: "FREQ=DAILY;UNTIL=20261020T080000Z", start: date(2026, 10, 5), want: date(2026, 10, 19), bounded: true},
+		{rule: "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3", start: date(2026, 10, 1), want: date(2026, 12, 25), bounded: true},
+		{rule: "FREQ=MONTHLY;INTERVAL=999;COUNT=1000", start: date(2026, 10, 1), want: date(9935, 7, 1), bounded: true},
+		{rule: "FREQ=WEEKLY;UNTIL=20261001", start: date(2026, 10, 5), want: date(2026, 10, 5), bounded: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			rule, err := ParseRRule(tt.rule)
+			if err != nil {
+				t.Fatalf("ParseRRule(%q) error = %v", tt.rule, err)
+			}
+			got, bounded := rule.LastDate(tt.start, "09:00", time.UTC)
+			if bounded != tt.bounded || !got.Equal(tt.want) {
+				t.Errorf("LastDate() = %s, %v, want %s, %v", got.Format(DateLayout), bounded, tt.want.Format(DateLayout), tt.bounded)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"time"
+	// Embedded zone database, so timezones work on hosts without tzdata
+	_ "time/tzdata"
+
+	errMessage "github.com/nas03/scholar-ai/backend/pkg/errors"
+)
+
+// DefaultTimezone is used for users that never picked one
+const DefaultTimezone = "UTC"
+
+// LoadLocation returns the location of an IANA timezone name, UTC when empty
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	// "Local" would silently follow the server timezone
+	if name == "Local" {
+		return nil, errMessage.ErrInvalidTimezone
+	}
+
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, errMessage.ErrInvalidTimezone
+	}
+	return location, nil
+}
+
+// DateLayout is the wire format of calendar dates
+const DateLayout = "2006-01-02"
+
+// ParseDate parses a calendar date as midnight UTC, the form dates are stored in
+func ParseDate(value string) (time.Time, error) {
+	return time.Parse(DateLayout, value)
+}
+
+// TodayIn returns the current calendar date in location as midnight UTC
+func TodayIn(location *time.Location) time.Time {
+	year, month, day := time.Now().In(location).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// ClockLayout is the wire format of wall clock times, TIME columns read back with seconds
+const ClockLayout = "15:04"
+
+// WallClock returns the "15:04" form of a wall clock time read as "15:04:05" or "15:04"
+func WallClock(value string) (string, error) {
+	t, err := time.Parse("15:04:05", value)
+	if err != nil {
+		if t, err = time.Parse(ClockLayout, value); err != nil {
+			return "", err
+		}
+	}
+	return t.Format(ClockLayout), nil
+}
+
+// ParseDateTimeIn reads a calendar date and a "15:04" wall clock time in location as an instant.
+// Times skipped by a daylight saving jump are moved forward like time.Date does.
+func ParseDateTimeIn(date, clock string, location *time.Location) (time.Time, error) {
+	return time.ParseInLocation(DateLayout+" 15:04", date+" "+clock, location)
+}
@@ -0,0 +1,34 @@
+package utils
+
+import "testing"
+
+func TestWallClock(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{value: "09:30:00", want: "09:30"},
+		{value: "23:59:59", want: "23:59"},
+		{value: "07:05", want: "07:05"},
+		{value: "", wantErr: true},
+		{value: "9:3", wantErr: true},
+		{value: "25:00:00", wantErr: true},
+		{value: "noon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := WallClock(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("WallClock(%q) = %q, want an error", tt.value, got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("WallClock(%q) = %q, %v, want %q", tt.value, got, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package errors
+
+import "errors"
+
+var (
+	ErrAssessmentNotFound       = errors.New("assessment component not found")
+	ErrInvalidAssessmentWeights = errors.New("assessment weights must sum to 100 percent")
+	ErrInvalidAssessmentScore   = errors.New("assessment score is above its max score")
+)
@@ -0,0 +1,25 @@
+package errors
+
+import "errors"
+
+var (
+	ErrInvalidCredentials  = errors.New("invalid credentials")
+	ErrAccountInactive     = errors.New("account is inactive")
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenExpired = errors.New("refresh token has expired")
+	ErrRefreshTokenReused  = errors.New("refresh token reuse detected")
+	ErrInvalidAccessToken  = errors.New("invalid access token")
+	ErrSessionRevoked      = errors.New("session has been revoked")
+	ErrForbidden           = errors.New("insufficient role")
+	ErrInvalidResetToken   = errors.New("invalid or expired password reset token")
+	ErrLoginLocked         = errors.New("login temporarily locked after failed attempts")
+	ErrInvalidTwoFactor    = errors.New("invalid two-factor code")
+	ErrTwoFactorEnabled    = errors.New("two-factor already enabled")
+	ErrTwoFactorNotEnabled = errors.New("two-factor not enabled")
+	ErrInvalidChallenge    = errors.New("invalid login challenge")
+	ErrSsoProviderNotFound = errors.New("sso provider not configured")
+	ErrInvalidSsoState     = errors.New("invalid sso state")
+	ErrSsoEmailNotVerified = errors.New("sso email not verified")
+	ErrIdentityLinked      = errors.New("identity already linked")
+	ErrIdentityNotFound    = errors.New("identity not found")
+)
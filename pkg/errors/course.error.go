@@ -0,0 +1,18 @@
+package errors
+
+import "errors"
+
+var (
+	ErrCourseNotFound      = errors.New("course not found")
+	ErrCourseAlreadyExists = errors.New("course code already exists in semester")
+	ErrInvalidCredits      = errors.New("credits out of range")
+	ErrInvalidGrade        = errors.New("grade is not on the grading scale")
+	ErrSemesterNotFound    = errors.New("semester not found")
+	ErrInvalidSemesterDate = errors.New("semester end date before start date")
+	ErrSemesterOverlap     = errors.New("semester overlaps another semester")
+	ErrSemesterHasCourses  = errors.New("semester still has courses")
+	ErrLecturerNotFound    = errors.New("lecturer not found")
+	ErrLecturerExists      = errors.New("lecturer name already exists")
+	ErrTagNotFound         = errors.New("tag not found")
+	ErrTagExists           = errors.New("tag name already exists")
+)
@@ -0,0 +1,10 @@
+package errors
+
+import "errors"
+
+var (
+	ErrAssignmentNotFound      = errors.New("assignment not found")
+	ErrExamNotFound            = errors.New("exam not found")
+	ErrInvalidStatusTransition = errors.New("status change not allowed")
+	ErrInvalidExamTime         = errors.New("exam must end after it starts")
+)
@@ -0,0 +1,8 @@
+package errors
+
+import "errors"
+
+var (
+	ErrGradingScaleNotFound = errors.New("grading scale not found")
+	ErrInvalidGradingScale  = errors.New("invalid grading scale")
+)
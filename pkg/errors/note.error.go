@@ -0,0 +1,10 @@
+package errors
+
+import "errors"
+
+var (
+	ErrNoteNotFound         = errors.New("note not found")
+	ErrNoteRevisionNotFound = errors.New("note revision not found")
+	ErrInvalidNoteContent   = errors.New("note content is not valid JSON")
+	ErrNoteVersionConflict  = errors.New("note was saved since the edited version")
+)
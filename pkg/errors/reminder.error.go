@@ -0,0 +1,9 @@
+package errors
+
+import "errors"
+
+var (
+	ErrReminderNotFound      = errors.New("reminder not found")
+	ErrInvalidRecurrenceRule = errors.New("invalid recurrence rule")
+	ErrOccurrenceNotFound    = errors.New("reminder occurrence not found")
+)
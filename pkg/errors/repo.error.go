@@ -9,10 +9,24 @@ var (
 	ErrInvalidInput      = errors.New("invalid input parameters")
 	ErrDatabaseError     = errors.New("database operation failed")
 	ErrEmptyPassword     = errors.New("password cannot be empty")
+	ErrPasswordTooShort  = errors.New("password is too short")
+	ErrPasswordTooLong   = errors.New("password is too long")
+	ErrPasswordTooWeak   = errors.New("password must contain letters and digits")
+	ErrPasswordTooCommon = errors.New("password is too common")
 	ErrInvalidOTP        = errors.New("invalid OTP provided")
 	ErrOTPExpired        = errors.New("OTP has expired")
 	ErrEmailNotVerified  = errors.New("email not verified")
 	ErrPhoneNotVerified  = errors.New("phone number not verified")
 	ErrInvalidEmail      = errors.New("invalid email format")
 	ErrInvalidUsername   = errors.New("invalid username format")
+	ErrEmailVerified     = errors.New("email already verified")
+	ErrOTPLocked         = errors.New("OTP locked after too many failed attempts")
+	ErrOTPResendCooldown = errors.New("OTP resend is cooling down")
+	ErrOTPResendLimit    = errors.New("OTP daily send limit reached")
+	ErrInvalidPhone      = errors.New("invalid phone number")
+	ErrPhoneVerified     = errors.New("phone number already verified")
+	ErrPhoneMissing      = errors.New("phone number not set")
+	ErrInvalidTimezone   = errors.New("invalid timezone")
+	ErrDeletionScheduled = errors.New("account deletion already scheduled")
+	ErrDeletionMissing   = errors.New("account deletion not scheduled")
 )
@@ -0,0 +1,9 @@
+package errors
+
+import "errors"
+
+var (
+	ErrClassSessionNotFound = errors.New("class session not found")
+	ErrClassSessionConflict = errors.New("class session overlaps another session")
+	ErrInvalidSessionTime   = errors.New("class session ends before it starts")
+)
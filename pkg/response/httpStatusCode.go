@@ -5,19 +5,56 @@ const (
 	CodeSuccess = 200
 
 	// User related codes
-	CodeRegisterInternalError = 2001
-	CodeUserAlreadyExists     = 2002
-	CodeUserNotFound          = 2003
-	CodeFailedGetUser         = 2004
-	CodeFailedUpdateUser      = 2005
-	CodeInvalidInput          = 2006
-	CodeInvalidOTP            = 2007
-	CodeOTPExpired            = 2008
-	CodeEmailNotVerified      = 2009
-	CodePhoneNotVerified      = 2010
-	CodeInvalidEmail          = 2011
-	CodeInvalidUsername       = 2012
-	CodeEmptyPassword         = 2013
+	CodeRegisterInternalError  = 2001
+	CodeUserAlreadyExists      = 2002
+	CodeUserNotFound           = 2003
+	CodeFailedGetUser          = 2004
+	CodeFailedUpdateUser       = 2005
+	CodeInvalidInput           = 2006
+	CodeInvalidOTP             = 2007
+	CodeOTPExpired             = 2008
+	CodeEmailNotVerified       = 2009
+	CodePhoneNotVerified       = 2010
+	CodeInvalidEmail           = 2011
+	CodeInvalidUsername        = 2012
+	CodeEmptyPassword          = 2013
+	CodeEmailAlreadyVerified   = 2014
+	CodeOTPLocked              = 2015
+	CodeOTPResendCooldown      = 2016
+	CodeOTPResendLimitExceeded = 2017
+	CodePasswordTooShort       = 2018
+	CodePasswordTooLong        = 2019
+	CodePasswordTooWeak        = 2020
+	CodePasswordTooCommon      = 2021
+	CodeInvalidPhoneNumber     = 2022
+	CodePhoneAlreadyVerified   = 2023
+	CodePhoneNumberMissing     = 2024
+	CodeInvalidTimezone        = 2025
+	CodeDeletionScheduled      = 2026
+	CodeDeletionNotScheduled   = 2027
+
+	// Auth related codes
+	CodeInvalidCredentials   = 4001
+	CodeAccountInactive      = 4002
+	CodeInvalidRefreshToken  = 4003
+	CodeRefreshTokenReused   = 4004
+	CodeLoginInternalError   = 4005
+	CodeUnauthorized         = 4006
+	CodeForbidden            = 4007
+	CodeInvalidResetToken    = 4008
+	CodeLoginLocked          = 4009
+	CodeTwoFactorRequired    = 4010
+	CodeInvalidTwoFactor     = 4011
+	CodeTwoFactorEnabled     = 4012
+	CodeTwoFactorNotEnabled  = 4013
+	CodeTwoFactorNotEnrolled = 4014
+	CodeInvalidChallenge     = 4015
+	CodeSsoProviderNotFound  = 4016
+	CodeInvalidSsoState      = 4017
+	CodeSsoFailed            = 4018
+	CodeSsoEmailNotVerified  = 4019
+	CodeIdentityLinked       = 4020
+	CodeIdentityNotFound     = 4021
 
 	// Mail related codes
 	CodeMailConfigMissing    = 3001
@@ -26,6 +63,88 @@ const (
 	CodeMailClientCreation   = 3004
 	CodeMailConnectionFailed = 3005
 	CodeMailSendFailed       = 3006
+
+	// SMS related codes
+	CodeSmsSendFailed = 5001
+
+	// Course related codes
+	CodeCourseNotFound      = 6001
+	CodeCourseAlreadyExists = 6002
+	CodeInvalidCredits      = 6003
+	CodeInvalidGrade        = 6004
+	CodeFailedGetCourse     = 6005
+	CodeFailedUpdateCourse  = 6006
+
+	// Semester related codes
+	CodeSemesterNotFound     = 6101
+	CodeInvalidSemesterDates = 6102
+	CodeSemesterOverlap      = 6103
+	CodeSemesterHasCourses   = 6104
+	CodeFailedGetSemester    = 6105
+	CodeFailedUpdateSemester = 6106
+
+	// Lecturer related codes
+	CodeLecturerNotFound      = 6201
+	CodeLecturerAlreadyExists = 6202
+	CodeFailedGetLecturer     = 6203
+	CodeFailedUpdateLecturer  = 6204
+
+	// Tag related codes
+	CodeTagNotFound      = 6301
+	CodeTagAlreadyExists = 6302
+	CodeFailedGetTag     = 6303
+	CodeFailedUpdateTag  = 6304
+
+	// Reminder related codes
+	CodeReminderNotFound     = 6401
+	CodeFailedGetReminder    = 6402
+	CodeFailedUpdateReminder = 6403
+	CodeOccurrenceNotFound   = 6404
+	CodeInvalidRecurrence    = 6405
+
+	// Class session related codes
+	CodeClassSessionNotFound     = 6501
+	CodeClassSessionConflict     = 6502
+	CodeInvalidSessionTime       = 6503
+	CodeFailedGetClassSession    = 6504
+	CodeFailedUpdateClassSession = 6505
+
+	// Calendar related codes
+	CodeCalendarFeedNotFound     = 6601
+	CodeFailedGetCalendar        = 6602
+	CodeFailedUpdateCalendarFeed = 6603
+	CodeInvalidCalendarFile      = 6604
+	CodeFailedImportCalendar     = 6605
+
+	// Grading related codes
+	CodeGradingScaleNotFound     = 6701
+	CodeInvalidGradingScale      = 6702
+	CodeFailedGetGradingScale    = 6703
+	CodeFailedUpdateGradingScale = 6704
+	CodeFailedGetGPA             = 6705
+
+	// Assessment related codes
+	CodeAssessmentNotFound       = 6801
+	CodeInvalidAssessmentWeights = 6802
+	CodeInvalidAssessmentScore   = 6803
+	CodeFailedGetAssessment      = 6804
+	CodeFailedUpdateAssessment   = 6805
+
+	// Coursework related codes
+	CodeAssignmentNotFound      = 6901
+	CodeExamNotFound            = 6902
+	CodeInvalidStatusTransition = 6903
+	CodeInvalidExamTime         = 6904
+	CodeFailedGetCoursework     = 6905
+	CodeFailedUpdateCoursework  = 6906
+
+	// Note related codes
+	CodeNoteNotFound         = 7001
+	CodeNoteRevisionNotFound = 7002
+	CodeInvalidNoteContent   = 7003
+	CodeNoteVersionConflict  = 7004
+	CodeFailedGetNote        = 7005
+	CodeFailedUpdateNote     = 7006
 )
 
 // Error messages mapping (following fidecwalletserver pattern)
@@ -33,19 +152,56 @@ var msg = map[int]string{
 	CodeSuccess: "Success",
 
 	// User related messages
-	CodeRegisterInternalError: "Internal server error occurred during registration",
-	CodeUserAlreadyExists:     "User already exists with this email or username",
-	CodeUserNotFound:          "User not found",
-	CodeFailedGetUser:         "Failed to retrieve user information",
-	CodeFailedUpdateUser:      "Failed to update user information",
-	CodeInvalidInput:          "Invalid input parameters provided",
-	CodeInvalidOTP:            "Invalid OTP provided",
-	CodeOTPExpired:            "OTP has expired",
-	CodeEmailNotVerified:      "Email address not verified",
-	CodePhoneNotVerified:      "Phone number not verified",
-	CodeInvalidEmail:          "Invalid email format",
-	CodeInvalidUsername:       "Invalid username format",
-	CodeEmptyPassword:         "Password cannot be empty",
+	CodeRegisterInternalError:  "Internal server error occurred during registration",
+	CodeUserAlreadyExists:      "User already exists with this email or username",
+	CodeUserNotFound:           "User not found",
+	CodeFailedGetUser:          "Failed to retrieve user information",
+	CodeFailedUpdateUser:       "Failed to update user information",
+	CodeInvalidInput:           "Invalid input parameters provided",
+	CodeInvalidOTP:             "Invalid OTP provided",
+	CodeOTPExpired:             "OTP has expired",
+	CodeEmailNotVerified:       "Email address not verified",
+	CodePhoneNotVerified:       "Phone number not verified",
+	CodeInvalidEmail:           "Invalid email format",
+	CodeInvalidUsername:        "Invalid username format",
+	CodeEmptyPassword:          "Password cannot be empty",
+	CodeEmailAlreadyVerified:   "Email address already verified",
+	CodeOTPLocked:              "Too many failed attempts, please request a new OTP",
+	CodeOTPResendCooldown:      "Please wait before requesting a new OTP",
+	CodeOTPResendLimitExceeded: "Daily OTP limit reached, please try again tomorrow",
+	CodePasswordTooShort:       "Password is too short",
+	CodePasswordTooLong:        "Password is too long",
+	CodePasswordTooWeak:        "Password must contain both letters and digits",
+	CodePasswordTooCommon:      "Password is too common, please choose another one",
+	CodeInvalidPhoneNumber:     "Invalid phone number, use the international format e.g. +14155552671",
+	CodePhoneAlreadyVerified:   "Phone number already verified",
+	CodePhoneNumberMissing:     "No phone number has been added",
+	CodeInvalidTimezone:        "Unknown timezone, use an IANA name e.g. Europe/London",
+	CodeDeletionScheduled:      "Account deletion is already scheduled",
+	CodeDeletionNotScheduled:   "Account deletion is not scheduled",
+
+	// Auth related messages
+	CodeInvalidCredentials:   "Invalid username, email or password",
+	CodeAccountInactive:      "Account is inactive",
+	CodeInvalidRefreshToken:  "Invalid or expired refresh token",
+	CodeRefreshTokenReused:   "Refresh token reuse detected, session revoked",
+	CodeLoginInternalError:   "Internal server error occurred during login",
+	CodeUnauthorized:         "Missing or invalid access token",
+	CodeForbidden:            "You do not have permission to access this resource",
+	CodeInvalidResetToken:    "Invalid or expired password reset token",
+	CodeLoginLocked:          "Too many failed login attempts, please try again later",
+	CodeTwoFactorRequired:    "Two-factor authentication code required",
+	CodeInvalidTwoFactor:     "Invalid two-factor authentication code",
+	CodeTwoFactorEnabled:     "Two-factor authentication is already enabled",
+	CodeTwoFactorNotEnabled:  "Two-factor authentication is not enabled",
+	CodeTwoFactorNotEnrolled: "Two-factor authentication has not been set up",
+	CodeInvalidChallenge:     "Invalid or expired login challenge",
+	CodeSsoProviderNotFound:  "Single sign-on provider not found",
+	CodeInvalidSsoState:      "Invalid or expired single sign-on request",
+	CodeSsoFailed:            "Failed to sign in with the provider",
+	CodeSsoEmailNotVerified:  "The provider did not return a verified email address",
+	CodeIdentityLinked:       "This provider account is already linked",
+	CodeIdentityNotFound:     "Provider is not linked to this account",
 
 	// Mail related messages
 	CodeMailConfigMissing:    "Mail configuration is missing",
@@ -54,4 +210,86 @@ var msg = map[int]string{
 	CodeMailClientCreation:   "Failed to create mail client",
 	CodeMailConnectionFailed: "Failed to connect to mail service",
 	CodeMailSendFailed:       "Failed to send email",
+
+	// SMS related messages
+	CodeSmsSendFailed: "Failed to send SMS",
+
+	// Course related messages
+	CodeCourseNotFound:      "Course not found",
+	CodeCourseAlreadyExists: "A course with this code already exists in the semester",
+	CodeInvalidCredits:      "Credits are out of the allowed range",
+	CodeInvalidGrade:        "Grade is not valid on the course's grading scale",
+	CodeFailedGetCourse:     "Failed to retrieve course information",
+	CodeFailedUpdateCourse:  "Failed to update course information",
+
+	// Semester related messages
+	CodeSemesterNotFound:     "Semester not found",
+	CodeInvalidSemesterDates: "Semester end date must not be before its start date",
+	CodeSemesterOverlap:      "Semester overlaps another semester",
+	CodeSemesterHasCourses:   "Semester still has courses, move or delete them first",
+	CodeFailedGetSemester:    "Failed to retrieve semester information",
+	CodeFailedUpdateSemester: "Failed to update semester information",
+
+	// Lecturer related messages
+	CodeLecturerNotFound:      "Lecturer not found",
+	CodeLecturerAlreadyExists: "A lecturer with this name already exists",
+	CodeFailedGetLecturer:     "Failed to retrieve lecturer information",
+	CodeFailedUpdateLecturer:  "Failed to update lecturer information",
+
+	// Tag related messages
+	CodeTagNotFound:      "Tag not found",
+	CodeTagAlreadyExists: "A tag with this name already exists",
+	CodeFailedGetTag:     "Failed to retrieve tag information",
+	CodeFailedUpdateTag:  "Failed to update tag information",
+
+	// Reminder related messages
+	CodeReminderNotFound:     "Reminder not found",
+	CodeFailedGetReminder:    "Failed to retrieve reminder information",
+	CodeFailedUpdateReminder: "Failed to update reminder information",
+	CodeOccurrenceNotFound:   "The reminder has no occurrence on this date",
+	CodeInvalidRecurrence:    "Invalid recurrence rule",
+
+	// Class session related messages
+	CodeClassSessionNotFound:     "Class session not found",
+	CodeClassSessionConflict:     "The class session overlaps another session in the semester",
+	CodeInvalidSessionTime:       "Class session end time must be after its start time",
+	CodeFailedGetClassSession:    "Failed to retrieve class session information",
+	CodeFailedUpdateClassSession: "Failed to update class session information",
+
+	// Calendar related messages
+	CodeCalendarFeedNotFound:     "Calendar subscription not found",
+	CodeFailedGetCalendar:        "Failed to build the calendar",
+	CodeFailedUpdateCalendarFeed: "Failed to update the calendar subscription",
+	CodeInvalidCalendarFile:      "The file is not a valid iCalendar (.ics) file or is too large",
+	CodeFailedImportCalendar:     "Failed to import the calendar",
+
+	// Grading related messages
+	CodeGradingScaleNotFound:     "Grading scale not found",
+	CodeInvalidGradingScale:      "Invalid grading scale, check its bands and score range",
+	CodeFailedGetGradingScale:    "Failed to retrieve grading scale information",
+	CodeFailedUpdateGradingScale: "Failed to update grading scale information",
+	CodeFailedGetGPA:             "Failed to compute the GPA",
+
+	// Assessment related messages
+	CodeAssessmentNotFound:       "Assessment component not found",
+	CodeInvalidAssessmentWeights: "Assessment weights must sum to 100 percent",
+	CodeInvalidAssessmentScore:   "Assessment score must be between 0 and its max score",
+	CodeFailedGetAssessment:      "Failed to retrieve assessment information",
+	CodeFailedUpdateAssessment:   "Failed to update assessment information",
+
+	// Coursework related messages
+	CodeAssignmentNotFound:      "Assignment not found",
+	CodeExamNotFound:            "Exam not found",
+	CodeInvalidStatusTransition: "Status change not allowed, pending work moves on to in progress, submitted and graded",
+	CodeInvalidExamTime:         "Exam must end after it starts",
+	CodeFailedGetCoursework:     "Failed to retrieve assignment or exam information",
+	CodeFailedUpdateCoursework:  "Failed to update assignment or exam information",
+
+	// Note related messages
+	CodeNoteNotFound:         "Note not found",
+	CodeNoteRevisionNotFound: "Note revision not found",
+	CodeInvalidNoteContent:   "Note content is not a valid rich-text JSON document",
+	CodeNoteVersionConflict:  "The note was saved elsewhere since it was loaded, reload it and try again",
+	CodeFailedGetNote:        "Failed to retrieve note information",
+	CodeFailedUpdateNote:     "Failed to update note information",
 }
@@ -44,3 +44,14 @@ func ErrorResponse(c *gin.Context, code int, message string) {
 		Error:   nil,
 	})
 }
+
+// ErrorResponseWithContent returns an error code along with data the client needs to recover,
+// e.g. how long to wait before retrying
+func ErrorResponseWithContent(c *gin.Context, code int, data interface{}) {
+	c.JSON(http.StatusOK, ResponseData{
+		Code:    code,
+		Message: GetMessageByCode(code),
+		Content: data,
+		Error:   nil,
+	})
+}
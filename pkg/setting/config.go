@@ -1,5 +1,7 @@
 package setting
 
+import "time"
+
 // Config holds all configuration settings for the application
 type Config struct {
 	Server   ServerSetting   `mapstructure:"server"`
@@ -7,6 +9,12 @@ type Config struct {
 	Log      LogSetting      `mapstructure:"log"`
 	Redis    RedisSetting    `mapstructure:"redis"`
 	Resend   ResendSetting   `mapstructure:"resend"`
+	Jwt      JwtSetting      `mapstructure:"jwt"`
+	Password PasswordSetting `mapstructure:"password"`
+	Frontend FrontendSetting `mapstructure:"frontend"`
+	Oidc     OidcSetting     `mapstructure:"oidc"`
+	Sms      SmsSetting      `mapstructure:"sms"`
+	Reminder ReminderSetting `mapstructure:"reminder"`
 }
 
 // ServerSetting holds server configuration
@@ -14,6 +22,10 @@ type ServerSetting struct {
 	Port int    `mapstructure:"port"`
 	Host string `mapstructure:"host"`
 	Mode string `mapstructure:"mode"`
+
+	// PublicURL is the address clients reach the API on, e.g. "https://api.example.com",
+	// links handed out to other apps such as calendar feeds are made absolute with it
+	PublicURL string `mapstructure:"public_url"`
 }
 
 // DatabaseSetting holds database configuration
@@ -46,3 +58,55 @@ type RedisSetting struct {
 	Password string `mapstructure:"password"`
 	Database int    `mapstructure:"database"`
 }
+
+// JwtSetting holds token signing configuration
+type JwtSetting struct {
+	Secret          string `mapstructure:"secret"`
+	Issuer          string `mapstructure:"issuer"`
+	AccessTokenTTL  int    `mapstructure:"access_token_ttl"`  // seconds
+	RefreshTokenTTL int    `mapstructure:"refresh_token_ttl"` // seconds
+}
+
+// PasswordSetting holds the password policy
+type PasswordSetting struct {
+	MinLength             int  `mapstructure:"min_length"` // defaults to 8
+	MaxLength             int  `mapstructure:"max_length"` // capped at 72 (bcrypt limit)
+	RequireLetterAndDigit bool `mapstructure:"require_letter_and_digit"`
+	AllowCommon           bool `mapstructure:"allow_common"` // skip the common password list
+}
+
+// FrontendSetting holds the web client configuration used to build links in emails
+type FrontendSetting struct {
+	BaseURL string `mapstructure:"base_url"`
+}
+
+// OidcSetting holds the single sign-on providers keyed by provider name (e.g. "google")
+type OidcSetting struct {
+	Providers map[string]OidcProviderSetting `mapstructure:"providers"`
+}
+
+// OidcProviderSetting holds the client registration of one OpenID Connect provider
+type OidcProviderSetting struct {
+	IssuerURL    string   `mapstructure:"issuer_url"` // discovery is read from <issuer_url>/.well-known/openid-configuration
+	ClientID     string   `mapstructure:"client_id"`
+	ClientSecret string   `mapstructure:"client_secret"`
+	RedirectURL  string   `mapstructure:"redirect_url"`
+	Scopes       []string `mapstructure:"scopes"`      // defaults to openid, email, profile
+	TrustEmail   bool     `mapstructure:"trust_email"` // treat emails as verified when the provider omits email_verified
+}
+
+// SmsSetting holds sms delivery configuration
+type SmsSetting struct {
+	Provider string `mapstructure:"provider"`  // "console" (default) or "file"
+	FilePath string `mapstructure:"file_path"` // used by the file provider
+}
+
+// ReminderSetting holds reminder notification configuration
+type ReminderSetting struct {
+	Offsets       []time.Duration `mapstructure:"offsets"`        // how long before the due time to notify, defaults to 168h, 72h and 24h
+	Coursework    []time.Duration `mapstructure:"coursework"`     // reminders created before assignment deadlines and exam starts, defaults to 72h and 24h
+	Channels      []string        `mapstructure:"channels"`       // "email" (default) and/or "webhook"
+	MaxAttempts   int             `mapstructure:"max_attempts"`   // tries per notification before giving up, defaults to 5
+	WebhookURL    string          `mapstructure:"webhook_url"`    // receives a JSON POST per notification
+	WebhookSecret string          `mapstructure:"webhook_secret"` // signs webhook bodies with HMAC-SHA256 when set
+}
@@ -18,6 +18,7 @@ CREATE TABLE IF NOT EXISTS `semesters`
     `name`       VARCHAR(255) NOT NULL,
     `start_date` DATETIME     NOT NULL,
     `end_date`   DATETIME     NOT NULL,
+    `user_id`    CHAR(36)     NOT NULL,
     `updated_at` DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     `created_at` DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
@@ -44,6 +45,7 @@ CREATE TABLE IF NOT EXISTS `tags`
     `id`         INT PRIMARY KEY AUTO_INCREMENT,
     `name`       VARCHAR(255)  NOT NULL,
     `color`      VARCHAR(7)    DEFAULT '#808080' COMMENT 'Hex color code for UI display',
+    `user_id`    CHAR(36)      NOT NULL,
     `updated_at` DATETIME      NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     `created_at` DATETIME      NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
@@ -76,14 +78,19 @@ CREATE TABLE IF NOT EXISTS `reminders`
 CREATE INDEX `users_index_email` ON `users` (`email`);
 CREATE INDEX `users_index_username` ON `users` (`username`);
 CREATE INDEX `semesters_index_start_date` ON `semesters` (`start_date`);
+CREATE INDEX `semesters_index_user_id` ON `semesters` (`user_id`);
 CREATE INDEX `courses_index_user_id` ON `courses` (`user_id`);
 CREATE INDEX `courses_index_semester_id` ON `courses` (`semester_id`);
 CREATE INDEX `courses_index_course_id` ON `courses` (`course_id`);
-CREATE INDEX `tags_index_name` ON `tags` (`name`);
+CREATE UNIQUE INDEX `tags_index_user_id_name` ON `tags` (`user_id`, `name`);
 CREATE INDEX `course_tags_index_tag_id` ON `course_tags` (`tag_id`);
 CREATE INDEX `reminders_index_user_id` ON `reminders` (`user_id`);
 
 -- Add foreign keys
+ALTER TABLE `semesters`
+    ADD CONSTRAINT `fk_semesters_user_id`
+    FOREIGN KEY (`user_id`) REFERENCES `users` (`user_id`) ON DELETE CASCADE;
+
 ALTER TABLE `courses`
     ADD CONSTRAINT `fk_courses_user_id`
     FOREIGN KEY (`user_id`) REFERENCES `users` (`user_id`) ON DELETE CASCADE;
@@ -92,6 +99,10 @@ ALTER TABLE `courses`
     ADD CONSTRAINT `fk_courses_semester_id`
     FOREIGN KEY (`semester_id`) REFERENCES `semesters` (`id`) ON DELETE RESTRICT;
 
+ALTER TABLE `tags`
+    ADD CONSTRAINT `fk_tags_user_id`
+    FOREIGN KEY (`user_id`) REFERENCES `users` (`user_id`) ON DELETE CASCADE;
+
 ALTER TABLE `course_tags`
     ADD CONSTRAINT `fk_course_tags_course_id`
     FOREIGN KEY (`course_id`) REFERENCES `courses` (`id`) ON DELETE CASCADE;
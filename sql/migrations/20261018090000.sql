@@ -0,0 +1,17 @@
+-- Create "sessions" table
+CREATE TABLE `sessions` (
+  `session_id` char(36) NOT NULL,
+  `user_id` char(36) NOT NULL,
+  `refresh_token_hash` char(64) NOT NULL,
+  `user_agent` varchar(255) NULL,
+  `ip_address` varchar(45) NULL,
+  `expires_at` datetime(3) NOT NULL,
+  `revoked_at` datetime(3) NULL,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  PRIMARY KEY (`session_id`),
+  INDEX `idx_sessions_expires_at` (`expires_at`),
+  UNIQUE INDEX `idx_sessions_refresh_token_hash` (`refresh_token_hash`),
+  INDEX `idx_sessions_user_id` (`user_id`),
+  CONSTRAINT `fk_users_sessions` FOREIGN KEY (`user_id`) REFERENCES `users` (`user_id`) ON UPDATE NO ACTION ON DELETE CASCADE
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
@@ -0,0 +1,2 @@
+-- Modify "users" table
+ALTER TABLE `users` ADD COLUMN `role` varchar(32) NOT NULL DEFAULT "student" AFTER `is_phone_verified`;
@@ -0,0 +1,2 @@
+-- Modify "users" table
+ALTER TABLE `users` ADD COLUMN `totp_secret` varchar(64) NULL AFTER `role`, ADD COLUMN `is_totp_enabled` tinyint NOT NULL DEFAULT 0 AFTER `totp_secret`, ADD COLUMN `totp_recovery` text NULL AFTER `is_totp_enabled`;
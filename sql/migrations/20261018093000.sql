@@ -0,0 +1,14 @@
+-- Create "user_identities" table
+CREATE TABLE `user_identities` (
+  `identity_id` char(36) NOT NULL,
+  `user_id` char(36) NOT NULL,
+  `provider` varchar(64) NOT NULL,
+  `subject` varchar(255) NOT NULL,
+  `email` varchar(255) NULL,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  PRIMARY KEY (`identity_id`),
+  UNIQUE INDEX `idx_user_identities_provider_subject` (`provider`, `subject`),
+  UNIQUE INDEX `idx_user_identities_user_provider` (`user_id`, `provider`),
+  CONSTRAINT `fk_users_identities` FOREIGN KEY (`user_id`) REFERENCES `users` (`user_id`) ON UPDATE NO ACTION ON DELETE CASCADE
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
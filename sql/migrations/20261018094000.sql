@@ -0,0 +1,2 @@
+-- Modify "users" table
+ALTER TABLE `users` MODIFY COLUMN `phone_number` varchar(16) NULL;
@@ -0,0 +1,2 @@
+-- Modify "users" table
+ALTER TABLE `users` ADD COLUMN `display_name` varchar(255) NULL AFTER `totp_recovery`, ADD COLUMN `university` varchar(255) NULL AFTER `display_name`, ADD COLUMN `major` varchar(255) NULL AFTER `university`, ADD COLUMN `graduation_year` smallint NULL AFTER `major`, ADD COLUMN `timezone` varchar(64) NOT NULL DEFAULT "UTC" AFTER `graduation_year`, ADD COLUMN `deletion_scheduled_at` datetime(3) NULL AFTER `timezone`, ADD INDEX `idx_users_deletion_scheduled_at` (`deletion_scheduled_at`);
@@ -0,0 +1,2 @@
+-- Modify "courses" table
+ALTER TABLE `courses` ADD UNIQUE INDEX `idx_courses_user_semester_course` (`user_id`, `semester_id`, `course_id`);
@@ -0,0 +1,12 @@
+-- Modify "semesters" table
+ALTER TABLE `semesters` ADD COLUMN `user_id` char(36) NULL AFTER `end_date`, ADD COLUMN `backfill_source_id` bigint NULL;
+-- Backfill "semesters" owners: a semester goes to the first user with a course in it
+UPDATE `semesters` AS `s` JOIN (SELECT `semester_id`, MIN(`user_id`) AS `user_id` FROM `courses` GROUP BY `semester_id`) AS `c` ON `c`.`semester_id` = `s`.`id` SET `s`.`user_id` = `c`.`user_id`;
+-- Backfill "semesters" copies for every other user sharing a semester
+INSERT INTO `semesters` (`name`, `start_date`, `end_date`, `user_id`, `backfill_source_id`, `created_at`, `updated_at`) SELECT DISTINCT `s`.`name`, `s`.`start_date`, `s`.`end_date`, `c`.`user_id`, `s`.`id`, `s`.`created_at`, `s`.`updated_at` FROM `semesters` AS `s` JOIN `courses` AS `c` ON `c`.`semester_id` = `s`.`id` WHERE `c`.`user_id` <> `s`.`user_id`;
+-- Backfill "courses" to point at the copy owned by their user
+UPDATE `courses` AS `c` JOIN `semesters` AS `s` ON `s`.`backfill_source_id` = `c`.`semester_id` AND `s`.`user_id` = `c`.`user_id` SET `c`.`semester_id` = `s`.`id`;
+-- Drop semesters without courses, they have no owner to assign
+DELETE FROM `semesters` WHERE `user_id` IS NULL;
+-- Modify "semesters" table
+ALTER TABLE `semesters` DROP COLUMN `backfill_source_id`, MODIFY COLUMN `user_id` char(36) NOT NULL, ADD INDEX `idx_semesters_user_id` (`user_id`), ADD CONSTRAINT `fk_users_semesters` FOREIGN KEY (`user_id`) REFERENCES `users` (`user_id`) ON UPDATE NO ACTION ON DELETE CASCADE;
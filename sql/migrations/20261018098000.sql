@@ -0,0 +1,42 @@
+-- Create "lecturers" table
+CREATE TABLE `lecturers` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `user_id` char(36) NOT NULL,
+  `name` varchar(255) NOT NULL,
+  `email` varchar(255) NULL,
+  `office` varchar(255) NULL,
+  `office_hours` varchar(255) NULL,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_lecturers_user_name` (`user_id`, `name`),
+  CONSTRAINT `fk_users_lecturers` FOREIGN KEY (`user_id`) REFERENCES `users` (`user_id`) ON UPDATE NO ACTION ON DELETE CASCADE
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
+-- Create "course_lecturers" table
+CREATE TABLE `course_lecturers` (
+  `course_id` bigint NOT NULL,
+  `lecturer_id` bigint NOT NULL,
+  PRIMARY KEY (`course_id`, `lecturer_id`),
+  INDEX `idx_course_lecturers_course_id` (`course_id`),
+  INDEX `idx_course_lecturers_lecturer_id` (`lecturer_id`),
+  CONSTRAINT `fk_course_lecturers_course` FOREIGN KEY (`course_id`) REFERENCES `courses` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE,
+  CONSTRAINT `fk_course_lecturers_lecturer` FOREIGN KEY (`lecturer_id`) REFERENCES `lecturers` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
+-- Backfill "lecturers" from the comma-separated names, one row per distinct name and user
+INSERT INTO `lecturers` (`user_id`, `name`, `created_at`, `updated_at`)
+WITH RECURSIVE `split` (`course_id`, `user_id`, `name`, `rest`) AS (
+  SELECT `id`, `user_id`, SUBSTRING_INDEX(`lecturers`, ',', 1), IF(LOCATE(',', `lecturers`) > 0, SUBSTRING(`lecturers`, LOCATE(',', `lecturers`) + 1), NULL) FROM `courses`
+  UNION ALL
+  SELECT `course_id`, `user_id`, SUBSTRING_INDEX(`rest`, ',', 1), IF(LOCATE(',', `rest`) > 0, SUBSTRING(`rest`, LOCATE(',', `rest`) + 1), NULL) FROM `split` WHERE `rest` IS NOT NULL
+)
+SELECT `user_id`, MIN(LEFT(TRIM(`name`), 255)), NOW(3), NOW(3) FROM `split` WHERE TRIM(`name`) <> '' GROUP BY `user_id`, LEFT(TRIM(`name`), 255);
+-- Backfill "course_lecturers" links, names match case-insensitively like the unique index
+INSERT INTO `course_lecturers` (`course_id`, `lecturer_id`)
+WITH RECURSIVE `split` (`course_id`, `user_id`, `name`, `rest`) AS (
+  SELECT `id`, `user_id`, SUBSTRING_INDEX(`lecturers`, ',', 1), IF(LOCATE(',', `lecturers`) > 0, SUBSTRING(`lecturers`, LOCATE(',', `lecturers`) + 1), NULL) FROM `courses`
+  UNION ALL
+  SELECT `course_id`, `user_id`, SUBSTRING_INDEX(`rest`, ',', 1), IF(LOCATE(',', `rest`) > 0, SUBSTRING(`rest`, LOCATE(',', `rest`) + 1), NULL) FROM `split` WHERE `rest` IS NOT NULL
+)
+SELECT DISTINCT `s`.`course_id`, `l`.`id` FROM `split` AS `s` JOIN `lecturers` AS `l` ON `l`.`user_id` = `s`.`user_id` AND `l`.`name` = LEFT(TRIM(`s`.`name`), 255);
+-- Modify "courses" table
+ALTER TABLE `courses` DROP COLUMN `lecturers`;
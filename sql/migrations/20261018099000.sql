@@ -0,0 +1,21 @@
+-- Modify "tags" table
+ALTER TABLE `tags` ADD COLUMN `user_id` char(36) NULL AFTER `color`, ADD COLUMN `backfill_source_id` bigint NULL;
+-- Backfill "tags" owners: a tag goes to the first user with a course using it
+UPDATE `tags` AS `t` JOIN (SELECT `ct`.`tag_id`, MIN(`c`.`user_id`) AS `user_id` FROM `course_tags` AS `ct` JOIN `courses` AS `c` ON `c`.`id` = `ct`.`course_id` GROUP BY `ct`.`tag_id`) AS `o` ON `o`.`tag_id` = `t`.`id` SET `t`.`user_id` = `o`.`user_id`;
+-- Backfill "tags" copies for every other user sharing a tag
+INSERT INTO `tags` (`name`, `color`, `user_id`, `backfill_source_id`, `created_at`, `updated_at`) SELECT DISTINCT `t`.`name`, `t`.`color`, `c`.`user_id`, `t`.`id`, `t`.`created_at`, `t`.`updated_at` FROM `tags` AS `t` JOIN `course_tags` AS `ct` ON `ct`.`tag_id` = `t`.`id` JOIN `courses` AS `c` ON `c`.`id` = `ct`.`course_id` WHERE `c`.`user_id` <> `t`.`user_id`;
+-- Backfill "course_tags" to point at the copy owned by the course's user
+UPDATE `course_tags` AS `ct` JOIN `courses` AS `c` ON `c`.`id` = `ct`.`course_id` JOIN `tags` AS `t` ON `t`.`backfill_source_id` = `ct`.`tag_id` AND `t`.`user_id` = `c`.`user_id` SET `ct`.`tag_id` = `t`.`id`;
+-- Drop tags without courses, they have no owner to assign
+DELETE FROM `tags` WHERE `user_id` IS NULL;
+-- Merge tags sharing a name for the same user into the oldest one
+UPDATE `tags` AS `t` JOIN (SELECT `user_id`, `name`, MIN(`id`) AS `keep_id` FROM `tags` GROUP BY `user_id`, `name`) AS `k` ON `k`.`user_id` = `t`.`user_id` AND `k`.`name` = `t`.`name` SET `t`.`backfill_source_id` = `k`.`keep_id`;
+INSERT IGNORE INTO `course_tags` (`course_id`, `tag_id`) SELECT `ct`.`course_id`, `t`.`backfill_source_id` FROM `course_tags` AS `ct` JOIN `tags` AS `t` ON `t`.`id` = `ct`.`tag_id` WHERE `t`.`id` <> `t`.`backfill_source_id`;
+DELETE `ct` FROM `course_tags` AS `ct` JOIN `tags` AS `t` ON `t`.`id` = `ct`.`tag_id` WHERE `t`.`id` <> `t`.`backfill_source_id`;
+DELETE FROM `tags` WHERE `id` <> `backfill_source_id`;
+-- Modify "tags" table
+ALTER TABLE `tags` DROP COLUMN `backfill_source_id`, MODIFY COLUMN `user_id` char(36) NOT NULL, DROP INDEX `idx_tags_name`, ADD UNIQUE INDEX `idx_tags_user_name` (`user_id`, `name`), ADD CONSTRAINT `fk_users_tags` FOREIGN KEY (`user_id`) REFERENCES `users` (`user_id`) ON UPDATE NO ACTION ON DELETE CASCADE;
+-- Modify "course_tags" table
+ALTER TABLE `course_tags` DROP FOREIGN KEY `fk_course_tags_course`, DROP FOREIGN KEY `fk_course_tags_tag`;
+-- Modify "course_tags" table
+ALTER TABLE `course_tags` ADD CONSTRAINT `fk_course_tags_course` FOREIGN KEY (`course_id`) REFERENCES `courses` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE, ADD CONSTRAINT `fk_course_tags_tag` FOREIGN KEY (`tag_id`) REFERENCES `tags` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE;
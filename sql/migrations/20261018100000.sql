@@ -0,0 +1,22 @@
+-- Create "reminders" table
+CREATE TABLE `reminders` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `title` varchar(255) NOT NULL,
+  `description` text NULL,
+  `due_date` date NOT NULL,
+  `due_time` time NOT NULL,
+  `due_at` datetime(3) NOT NULL,
+  `user_id` char(36) NOT NULL,
+  `course_id` bigint NULL,
+  `type` tinyint NOT NULL DEFAULT 0,
+  `status` tinyint NOT NULL DEFAULT 0,
+  `completed_at` datetime(3) NULL,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_reminders_course_id` (`course_id`),
+  INDEX `idx_reminders_status_due_at` (`status`, `due_at`),
+  INDEX `idx_reminders_user_id` (`user_id`),
+  CONSTRAINT `fk_reminders_course` FOREIGN KEY (`course_id`) REFERENCES `courses` (`id`) ON UPDATE NO ACTION ON DELETE SET NULL,
+  CONSTRAINT `fk_users_reminders` FOREIGN KEY (`user_id`) REFERENCES `users` (`user_id`) ON UPDATE NO ACTION ON DELETE CASCADE
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
@@ -0,0 +1,31 @@
+-- Create "reminder_deliveries" table
+CREATE TABLE `reminder_deliveries` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `reminder_id` bigint NOT NULL,
+  `due_at` datetime(3) NOT NULL,
+  `offset_seconds` bigint NOT NULL,
+  `channel` varchar(16) NOT NULL,
+  `status` tinyint NOT NULL DEFAULT 0,
+  `attempts` bigint NOT NULL DEFAULT 0,
+  `next_attempt_at` datetime(3) NOT NULL,
+  `last_error` text NULL,
+  `sent_at` datetime(3) NULL,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_reminder_deliveries_status_next` (`status`, `next_attempt_at`),
+  UNIQUE INDEX `idx_reminder_deliveries_unique` (`reminder_id`, `due_at`, `offset_seconds`, `channel`),
+  CONSTRAINT `fk_reminder_deliveries_reminder` FOREIGN KEY (`reminder_id`) REFERENCES `reminders` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
+-- Create "reminder_delivery_attempts" table
+CREATE TABLE `reminder_delivery_attempts` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `delivery_id` bigint NOT NULL,
+  `attempt` bigint NOT NULL,
+  `status` tinyint NOT NULL,
+  `error` text NULL,
+  `created_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_reminder_delivery_attempts_delivery_id` (`delivery_id`),
+  CONSTRAINT `fk_reminder_delivery_attempts_delivery` FOREIGN KEY (`delivery_id`) REFERENCES `reminder_deliveries` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
@@ -0,0 +1,17 @@
+-- Modify "reminders" table
+ALTER TABLE `reminders` ADD COLUMN `rrule` varchar(255) NULL AFTER `completed_at`, ADD COLUMN `series_id` bigint NULL AFTER `rrule`, ADD COLUMN `occurrence_date` date NULL AFTER `series_id`, ADD INDEX `idx_reminders_series_id` (`series_id`), ADD CONSTRAINT `fk_reminders_series` FOREIGN KEY (`series_id`) REFERENCES `reminders` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE;
+-- Create "reminder_exdates" table
+CREATE TABLE `reminder_exdates` (
+  `reminder_id` bigint NOT NULL,
+  `date` date NOT NULL,
+  PRIMARY KEY (`reminder_id`, `date`),
+  CONSTRAINT `fk_reminders_ex_dates` FOREIGN KEY (`reminder_id`) REFERENCES `reminders` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
+-- Create "reminder_completions" table
+CREATE TABLE `reminder_completions` (
+  `reminder_id` bigint NOT NULL,
+  `occurrence_date` date NOT NULL,
+  `completed_at` datetime(3) NOT NULL,
+  PRIMARY KEY (`reminder_id`, `occurrence_date`),
+  CONSTRAINT `fk_reminders_completions` FOREIGN KEY (`reminder_id`) REFERENCES `reminders` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
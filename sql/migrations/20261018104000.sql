@@ -0,0 +1,10 @@
+-- Create "calendar_feeds" table
+CREATE TABLE `calendar_feeds` (
+  `user_id` char(36) NOT NULL,
+  `token_hash` char(64) NOT NULL,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  PRIMARY KEY (`user_id`),
+  UNIQUE INDEX `idx_calendar_feeds_token_hash` (`token_hash`),
+  CONSTRAINT `fk_users_calendar_feed` FOREIGN KEY (`user_id`) REFERENCES `users` (`user_id`) ON UPDATE NO ACTION ON DELETE CASCADE
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
@@ -0,0 +1,59 @@
+-- Create "grading_scales" table
+CREATE TABLE `grading_scales` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `name` varchar(255) NOT NULL,
+  `user_id` char(36) NULL,
+  `university` varchar(255) NULL,
+  `is_default` tinyint NOT NULL DEFAULT 0,
+  `type` tinyint NOT NULL DEFAULT 0,
+  `max_points` double NOT NULL,
+  `max_score` double NOT NULL,
+  `pass_score` double NOT NULL DEFAULT 0,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_grading_scales_university` (`university`),
+  INDEX `idx_grading_scales_user_id` (`user_id`),
+  CONSTRAINT `fk_users_grading_scales` FOREIGN KEY (`user_id`) REFERENCES `users` (`user_id`) ON UPDATE NO ACTION ON DELETE CASCADE
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
+-- Create "grading_bands" table
+CREATE TABLE `grading_bands` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `scale_id` bigint NOT NULL,
+  `letter` varchar(8) NOT NULL,
+  `min_score` double NOT NULL,
+  `points` double NOT NULL,
+  `is_passing` tinyint NOT NULL DEFAULT 1,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_grading_bands_scale_letter` (`scale_id`, `letter`),
+  CONSTRAINT `fk_grading_scales_bands` FOREIGN KEY (`scale_id`) REFERENCES `grading_scales` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
+-- Create "gpa_settings" table
+CREATE TABLE `gpa_settings` (
+  `user_id` char(36) NOT NULL,
+  `grading_scale_id` bigint NULL,
+  `retake_policy` varchar(16) NOT NULL DEFAULT "latest",
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  PRIMARY KEY (`user_id`),
+  INDEX `idx_gpa_settings_grading_scale_id` (`grading_scale_id`),
+  CONSTRAINT `fk_gpa_settings_grading_scale` FOREIGN KEY (`grading_scale_id`) REFERENCES `grading_scales` (`id`) ON UPDATE NO ACTION ON DELETE SET NULL,
+  CONSTRAINT `fk_users_gpa_setting` FOREIGN KEY (`user_id`) REFERENCES `users` (`user_id`) ON UPDATE NO ACTION ON DELETE CASCADE
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
+-- Seed the system scales, the 4.0 letter scale is the default, the 4.0 points scale keeps the grades stored as points
+INSERT INTO `grading_scales` (`id`, `name`, `is_default`, `type`, `max_points`, `max_score`, `pass_score`, `created_at`, `updated_at`) VALUES
+  (1, '4.0 letter scale', 1, 0, 4.0, 100, 0, NOW(3), NOW(3)),
+  (2, '5.0 letter scale', 0, 0, 5.0, 100, 0, NOW(3), NOW(3)),
+  (3, '10.0 scale', 0, 1, 10.0, 10, 5, NOW(3), NOW(3)),
+  (4, 'Percentage', 0, 1, 100, 100, 50, NOW(3), NOW(3)),
+  (5, '4.0 points', 0, 1, 4.0, 4.0, 1.0, NOW(3), NOW(3));
+INSERT INTO `grading_bands` (`scale_id`, `letter`, `min_score`, `points`, `is_passing`) VALUES
+  (1, 'A', 93, 4.0, 1), (1, 'A-', 90, 3.7, 1), (1, 'B+', 87, 3.3, 1), (1, 'B', 83, 3.0, 1), (1, 'B-', 80, 2.7, 1),
+  (1, 'C+', 77, 2.3, 1), (1, 'C', 73, 2.0, 1), (1, 'C-', 70, 1.7, 1), (1, 'D+', 67, 1.3, 1), (1, 'D', 65, 1.0, 1), (1, 'F', 0, 0, 0),
+  (2, 'A', 70, 5.0, 1), (2, 'B', 60, 4.0, 1), (2, 'C', 50, 3.0, 1), (2, 'D', 45, 2.0, 1), (2, 'E', 40, 1.0, 1), (2, 'F', 0, 0, 0);
+-- Modify "courses" table
+ALTER TABLE `courses` ADD COLUMN `grade` varchar(16) NULL AFTER `semester_id`, ADD COLUMN `is_pass_fail` tinyint NOT NULL DEFAULT 0 AFTER `grade`, ADD COLUMN `exclude_from_gpa` tinyint NOT NULL DEFAULT 0 AFTER `is_pass_fail`, ADD COLUMN `grading_scale_id` bigint NULL AFTER `exclude_from_gpa`, ADD INDEX `idx_courses_grading_scale_id` (`grading_scale_id`), ADD CONSTRAINT `fk_courses_grading_scale` FOREIGN KEY (`grading_scale_id`) REFERENCES `grading_scales` (`id`) ON UPDATE NO ACTION ON DELETE SET NULL;
+-- Backfill "grade" with the 4.0 points as they are, graded on the linear 4.0 points scale so no value is lost
+UPDATE `courses` SET `grade` = CAST(ROUND(`gpa`, 2) AS CHAR), `grading_scale_id` = 5 WHERE `gpa` > 0;
+-- Modify "courses" table
+ALTER TABLE `courses` DROP COLUMN `gpa`;
@@ -0,0 +1,15 @@
+-- Create "assessment_components" table
+CREATE TABLE `assessment_components` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `course_id` bigint NOT NULL,
+  `name` varchar(255) NOT NULL,
+  `weight` double NOT NULL,
+  `max_score` double NOT NULL,
+  `score` double NULL,
+  `due_date` date NULL,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_assessment_components_course_id` (`course_id`),
+  CONSTRAINT `fk_assessment_components_course` FOREIGN KEY (`course_id`) REFERENCES `courses` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
@@ -0,0 +1,36 @@
+-- Create "assignments" table
+CREATE TABLE `assignments` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `course_id` bigint NOT NULL,
+  `title` varchar(255) NOT NULL,
+  `description` text NULL,
+  `due_at` datetime(3) NOT NULL,
+  `weight` double NULL,
+  `status` tinyint NOT NULL DEFAULT 0,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_assignments_course_id` (`course_id`),
+  INDEX `idx_assignments_due_at` (`due_at`),
+  CONSTRAINT `fk_assignments_course` FOREIGN KEY (`course_id`) REFERENCES `courses` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
+-- Create "exams" table
+CREATE TABLE `exams` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `course_id` bigint NOT NULL,
+  `title` varchar(255) NOT NULL,
+  `description` text NULL,
+  `start_at` datetime(3) NOT NULL,
+  `end_at` datetime(3) NULL,
+  `location` varchar(255) NULL,
+  `weight` double NULL,
+  `status` tinyint NOT NULL DEFAULT 0,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_exams_course_id` (`course_id`),
+  INDEX `idx_exams_start_at` (`start_at`),
+  CONSTRAINT `fk_exams_course` FOREIGN KEY (`course_id`) REFERENCES `courses` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
+-- Modify "reminders" table
+ALTER TABLE `reminders` ADD COLUMN `assignment_id` bigint NULL AFTER `course_id`, ADD COLUMN `exam_id` bigint NULL AFTER `assignment_id`, ADD INDEX `idx_reminders_assignment_id` (`assignment_id`), ADD INDEX `idx_reminders_exam_id` (`exam_id`), ADD CONSTRAINT `fk_reminders_assignment` FOREIGN KEY (`assignment_id`) REFERENCES `assignments` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE, ADD CONSTRAINT `fk_reminders_exam` FOREIGN KEY (`exam_id`) REFERENCES `exams` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE;
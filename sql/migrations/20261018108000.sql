@@ -0,0 +1,45 @@
+-- Create "notes" table
+CREATE TABLE `notes` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `user_id` char(36) NOT NULL,
+  `course_id` bigint NULL,
+  `title` varchar(255) NOT NULL,
+  `format` varchar(16) NOT NULL DEFAULT "markdown",
+  `content` longtext NOT NULL,
+  `lecture_date` date NULL,
+  `version` bigint NOT NULL DEFAULT 1,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_notes_course_id` (`course_id`),
+  INDEX `idx_notes_lecture_date` (`lecture_date`),
+  INDEX `idx_notes_user_id` (`user_id`),
+  CONSTRAINT `fk_notes_course` FOREIGN KEY (`course_id`) REFERENCES `courses` (`id`) ON UPDATE NO ACTION ON DELETE SET NULL,
+  CONSTRAINT `fk_users_notes` FOREIGN KEY (`user_id`) REFERENCES `users` (`user_id`) ON UPDATE NO ACTION ON DELETE CASCADE
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
+-- Create "note_revisions" table
+CREATE TABLE `note_revisions` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `note_id` bigint NOT NULL,
+  `version` bigint NOT NULL,
+  `course_id` bigint NULL,
+  `title` varchar(255) NOT NULL,
+  `format` varchar(16) NOT NULL,
+  `content` longtext NOT NULL,
+  `lecture_date` date NULL,
+  `restored_from` bigint NULL,
+  `created_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_note_revisions_note_version` (`note_id`, `version`),
+  CONSTRAINT `fk_note_revisions_note` FOREIGN KEY (`note_id`) REFERENCES `notes` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
+-- Create "note_tags" table
+CREATE TABLE `note_tags` (
+  `note_id` bigint NOT NULL,
+  `tag_id` bigint NOT NULL,
+  PRIMARY KEY (`note_id`, `tag_id`),
+  INDEX `idx_note_tags_note_id` (`note_id`),
+  INDEX `idx_note_tags_tag_id` (`tag_id`),
+  CONSTRAINT `fk_note_tags_note` FOREIGN KEY (`note_id`) REFERENCES `notes` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE,
+  CONSTRAINT `fk_note_tags_tag` FOREIGN KEY (`tag_id`) REFERENCES `tags` (`id`) ON UPDATE NO ACTION ON DELETE CASCADE
+) CHARSET utf8mb4 COLLATE utf8mb4_0900_ai_ci;
@@ -0,0 +1,4 @@
+-- Modify "assignments" table
+ALTER TABLE `assignments` DROP COLUMN `weight`, ADD COLUMN `component_id` bigint NULL AFTER `due_at`, ADD COLUMN `reminder_offsets` varchar(255) NULL AFTER `component_id`, ADD INDEX `idx_assignments_component_id` (`component_id`), ADD CONSTRAINT `fk_assignments_component` FOREIGN KEY (`component_id`) REFERENCES `assessment_components` (`id`) ON UPDATE NO ACTION ON DELETE SET NULL;
+-- Modify "exams" table
+ALTER TABLE `exams` DROP COLUMN `weight`, ADD COLUMN `component_id` bigint NULL AFTER `location`, ADD COLUMN `reminder_offsets` varchar(255) NULL AFTER `component_id`, ADD INDEX `idx_exams_component_id` (`component_id`), ADD CONSTRAINT `fk_exams_component` FOREIGN KEY (`component_id`) REFERENCES `assessment_components` (`id`) ON UPDATE NO ACTION ON DELETE SET NULL;
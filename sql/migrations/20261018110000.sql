@@ -0,0 +1,2 @@
+-- Modify "reminders" table
+ALTER TABLE `reminders` ADD COLUMN `series_end_at` datetime(3) NULL AFTER `rrule`, ADD INDEX `idx_reminders_series_end_at` (`series_end_at`);